@@ -0,0 +1,253 @@
+package rui
+
+import "strings"
+
+const (
+	// Role is the constant for the "role" property tag.
+	// The "role" string property sets the ARIA role of the view, for example "alert", "status", "region", "dialog".
+	// If the property is not set then the built-in role of the view is used: "listbox" for ListView,
+	// "grid" for TableView with the cell or row selection. The empty string removes the built-in role.
+	Role = "role"
+	// AriaLabel is the constant for the "aria-label" property tag.
+	// The "aria-label" string property sets the text that labels the view for assistive technologies.
+	// The text is translated as the text of TextView.
+	AriaLabel = "aria-label"
+	// AriaLabelledBy is the constant for the "aria-labelledby" property tag.
+	// The "aria-labelledby" string property contains the space separated list of ids of views which label the view.
+	AriaLabelledBy = "aria-labelledby"
+	// AriaDescribedBy is the constant for the "aria-describedby" property tag.
+	// The "aria-describedby" string property contains the space separated list of ids of views which describe the view.
+	AriaDescribedBy = "aria-describedby"
+	// AriaLive is the constant for the "aria-live" property tag.
+	// The "aria-live" int property marks the view as a live region: the changes of its content are announced
+	// by screen readers. Valid values are AriaLiveOff (0), AriaLivePolite (1), and AriaLiveAssertive (2).
+	AriaLive = "aria-live"
+	// AriaAtomic is the constant for the "aria-atomic" property tag.
+	// The "aria-atomic" bool property defines whether the whole live region is announced when it is changed (true)
+	// or only the changed part (false).
+	AriaAtomic = "aria-atomic"
+	// AriaBusy is the constant for the "aria-busy" property tag.
+	// The "aria-busy" bool property defines that the view is being updated and the assistive technologies
+	// should wait until the update is finished.
+	AriaBusy = "aria-busy"
+	// AriaHidden is the constant for the "aria-hidden" property tag.
+	// The "aria-hidden" bool property hides the view from the assistive technologies.
+	AriaHidden = "aria-hidden"
+	// AriaExpanded is the constant for the "aria-expanded" property tag.
+	// The "aria-expanded" bool property defines whether the content controlled by the view is expanded or collapsed.
+	AriaExpanded = "aria-expanded"
+	// AriaModal is the constant for the "aria-modal" property tag.
+	// The "aria-modal" bool property defines whether the view is modal when it is displayed.
+	AriaModal = "aria-modal"
+)
+
+const (
+	// AriaLiveOff - value of the "aria-live" property: the changes are not announced
+	AriaLiveOff = 0
+	// AriaLivePolite - value of the "aria-live" property: the changes are announced when the user is idle
+	AriaLivePolite = 1
+	// AriaLiveAssertive - value of the "aria-live" property: the changes are announced immediately
+	AriaLiveAssertive = 2
+)
+
+// ariaBoolProperties is the list of bool properties that are output as "true"/"false" ARIA attributes
+var ariaBoolProperties = []string{AriaAtomic, AriaBusy, AriaExpanded, AriaHidden, AriaModal}
+
+// GetRole returns the ARIA role of the view: the value of the "role" property or the built-in role of the view.
+// If the second argument (subviewID) is "" then a value of the first argument (view) is returned
+func GetRole(view View, subviewID string) string {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		return view.ariaRole()
+	}
+	return ""
+}
+
+// GetAriaLabel returns the value of the "aria-label" property of the view.
+// If the second argument (subviewID) is "" then a value of the first argument (view) is returned
+func GetAriaLabel(view View, subviewID string) string {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if text, ok := stringProperty(view, AriaLabel, view.Session()); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// GetAriaLive returns the value of the "aria-live" property of the view: AriaLiveOff (0), AriaLivePolite (1),
+// or AriaLiveAssertive (2). If the second argument (subviewID) is "" then a value of the first argument (view) is returned
+func GetAriaLive(view View, subviewID string) int {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if value, ok := enumProperty(view, AriaLive, view.Session(), AriaLiveOff); ok {
+			return value
+		}
+	}
+	return AriaLiveOff
+}
+
+// viewRole returns the value of the "role" property or the built-in role if the property is not set
+func viewRole(view View, defaultRole string) string {
+	if role, ok := stringProperty(view, Role, view.Session()); ok {
+		return role
+	}
+	return defaultRole
+}
+
+// ariaText escapes the text of an attribute value of the HTML text placed in a script
+func ariaText(text string) string {
+	for _, ch := range []struct{ old, new string }{
+		{old: "&", new: `&amp;`},
+		{old: "\"", new: `&quot;`},
+		{old: "<", new: `&lt;`},
+	} {
+		if strings.Contains(text, ch.old) {
+			text = strings.ReplaceAll(text, ch.old, ch.new)
+		}
+	}
+	return textToJS(text)
+}
+
+// ariaLabelText returns the translated value of the "aria-label" property
+func ariaLabelText(view View) (string, bool) {
+	text, ok := stringProperty(view, AriaLabel, view.Session())
+	if ok && text != "" && !GetNotTranslate(view, "") {
+		text = viewText(view, text)
+	}
+	return text, ok
+}
+
+// ariaReferences converts the space separated list of view ids to the list of html ids.
+// An id which is not found among the views of the session is used as is
+func ariaReferences(view View, ids string) string {
+	session := view.Session()
+	roots := []View{}
+	if root := session.RootView(); root != nil {
+		roots = append(roots, root)
+	}
+	if manager := session.popupManager(); manager != nil {
+		for _, popup := range manager.popups {
+			roots = append(roots, popup.rootView())
+		}
+	}
+
+	findView := func(id string) View {
+		for _, root := range roots {
+			if root.ID() == id {
+				return root
+			}
+			if container, ok := root.(ParanetView); ok {
+				if result := viewByID(container, id); result != nil {
+					return result
+				}
+			}
+		}
+		return nil
+	}
+
+	result := []string{}
+	for _, id := range strings.Fields(ids) {
+		if ref := findView(id); ref != nil {
+			result = append(result, ref.htmlID())
+		} else {
+			result = append(result, id)
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+func ariaHTML(view View, buffer *strings.Builder) {
+	writeAttribute := func(name, value string) {
+		buffer.WriteString(name)
+		buffer.WriteString(`="`)
+		buffer.WriteString(ariaText(value))
+		buffer.WriteString(`" `)
+	}
+
+	if role := view.ariaRole(); role != "" {
+		writeAttribute(Role, role)
+	}
+
+	if text, ok := ariaLabelText(view); ok && text != "" {
+		writeAttribute(AriaLabel, text)
+	}
+
+	session := view.Session()
+	for _, tag := range []string{AriaLabelledBy, AriaDescribedBy} {
+		if ids, ok := stringProperty(view, tag, session); ok && ids != "" {
+			writeAttribute(tag, ariaReferences(view, ids))
+		}
+	}
+
+	if value, ok := enumProperty(view, AriaLive, session, AriaLiveOff); ok {
+		writeAttribute(AriaLive, enumProperties[AriaLive].cssValues[value])
+	}
+
+	for _, tag := range ariaBoolProperties {
+		if value, ok := boolProperty(view, tag, session); ok {
+			if value {
+				writeAttribute(tag, "true")
+			} else {
+				writeAttribute(tag, "false")
+			}
+		}
+	}
+}
+
+// updateAriaProperty updates the ARIA attribute of the view on the client side after the property change
+func updateAriaProperty(view View, tag string) {
+	htmlID := view.htmlID()
+	session := view.Session()
+	value := ""
+	ok := false
+
+	switch tag {
+	case Role:
+		value = view.ariaRole()
+		ok = value != ""
+
+	case AriaLabel:
+		value, ok = ariaLabelText(view)
+
+	case AriaLabelledBy, AriaDescribedBy:
+		if value, ok = stringProperty(view, tag, session); ok {
+			value = ariaReferences(view, value)
+		}
+
+	case AriaLive:
+		var n int
+		if n, ok = enumProperty(view, AriaLive, session, AriaLiveOff); ok {
+			value = enumProperties[AriaLive].cssValues[n]
+		}
+
+	default:
+		var b bool
+		if b, ok = boolProperty(view, tag, session); ok {
+			value = "false"
+			if b {
+				value = "true"
+			}
+		}
+	}
+
+	if ok && value != "" {
+		updateProperty(htmlID, tag, textToJS(value), session)
+	} else {
+		removeProperty(htmlID, tag, session)
+	}
+}
+
+func isAriaProperty(tag string) bool {
+	switch tag {
+	case Role, AriaLabel, AriaLabelledBy, AriaDescribedBy, AriaLive:
+		return true
+	}
+	return isPropertyInList(tag, ariaBoolProperties)
+}
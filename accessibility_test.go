@@ -0,0 +1,174 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAriaProperties(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	label := NewTextView(session, Params{ID: "label", Text: "Status"})
+	status := NewTextView(session, Params{
+		Role:           "status",
+		AriaLabelledBy: "label",
+		AriaLive:       "polite",
+		AriaAtomic:     true,
+		AriaHidden:     false,
+		AriaLabel:      `Say "hi"`,
+	})
+	session.rootView = NewListLayout(session, Params{Content: []View{label, status}})
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(status, buffer)
+	html := buffer.String()
+
+	for _, attr := range []string{
+		`role="status"`,
+		`aria-label="Say &quot;hi&quot;"`,
+		`aria-labelledby="` + label.htmlID() + `"`,
+		`aria-live="polite"`,
+		`aria-atomic="true"`,
+		`aria-hidden="false"`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+
+	if GetAriaLive(status, "") != AriaLivePolite || GetRole(status, "") != "status" {
+		t.Error("Invalid values of aria-live or role properties")
+	}
+}
+
+func TestCompositeViewRoles(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	list := NewListView(session, Params{Items: []string{"a", "b"}, Current: 1})
+	session.rootView = list
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(list, buffer)
+	html := buffer.String()
+
+	for _, attr := range []string{
+		`role="listbox"`,
+		`aria-activedescendant="` + list.htmlID() + `-1"`,
+		`id="` + list.htmlID() + `-0" class="ruiView ruiListItem" role="option" aria-selected="false"`,
+		`role="option" aria-selected="true"`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+
+	list.Set(Role, "")
+	if role := GetRole(list, ""); role != "" {
+		t.Errorf(`The role of ListView after setting "" is %s`, role)
+	}
+	list.Remove(Role)
+	if role := GetRole(list, ""); role != "listbox" {
+		t.Errorf(`The built-in role of ListView is %s`, role)
+	}
+
+	tabs := NewTabsLayout(session, Params{Content: []View{
+		NewView(session, Params{Title: "First"}),
+		NewView(session, Params{Title: "Second"}),
+	}})
+	buffer.Reset()
+	viewHTML(tabs, buffer)
+	html = buffer.String()
+	for _, attr := range []string{
+		`role="tablist"`,
+		`role="tab" aria-selected="true" aria-controls="` + tabs.htmlID() + `-page0"`,
+		`role="tab" aria-selected="false"`,
+		`role="tabpanel" aria-labelledby="` + tabs.htmlID() + `-1"`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+
+	popup := NewPopup(NewView(session, nil), Params{Title: "Dialog"})
+	buffer.Reset()
+	popup.html(buffer)
+	html = buffer.String()
+	for _, attr := range []string{`role="dialog"`, `aria-modal="true"`, `aria-labelledby="`} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+}
+
+func TestDropDownListAria(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	list := NewDropDownList(session, Params{
+		Items:         []string{"a", "b", "c"},
+		Current:       1,
+		DisabledItems: []interface{}{2},
+		AriaLabel:     "Size",
+	})
+	session.rootView = list
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(list, buffer)
+	html := buffer.String()
+
+	for _, attr := range []string{
+		`aria-label="Size"`,
+		`<option>a</option>`,
+		`<option selected>b</option>`,
+		`<option disabled>c</option>`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+
+	// the select element and its options use the native semantics
+	for _, attr := range []string{`role=`, `aria-haspopup`, `aria-selected`, `aria-disabled`} {
+		if strings.Contains(html, attr) {
+			t.Errorf("%s is found in %s", attr, html)
+		}
+	}
+}
+
+func TestCloseButtonLabels(t *testing.T) {
+	createTestLog(t, false)
+
+	loadStringResources(ParseDataText(`strings:eo { Close = "Fermi" }`))
+	defer func() {
+		delete(stringResources, "eo")
+		delete(pluralResources, "eo")
+	}()
+
+	session := new(sessionData)
+	session.language = "eo"
+
+	tabs := NewTabsLayout(session, Params{
+		TabCloseButton: true,
+		Content:        []View{NewView(session, Params{Title: "First"})},
+	})
+	session.rootView = tabs
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(tabs, buffer)
+	if html := buffer.String(); !strings.Contains(html, `class="ruiTabCloseButton" role="button" aria-label="Fermi"`) {
+		t.Errorf("The label of the tab close button is not translated: %s", html)
+	}
+
+	popup := NewPopup(NewView(session, nil), Params{Title: "Dialog", CloseButton: true})
+	buffer.Reset()
+	popup.html(buffer)
+	if html := buffer.String(); !strings.Contains(html, `aria-label="Fermi"`) {
+		t.Errorf("The label of the popup close button is not translated: %s", html)
+	}
+}
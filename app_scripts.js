@@ -29,6 +29,13 @@ window.onload = function() {
 	};
 };
 
+const darkThemeMediaQuery = window.matchMedia("(prefers-color-scheme: dark)");
+if (darkThemeMediaQuery.addEventListener) {
+	darkThemeMediaQuery.addEventListener("change", function(event) {
+		sendMessage("dark-theme-changed{session=" + sessionID + ",dark=" + (event.matches ? "1" : "0") + "}");
+	});
+}
+
 function socketOpen() {
 
 	const touch_screen = (('ontouchstart' in document.documentElement) || (navigator.maxTouchPoints > 0) || (navigator.msMaxTouchPoints > 0)) ? "1" : "0";
@@ -207,6 +214,57 @@ function updateCSSRule(selector, ruleText) {
 	scanElementsSize();
 }
 
+function setThemeStyles(variables, rules) {
+	var elements = document.head.querySelectorAll("style[data-theme-section]");
+	for (var i = 0; i < elements.length; i++) {
+		elements[i].remove();
+	}
+	updateThemeStyles(variables, rules);
+}
+
+function updateThemeStyles(variables, rules) {
+	if (variables != null) {
+		var element = document.getElementById("ruiThemeVariables");
+		if (!element) {
+			element = document.createElement("style");
+			element.id = "ruiThemeVariables";
+			document.head.appendChild(element);
+		}
+		element.textContent = variables;
+	}
+
+	for (var i = 0; i < rules.length; i++) {
+		var id = "ruiThemeRule-" + rules[i][0];
+		var section = rules[i][1];
+		var text = rules[i][2];
+		var element = document.getElementById(id);
+		if (text == "") {
+			if (element) {
+				element.remove();
+			}
+			continue;
+		}
+
+		if (!element) {
+			// the rules of the media sections are placed after the main rules in the order of the sections
+			element = document.createElement("style");
+			element.id = id;
+			element.dataset.themeSection = section;
+			var next = null;
+			var elements = document.head.querySelectorAll("style[data-theme-section]");
+			for (var k = 0; k < elements.length; k++) {
+				if (Number(elements[k].dataset.themeSection) > section) {
+					next = elements[k];
+					break;
+				}
+			}
+			document.head.insertBefore(element, next);
+		}
+		element.textContent = text;
+	}
+	scanElementsSize();
+}
+
 function updateCSSStyle(elementId, style) {
 	var element = document.getElementById(elementId);
 	if (element) {
@@ -296,6 +354,7 @@ function activateTab(layoutId, tabNumber) {
 				var tab = document.getElementById(tabId);
 				if (tab) {	
 					tab.className = element.getAttribute(styleProperty);
+					tab.setAttribute("aria-selected", display == "" ? "true" : "false");
 					var page = document.getElementById(tab.getAttribute("data-view"));
 					if (page) {
 						page.style.display = display;
@@ -689,6 +748,7 @@ function selectListItem(element, item, needSendMessage) {
 			if (current.classList) {
 				current.classList.remove(focusStyle, blurStyle);
 			}
+			current.setAttribute("aria-selected", "false");
 			if (sendMessage) {
 				message = "itemUnselected{session=" + sessionID + ",id=" + element.id + "}";
 			}
@@ -706,7 +766,9 @@ function selectListItem(element, item, needSendMessage) {
 			}
 		}
 
+		item.setAttribute("aria-selected", "true");
 		element.setAttribute("data-current", item.id);
+		element.setAttribute("aria-activedescendant", item.id);
 		if (sendMessage) {
 			var number = getListItemNumber(item.id)
 			if (number != undefined) {
@@ -838,6 +900,7 @@ function getKey(event) {
 
 	if (event.keyCode) {
 		switch (event.keyCode) {
+			case 9: return "Tab";
 			case 13: return "Enter";
 			case 32: return " ";
 			case 33: return "PageUp";
@@ -926,6 +989,7 @@ function listViewFocusEvent(element, event) {
 			} 
 		}
 	}
+	focusEvent(element, event);
 }
 
 function listViewBlurEvent(element, event) {
@@ -939,6 +1003,7 @@ function listViewBlurEvent(element, event) {
 			}
 		}
 	}
+	blurEvent(element, event);
 }
 
 function selectRadioButton(radioButtonId) {
@@ -1413,6 +1478,7 @@ function tableViewFocusEvent(element, event) {
 			} 
 		}
 	}
+	focusEvent(element, event);
 }
 
 function tableViewBlurEvent(element, event) {
@@ -1424,6 +1490,7 @@ function tableViewBlurEvent(element, event) {
 			current.classList.add(getTableSelectedItemStyle(element));
 		}
 	}
+	blurEvent(element, event);
 }
 
 function setTableCellCursor(element, row, column) {
@@ -1441,10 +1508,15 @@ function setTableCellCursor(element, row, column) {
 			oldCell.classList.remove(focusStyle);
 			oldCell.classList.remove(getTableSelectedItemStyle(element));
 		}
+		if (oldCell) {
+			oldCell.setAttribute("aria-selected", "false");
+		}
 	}
 
 	cell.classList.add(focusStyle);
+	cell.setAttribute("aria-selected", "true");
 	element.setAttribute("data-current", cellID);
+	element.setAttribute("aria-activedescendant", cellID);
 	if (cell.scrollIntoViewIfNeeded) {
 		cell.scrollIntoViewIfNeeded()
 	} else {
@@ -1617,10 +1689,15 @@ function setTableRowCursor(element, row) {
 			oldRow.classList.remove(getTableSelectedItemStyle(element));
 
 		}
+		if (oldRow) {
+			oldRow.setAttribute("aria-selected", "false");
+		}
 	}
 
 	tableRow.classList.add(focusStyle);
+	tableRow.setAttribute("aria-selected", "true");
 	element.setAttribute("data-current", tableRowID);
+	element.setAttribute("aria-activedescendant", tableRowID);
 	if (tableRow.scrollIntoViewIfNeeded) {
 		tableRow.scrollIntoViewIfNeeded()
 	} else {
@@ -1789,4 +1866,291 @@ function tableRowClickEvent(element, event) {
 
 function stopEventPropagation(element, event) {
 	event.stopPropagation()
-}
\ No newline at end of file
+}
+
+var popupFocusStack = [];
+
+function isFocusableElement(element) {
+	if (element.tabIndex < 0 || element.disabled || element.getAttribute("data-disabled") == "1") {
+		return false;
+	}
+	if (element.getClientRects().length == 0) {
+		return false;
+	}
+	return window.getComputedStyle(element).visibility != "hidden";
+}
+
+function tabbableElements(root) {
+	const positive = [];
+	const natural = [];
+	const elements = root.querySelectorAll('[tabindex], a[href], area[href], button, input, select, textarea, summary, iframe, ' +
+		'audio[controls], video[controls], [contenteditable]:not([contenteditable="false"])');
+	for (var i = 0; i < elements.length; i++) {
+		const element = elements[i];
+		if (isFocusableElement(element)) {
+			if (element.tabIndex > 0) {
+				positive.push(element);
+			} else {
+				natural.push(element);
+			}
+		}
+	}
+	positive.sort(function(a, b) { return a.tabIndex - b.tabIndex; });
+	return positive.concat(natural);
+}
+
+function focusGroupOf(element) {
+	if (element && element.parentElement) {
+		return element.parentElement.closest("[data-focusgroup]");
+	}
+	return null;
+}
+
+function focusGroupItems(group) {
+	const result = [];
+	const elements = tabbableElements(group);
+	for (var i = 0; i < elements.length; i++) {
+		if (focusGroupOf(elements[i]) == group) {
+			result.push(elements[i]);
+		}
+	}
+	return result;
+}
+
+function focusGroupStop(group, items) {
+	if (items.indexOf(document.activeElement) >= 0) {
+		return document.activeElement;
+	}
+	const currentId = group.getAttribute("data-focuscurrent");
+	if (currentId) {
+		const current = document.getElementById(currentId);
+		if (current && items.indexOf(current) >= 0) {
+			return current;
+		}
+	}
+	return items[0];
+}
+
+// focusStops returns the list of elements visited by the Tab key: the subviews of
+// each focus group are replaced by one element
+function focusStops(root) {
+	const result = [];
+	const elements = tabbableElements(root);
+	for (var i = 0; i < elements.length; i++) {
+		const element = elements[i];
+		const group = focusGroupOf(element);
+		if (!group) {
+			result.push(element);
+		} else if (root.contains(group) || root == group) {
+			const items = focusGroupItems(group);
+			const stop = focusGroupStop(group, items);
+			if (element == stop) {
+				result.push(element);
+			}
+		} else {
+			result.push(element);
+		}
+	}
+	return result;
+}
+
+function focusRoot() {
+	const layer = document.getElementById("ruiPopupLayer");
+	if (layer && layer.lastElementChild) {
+		return layer.lastElementChild;
+	}
+	return document.body;
+}
+
+function focusNextElement(backward) {
+	const stops = focusStops(focusRoot());
+	if (stops.length == 0) {
+		return false;
+	}
+
+	var index = stops.indexOf(document.activeElement);
+	if (index < 0) {
+		const group = focusGroupOf(document.activeElement);
+		for (var i = 0; i < stops.length && group; i++) {
+			if (focusGroupOf(stops[i]) == group) {
+				index = i;
+				break;
+			}
+		}
+	}
+
+	if (index < 0 && document.activeElement && document.activeElement != document.body) {
+		// the focused element is not a stop: the nearest stop in the document order is used
+		const position = backward ? Node.DOCUMENT_POSITION_PRECEDING : Node.DOCUMENT_POSITION_FOLLOWING;
+		for (var i = 0; i < stops.length; i++) {
+			const stop = stops[backward ? stops.length - 1 - i : i];
+			if (document.activeElement.compareDocumentPosition(stop) & position) {
+				stop.focus();
+				return true;
+			}
+		}
+	}
+
+	if (index < 0) {
+		index = backward ? stops.length - 1 : 0;
+	} else if (backward) {
+		index = index > 0 ? index - 1 : stops.length - 1;
+	} else {
+		index = index < stops.length - 1 ? index + 1 : 0;
+	}
+
+	stops[index].focus();
+	return true;
+}
+
+function popupShown() {
+	const active = document.activeElement;
+	popupFocusStack.push(active && active != document.body ? active.id : "");
+	if (active && active != document.body) {
+		active.blur();
+	}
+
+	const root = focusRoot();
+	const stops = focusStops(root);
+	if (stops.length > 0) {
+		stops[0].focus();
+	} else {
+		const dialog = root.querySelector('[role="dialog"]');
+		if (dialog) {
+			dialog.focus();
+		}
+	}
+}
+
+function popupDismissed(index) {
+	if (index < 0 || index >= popupFocusStack.length) {
+		return;
+	}
+
+	const top = index == popupFocusStack.length - 1;
+	const id = popupFocusStack[index];
+	popupFocusStack.splice(index, 1);
+
+	if (top) {
+		const element = id ? document.getElementById(id) : null;
+		if (element && isFocusableElement(element)) {
+			element.focus();
+		} else {
+			const layer = document.getElementById("ruiPopupLayer");
+			if (layer && layer.lastElementChild) {
+				focusNextElement(false);
+			}
+		}
+	}
+}
+
+function moveFocusInGroup(group, event) {
+	const items = focusGroupItems(group);
+	var index = items.indexOf(document.activeElement);
+	if (index < 0) {
+		return false;
+	}
+
+	const direction = group.getAttribute("data-focusgroup");
+	const rtl = window.getComputedStyle(group).direction == "rtl";
+	const horizontal = direction == "horizontal" || direction == "both";
+	const vertical = direction == "vertical" || direction == "both";
+
+	switch (getKey(event)) {
+		case "ArrowLeft":
+			if (!horizontal) return false;
+			index += rtl ? 1 : -1;
+			break;
+
+		case "ArrowRight":
+			if (!horizontal) return false;
+			index += rtl ? -1 : 1;
+			break;
+
+		case "ArrowUp":
+			if (!vertical) return false;
+			index--;
+			break;
+
+		case "ArrowDown":
+			if (!vertical) return false;
+			index++;
+			break;
+
+		case "Home":
+			index = 0;
+			break;
+
+		case "End":
+			index = items.length - 1;
+			break;
+
+		default:
+			return false;
+	}
+
+	if (index < 0) {
+		index = items.length - 1;
+	} else if (index >= items.length) {
+		index = 0;
+	}
+
+	items[index].focus();
+	return true;
+}
+
+document.addEventListener("keydown", function(event) {
+	if (event.defaultPrevented || event.altKey || event.ctrlKey || event.metaKey) {
+		return;
+	}
+
+	if (getKey(event) == "Tab") {
+		// the focus is trapped in the top popup and the subviews of a focus group are one stop.
+		// In other cases the focus is moved by the browser
+		const layer = document.getElementById("ruiPopupLayer");
+		if ((layer && layer.lastElementChild) || focusGroupOf(document.activeElement)) {
+			if (focusNextElement(event.shiftKey)) {
+				event.preventDefault();
+			}
+		} else {
+			tabNavigation = true;
+		}
+		return;
+	}
+
+	const active = document.activeElement;
+	if (!active || active.tagName == "INPUT" || active.tagName == "TEXTAREA" || active.tagName == "SELECT") {
+		return;
+	}
+
+	const group = focusGroupOf(active);
+	if (group && moveFocusInGroup(group, event)) {
+		event.preventDefault();
+	}
+});
+
+// tabNavigation is true while the browser moves the focus by the Tab key
+var tabNavigation = false;
+
+document.addEventListener("keyup", function(event) {
+	tabNavigation = false;
+});
+
+document.addEventListener("focusin", function(event) {
+	const group = focusGroupOf(event.target);
+	if (group && tabNavigation && !(event.relatedTarget && group.contains(event.relatedTarget))) {
+		// the focus enters the group by the Tab key: the last focused subview of the group gets the focus
+		tabNavigation = false;
+		const currentId = group.getAttribute("data-focuscurrent");
+		const current = currentId ? document.getElementById(currentId) : null;
+		if (current && current != event.target && focusGroupItems(group).indexOf(current) >= 0) {
+			current.focus();
+			return;
+		}
+	}
+	tabNavigation = false;
+
+	if (group && event.target.id) {
+		group.setAttribute("data-focuscurrent", event.target.id);
+	}
+});
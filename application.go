@@ -2,10 +2,12 @@ package rui
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
-	"io"
 	"log"
 	"math/rand"
 	"net/http"
@@ -15,6 +17,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -39,6 +42,17 @@ type application struct {
 	params            AppParams
 	createContentFunc func(Session) SessionContent
 	sessions          map[int]Session
+	sessionsMutex     sync.RWMutex
+	startPage         startPageData
+}
+
+// startPageData is the start page cache. The page depends only on AppParams,
+// so it is created and compressed once
+type startPageData struct {
+	once sync.Once
+	data []byte
+	gzip []byte
+	hash string
 }
 
 // AppParams defines parameters of the app
@@ -59,6 +73,10 @@ type AppParams struct {
 	KeyFile string
 	// Redirect80 - if true then the function of redirect from port 80 to 443 is created
 	Redirect80 bool
+	// ThemeVariables - if true then the theme colors and size constants are output as CSS custom properties
+	// ("--ruiTextColor", etc.) and the styles refer to them by "var(...)". In this case the switching of
+	// the dark theme and of the custom theme updates the CSS variables without updating of the view styles
+	ThemeVariables bool
 }
 
 func (app *application) getStartPage() string {
@@ -106,8 +124,25 @@ func (app *application) getStartPage() string {
 	return buffer.String()
 }
 
+func (app *application) cachedStartPage() *startPageData {
+	page := &app.startPage
+	page.once.Do(func() {
+		page.data = []byte(app.getStartPage())
+
+		sum := sha256.Sum256(page.data)
+		page.hash = hex.EncodeToString(sum[:10])
+
+		buffer := new(bytes.Buffer)
+		writer := gzip.NewWriter(buffer)
+		if _, err := writer.Write(page.data); err == nil && writer.Close() == nil {
+			page.gzip = buffer.Bytes()
+		}
+	})
+	return page
+}
+
 func (app *application) Finish() {
-	for _, session := range app.sessions {
+	for _, session := range app.allSessions() {
 		session.close()
 	}
 
@@ -120,6 +155,9 @@ func (app *application) Finish() {
 }
 
 func (app *application) nextSessionID() int {
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+
 	n := rand.Intn(0x7FFFFFFE) + 1
 	_, ok := app.sessions[n]
 	for ok {
@@ -130,7 +168,27 @@ func (app *application) nextSessionID() int {
 }
 
 func (app *application) removeSession(id int) {
+	app.sessionsMutex.Lock()
 	delete(app.sessions, id)
+	app.sessionsMutex.Unlock()
+}
+
+func (app *application) session(id int) Session {
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+	return app.sessions[id]
+}
+
+// allSessions returns the list of the live sessions of the application
+func (app *application) allSessions() []Session {
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+
+	result := make([]Session, 0, len(app.sessions))
+	for _, session := range app.sessions {
+		result = append(result, session)
+	}
+	return result
 }
 
 func (app *application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -143,8 +201,8 @@ func (app *application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case "GET":
 		switch req.URL.Path {
 		case "/":
-			w.WriteHeader(http.StatusOK)
-			io.WriteString(w, app.getStartPage())
+			page := app.cachedStartPage()
+			serveData("index.html", page.data, page.gzip, page.hash, w, req)
 
 		case "/ws":
 			if brige := CreateSocketBrige(w, req); brige != nil {
@@ -196,7 +254,7 @@ func (app *application) socketReader(brige WebBrige) {
 			case "reconnect":
 				if sessionText, ok := obj.PropertyValue("session"); ok {
 					if sessionID, err := strconv.Atoi(sessionText); err == nil {
-						if session = app.sessions[sessionID]; session != nil {
+						if session = app.session(sessionID); session != nil {
 							session.setBrige(events, brige)
 							answer := allocStringBuilder()
 							defer freeStringBuilder(answer)
@@ -268,6 +326,12 @@ func sessionEventHandler(session Session, events chan DataObject, brige WebBrige
 		case "resize":
 			session.handleResize(data)
 
+		case "hot-reload":
+			session.handleHotReload(data)
+
+		case "dark-theme-changed":
+			session.handleDarkThemeChanged(data)
+
 		default:
 			session.handleViewEvent(command, data)
 		}
@@ -285,7 +349,9 @@ func (app *application) startSession(params DataObject, events chan DataObject,
 		return nil, ""
 	}
 
+	app.sessionsMutex.Lock()
 	app.sessions[session.ID()] = session
+	app.sessionsMutex.Unlock()
 
 	answer := allocStringBuilder()
 	defer freeStringBuilder(answer)
@@ -304,6 +370,14 @@ func (app *application) startSession(params DataObject, events chan DataObject,
 }
 
 var apps = []*application{}
+var appsMutex sync.Mutex
+
+// allApps returns the list of the started applications
+func allApps() []*application {
+	appsMutex.Lock()
+	defer appsMutex.Unlock()
+	return append([]*application{}, apps...)
+}
 
 // StartApp - create the new application and start it
 func StartApp(addr string, createContentFunc func(Session) SessionContent, params AppParams) {
@@ -311,7 +385,9 @@ func StartApp(addr string, createContentFunc func(Session) SessionContent, param
 	app.params = params
 	app.sessions = map[int]Session{}
 	app.createContentFunc = createContentFunc
+	appsMutex.Lock()
 	apps = append(apps, app)
+	appsMutex.Unlock()
 
 	redirectAddr := ""
 	if index := strings.IndexRune(addr, ':'); index >= 0 {
@@ -355,10 +431,12 @@ func StartApp(addr string, createContentFunc func(Session) SessionContent, param
 }
 
 func FinishApp() {
-	for _, app := range apps {
+	for _, app := range allApps() {
 		app.Finish()
 	}
+	appsMutex.Lock()
 	apps = []*application{}
+	appsMutex.Unlock()
 }
 
 func OpenBrowser(url string) bool {
@@ -668,15 +668,37 @@ func (border *borderProperty) cssWidth(builder cssBuilder, session Session) {
 
 func (border *borderProperty) cssColor(builder cssBuilder, session Session) {
 	borders := border.ViewBorders(session)
-	if borders.Top.Color == borders.Right.Color &&
-		borders.Top.Color == borders.Left.Color &&
-		borders.Top.Color == borders.Bottom.Color {
-		if borders.Top.Color != 0 {
-			builder.add("border-color", borders.Top.Color.cssString())
+	sides := []struct {
+		prefix string
+		color  Color
+	}{
+		{"top-", borders.Top.Color},
+		{"right-", borders.Right.Color},
+		{"bottom-", borders.Bottom.Color},
+		{"left-", borders.Left.Color},
+	}
+
+	values := make([]string, len(sides))
+	variables := false
+	for i, side := range sides {
+		value := border.getRaw(side.prefix + ColorTag)
+		if value == nil {
+			value = border.getRaw(ColorTag)
+		}
+		if variable, ok := session.themeVariable(value, true); ok {
+			values[i] = variable
+			variables = true
+		} else {
+			values[i] = side.color.cssString()
+		}
+	}
+
+	if values[0] == values[1] && values[0] == values[2] && values[0] == values[3] {
+		if variables || borders.Top.Color != 0 {
+			builder.add("border-color", values[0])
 		}
 	} else {
-		builder.addValues("border-color", " ", borders.Top.Color.cssString(),
-			borders.Right.Color.cssString(), borders.Bottom.Color.cssString(), borders.Left.Color.cssString())
+		builder.addValues("border-color", " ", values...)
 	}
 }
 
@@ -197,6 +197,9 @@ type Canvas interface {
 
 	// TextWidth calculates the width of the text drawn by a given font
 	TextWidth(text string, fontName string, fontSize SizeUnit) float64
+	// TextWidths calculates the widths of the texts drawn by a given font.
+	// All texts are measured by one request to the client, the result is cached by the session
+	TextWidths(texts []string, fontName string, fontSize SizeUnit) []float64
 
 	// SetTextBaseline sets the current text baseline used when drawing text. Valid values:
 	// AlphabeticBaseline (0), TopBaseline (1), MiddleBaseline (2), BottomBaseline (3),
@@ -266,6 +269,13 @@ type Canvas interface {
 	// StrokeText strokes — that is, draws the outlines of — the characters of a text string
 	// at the specified coordinates
 	StrokeText(x, y float64, text string)
+	// FillTextInRect draws a multi-line text in the rectangle (x, y, width, height) using the current font
+	// and the current FillStyle. The text is wrapped and aligned according to the layout parameters.
+	// Returns the number of drawn lines
+	FillTextInRect(x, y, width, height float64, text string, params TextLayoutParams) int
+	// TextLayout splits the text into lines that fit into the given width
+	// using the current font and the layout parameters
+	TextLayout(width float64, text string, params TextLayoutParams) []string
 
 	// DrawImage draws the image at the (x, y) position
 	DrawImage(x, y float64, image Image)
@@ -279,13 +289,17 @@ type Canvas interface {
 }
 
 type canvasData struct {
-	view   CanvasView
-	script strings.Builder
+	view      CanvasView
+	script    strings.Builder
+	font      canvasFont
+	fontStack []canvasFont
+	webFonts  []string
 }
 
 func newCanvas(view CanvasView) Canvas {
 	canvas := new(canvasData)
 	canvas.view = view
+	canvas.font = defaultCanvasFont()
 	canvas.script.Grow(4096)
 	canvas.script.WriteString(`const canvas = document.getElementById('`)
 	canvas.script.WriteString(view.htmlID())
@@ -310,7 +324,49 @@ ctx.scale(dpr, dpr);`)
 
 func (canvas *canvasData) finishDraw() string {
 	canvas.script.WriteString("\n")
-	return canvas.script.String()
+	if len(canvas.webFonts) == 0 {
+		return canvas.script.String()
+	}
+
+	// the drawing is postponed until the fonts from the "fonts" resource directory are loaded
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	writeWebFontsLoading(canvas.webFonts, buffer)
+	buffer.WriteString(".then(() => {\n")
+	buffer.WriteString(canvas.script.String())
+	buffer.WriteString("});\n")
+	return buffer.String()
+}
+
+// writeWebFontsLoading writes the JavaScript promise that is resolved when the fonts are loaded
+func writeWebFontsLoading(fonts []string, buffer *strings.Builder) {
+	buffer.WriteString("Promise.all([")
+	for i, font := range fonts {
+		if i > 0 {
+			buffer.WriteRune(',')
+		}
+		buffer.WriteString("document.fonts.load('")
+		buffer.WriteString(font)
+		buffer.WriteString("')")
+	}
+	buffer.WriteString("]).catch(() => {})")
+}
+
+// addWebFont remembers the font if it uses a family from the "fonts" resource directory
+func (canvas *canvasData) addWebFont(font canvasFont) {
+	for _, name := range strings.Split(font.name, ",") {
+		if fontResources.isWebFont(strings.Trim(name, " \n\"'")) {
+			text := canvas.fontText(font)
+			for _, f := range canvas.webFonts {
+				if f == text {
+					return
+				}
+			}
+			canvas.webFonts = append(canvas.webFonts, text)
+			return
+		}
+	}
 }
 
 func (canvas *canvasData) View() CanvasView {
@@ -333,10 +389,15 @@ func (canvas *canvasData) Height() float64 {
 
 func (canvas *canvasData) Save() {
 	canvas.script.WriteString("\nctx.save();")
+	canvas.fontStack = append(canvas.fontStack, canvas.font)
 }
 
 func (canvas *canvasData) Restore() {
 	canvas.script.WriteString("\nctx.restore();")
+	if n := len(canvas.fontStack); n > 0 {
+		canvas.font = canvas.fontStack[n-1]
+		canvas.fontStack = canvas.fontStack[:n-1]
+	}
 }
 
 func (canvas *canvasData) ClipRect(x, y, width, height float64) {
@@ -587,17 +648,13 @@ func (canvas *canvasData) writeFont(name string, script *strings.Builder) {
 		}
 
 	}
-	script.WriteString("';")
 }
 
 func (canvas *canvasData) SetFont(name string, size SizeUnit) {
-	canvas.script.WriteString("\nctx.font = '")
-	canvas.script.WriteString(size.cssString("1em"))
-	canvas.writeFont(name, &canvas.script)
+	canvas.SetFontWithParams(name, size, FontParams{})
 }
 
-func (canvas *canvasData) setFontWithParams(name string, size SizeUnit, params FontParams, script *strings.Builder) {
-	script.WriteString("\nctx.font = '")
+func (canvas *canvasData) writeFontText(name string, size SizeUnit, params FontParams, script *strings.Builder) {
 	if params.Italic {
 		script.WriteString("italic ")
 	}
@@ -640,53 +697,24 @@ func (canvas *canvasData) setFontWithParams(name string, size SizeUnit, params F
 	canvas.writeFont(name, script)
 }
 
+func (canvas *canvasData) setFontWithParams(name string, size SizeUnit, params FontParams, script *strings.Builder) {
+	script.WriteString("\nctx.font = '")
+	canvas.writeFontText(name, size, params, script)
+	script.WriteString("';")
+}
+
 func (canvas *canvasData) SetFontWithParams(name string, size SizeUnit, params FontParams) {
+	canvas.font = canvasFont{name: name, size: size, params: params}
+	canvas.addWebFont(canvas.font)
 	canvas.setFontWithParams(name, size, params, &canvas.script)
 }
 
 func (canvas *canvasData) TextWidth(text string, fontName string, fontSize SizeUnit) float64 {
-	script := allocStringBuilder()
-	defer freeStringBuilder(script)
-
-	script.WriteString(`const canvas = document.getElementById('`)
-	script.WriteString(canvas.View().htmlID())
-	script.WriteString(`');
-const ctx = canvas.getContext('2d');
-const dpr = window.devicePixelRatio || 1;
-var gradient;
-var path;
-ctx.save()
-ctx.scale(dpr, dpr);`)
-
-	canvas.setFontWithParams(fontName, fontSize, FontParams{}, script)
-	script.WriteString("\nvar w = ctx.measureText('")
-	canvas.writeStringArgs(text, script)
-	script.WriteString(`').width;
-sendMessage('answer{width=' + w + ', answerID=' + answerID + '}')`)
-
-	result := canvas.View().Session().runGetterScript(script.String())
-	switch result.Tag() {
-	case "answer":
-		if value, ok := result.PropertyValue("width"); ok {
-			w, err := strconv.ParseFloat(value, 32)
-			if err == nil {
-				return w
-			}
-			ErrorLog(err.Error())
-		}
-
-	case "error":
-		if text, ok := result.PropertyValue("errorText"); ok {
-			ErrorLog(text)
-		} else {
-			ErrorLog("error")
-		}
-
-	default:
-		ErrorLog("Unknown answer: " + result.Tag())
-	}
+	return canvas.measureTexts([]string{text}, canvasFont{name: fontName, size: fontSize})[0]
+}
 
-	return 0
+func (canvas *canvasData) TextWidths(texts []string, fontName string, fontSize SizeUnit) []float64 {
+	return canvas.measureTexts(texts, canvasFont{name: fontName, size: fontSize})
 }
 
 func (canvas *canvasData) SetTextBaseline(baseline int) {
@@ -0,0 +1,488 @@
+package rui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TextLayoutParams defines the parameters of the multi-line text drawing by FillTextInRect function
+type TextLayoutParams struct {
+	// Align - the horizontal alignment of lines. Valid values:
+	// LeftAlign (0), RightAlign (1), CenterAlign (2)
+	Align int
+	// VerticalAlign - the vertical alignment of the text block in the rectangle. Valid values:
+	// TopAlign (0), BottomAlign (1), CenterAlign (2)
+	VerticalAlign int
+	// LineHeight - the height of a line relative to the font size. If 0 then 1.2 is used
+	LineHeight float64
+	// MaxLines - the maximal number of lines. If 0 then the number of lines is limited only by the rectangle height
+	MaxLines int
+	// Ellipsis - if true then "…" is added to the end of the last line if the text is truncated
+	Ellipsis bool
+	// NoWrap - if true then the text is broken only at line feeds
+	NoWrap bool
+}
+
+const (
+	defaultTextLineHeight = 1.2
+	textEllipsis          = "…"
+	maxTextMetricsCount   = 4096
+)
+
+type canvasFont struct {
+	name   string
+	size   SizeUnit
+	params FontParams
+}
+
+func defaultCanvasFont() canvasFont {
+	return canvasFont{name: "sans-serif", size: Px(10)}
+}
+
+// isRelative returns true if the font size depends on the font size of the canvas element
+func (font canvasFont) isRelative() bool {
+	switch font.size.Type {
+	case SizeInEM, SizeInEX, SizeInPercent, Auto:
+		return true
+	}
+	return false
+}
+
+// sizeInPx returns the font size in pixels. Relative units are calculated for the 16px base font size,
+// use canvasData.fontSizeInPx to get the size of a relative font calculated by the client
+func (font canvasFont) sizeInPx() float64 {
+	const baseSize = 16
+	switch font.size.Type {
+	case SizeInPixel:
+		return font.size.Value
+	case SizeInEM:
+		return font.size.Value * baseSize
+	case SizeInEX:
+		return font.size.Value * baseSize / 2
+	case SizeInPercent:
+		return font.size.Value * baseSize / 100
+	case SizeInPt:
+		return font.size.Value * 96 / 72
+	case SizeInPc:
+		return font.size.Value * 16
+	case SizeInInch:
+		return font.size.Value * 96
+	case SizeInMM:
+		return font.size.Value * 96 / 25.4
+	case SizeInCM:
+		return font.size.Value * 96 / 2.54
+	}
+	return baseSize
+}
+
+// fontMetrics is the font size in pixels (0 if unknown) and widths of texts measured by the font
+type fontMetrics struct {
+	size   float64
+	widths map[string]float64
+}
+
+// textMetrics caches widths of texts measured on the client side.
+// The total number of cached widths of all fonts is limited by maxTextMetricsCount
+type textMetrics struct {
+	fonts map[string]*fontMetrics
+	count int
+	mutex sync.Mutex
+}
+
+func (metrics *textMetrics) get(font string, texts []string) ([]float64, []string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	result := make([]float64, len(texts))
+	missing := []string{}
+	var fontWidths map[string]float64
+	if fontData, ok := metrics.fonts[font]; ok {
+		fontWidths = fontData.widths
+	}
+	for i, text := range texts {
+		if width, ok := fontWidths[text]; ok {
+			result[i] = width
+		} else {
+			result[i] = -1
+			missing = append(missing, text)
+		}
+	}
+	return result, missing
+}
+
+func (metrics *textMetrics) fontSize(font string) (float64, bool) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if fontData, ok := metrics.fonts[font]; ok && fontData.size > 0 {
+		return fontData.size, true
+	}
+	return 0, false
+}
+
+func (metrics *textMetrics) set(font string, size float64, texts []string, widths []float64) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if metrics.fonts == nil || metrics.count+len(texts) > maxTextMetricsCount {
+		metrics.fonts = map[string]*fontMetrics{}
+		metrics.count = 0
+	}
+	fontData, ok := metrics.fonts[font]
+	if !ok {
+		fontData = &fontMetrics{widths: map[string]float64{}}
+		metrics.fonts[font] = fontData
+	}
+	if size > 0 {
+		fontData.size = size
+	}
+	for i, text := range texts {
+		if _, ok := fontData.widths[text]; !ok {
+			metrics.count++
+		}
+		fontData.widths[text] = widths[i]
+	}
+}
+
+func (canvas *canvasData) fontText(font canvasFont) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	canvas.writeFontText(font.name, font.size, font.params, buffer)
+	return buffer.String()
+}
+
+// fontSizeInPx returns the font size in pixels. The size of a relative font is calculated by the client
+// together with the text widths. If the size is not received then sizeInPx of the font is returned
+func (canvas *canvasData) fontSizeInPx(font canvasFont) float64 {
+	if !font.isRelative() {
+		return font.sizeInPx()
+	}
+
+	metrics := canvas.View().Session().textMetrics()
+	fontText := canvas.fontText(font)
+	if size, ok := metrics.fontSize(fontText); ok {
+		return size
+	}
+	canvas.measureTexts([]string{" "}, font)
+	if size, ok := metrics.fontSize(fontText); ok {
+		return size
+	}
+	return font.sizeInPx()
+}
+
+// measureTexts returns the widths of texts. Widths absent in the session cache are measured by one getter script.
+// The script also returns the font size in pixels used by fontSizeInPx
+func (canvas *canvasData) measureTexts(texts []string, font canvasFont) []float64 {
+	session := canvas.View().Session()
+	fontText := canvas.fontText(font)
+	result, missing := session.textMetrics().get(fontText, texts)
+	if len(missing) == 0 {
+		if _, ok := session.textMetrics().fontSize(fontText); ok || !font.isRelative() {
+			return result
+		}
+	}
+
+	unique := make([]string, 0, len(missing))
+	added := map[string]bool{}
+	for _, text := range missing {
+		if !added[text] {
+			added[text] = true
+			unique = append(unique, text)
+		}
+	}
+
+	script := allocStringBuilder()
+	defer freeStringBuilder(script)
+
+	webFont := false
+	for _, name := range strings.Split(font.name, ",") {
+		if fontResources.isWebFont(strings.Trim(name, " \n\"'")) {
+			webFont = true
+			break
+		}
+	}
+	if webFont {
+		writeWebFontsLoading([]string{fontText}, script)
+		script.WriteString(".then(() => {\n")
+	}
+
+	script.WriteString(`const canvas = document.getElementById('`)
+	script.WriteString(canvas.View().htmlID())
+	script.WriteString(`');
+const ctx = canvas.getContext('2d');
+ctx.save();
+ctx.font = '`)
+	script.WriteString(fontText)
+	script.WriteString(`';
+const texts = [`)
+	for i, text := range unique {
+		if i > 0 {
+			script.WriteRune(',')
+		}
+		script.WriteRune('\'')
+		canvas.writeStringArgs(text, script)
+		script.WriteRune('\'')
+	}
+	script.WriteString(`];
+const widths = texts.map(text => ctx.measureText(text).width);
+const fontSize = (ctx.font.match(/([\d.]+)px/) || [0, 0])[1];
+ctx.restore();
+sendMessage('answer{widths=[' + widths.join(',') + '], fontSize=' + fontSize + ', answerID=' + answerID + '}')`)
+	if webFont {
+		script.WriteString("\n});")
+	}
+
+	widths := make([]float64, len(unique))
+	answer := session.runGetterScript(script.String())
+	switch answer.Tag() {
+	case "answer":
+		if node := answer.PropertyWithTag("widths"); node != nil && node.Type() == ArrayNode && node.ArraySize() == len(unique) {
+			for i := range unique {
+				if value := node.ArrayElement(i); value != nil && !value.IsObject() {
+					w, err := strconv.ParseFloat(value.Value(), 64)
+					if err != nil {
+						ErrorLog(err.Error())
+						return canvas.zeroWidths(result)
+					}
+					widths[i] = w
+				}
+			}
+			fontSize := 0.0
+			if text, ok := answer.PropertyValue("fontSize"); ok {
+				fontSize, _ = strconv.ParseFloat(text, 64)
+			}
+			session.textMetrics().set(fontText, fontSize, unique, widths)
+		} else {
+			ErrorLog(`Invalid "widths" value of the answer`)
+			return canvas.zeroWidths(result)
+		}
+
+	case "error":
+		if text, ok := answer.PropertyValue("errorText"); ok {
+			ErrorLog(text)
+		} else {
+			ErrorLog("error")
+		}
+		return canvas.zeroWidths(result)
+
+	default:
+		ErrorLog("Unknown answer: " + answer.Tag())
+		return canvas.zeroWidths(result)
+	}
+
+	measured := map[string]float64{}
+	for i, text := range unique {
+		measured[text] = widths[i]
+	}
+	for i, text := range texts {
+		if result[i] < 0 {
+			result[i] = measured[text]
+		}
+	}
+	return result
+}
+
+func (canvas *canvasData) zeroWidths(widths []float64) []float64 {
+	for i, w := range widths {
+		if w < 0 {
+			widths[i] = 0
+		}
+	}
+	return widths
+}
+
+func (canvas *canvasData) TextLayout(width float64, text string, params TextLayoutParams) []string {
+	lines, _ := canvas.textLayout(width, canvas.measureLayoutText(text, params), params, params.MaxLines)
+	return lines
+}
+
+// textLayoutData is the text split into paragraphs and words with the widths of the words and their characters
+type textLayoutData struct {
+	words  [][]string
+	widths map[string]float64
+}
+
+// measureLayoutText splits the text into words and measures the words, the characters of the words,
+// the space, and the ellipsis by one request
+func (canvas *canvasData) measureLayoutText(text string, params TextLayoutParams) textLayoutData {
+	paragraphs := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	data := textLayoutData{words: [][]string{}, widths: map[string]float64{}}
+	measure := []string{" ", textEllipsis}
+	added := map[string]bool{" ": true, textEllipsis: true}
+	addText := func(text string) {
+		if !added[text] {
+			added[text] = true
+			measure = append(measure, text)
+		}
+	}
+
+	for _, paragraph := range paragraphs {
+		var list []string
+		if params.NoWrap {
+			list = []string{paragraph}
+		} else {
+			list = strings.Fields(paragraph)
+		}
+		data.words = append(data.words, list)
+		for _, word := range list {
+			addText(word)
+			for _, r := range word {
+				addText(string(r))
+			}
+		}
+	}
+
+	for i, w := range canvas.measureTexts(measure, canvas.font) {
+		data.widths[measure[i]] = w
+	}
+	return data
+}
+
+// textLayout splits the measured text into lines. The second result is true if the text was truncated
+func (canvas *canvasData) textLayout(width float64, data textLayoutData, params TextLayoutParams, maxLines int) ([]string, bool) {
+	widths := data.widths
+	spaceWidth := widths[" "]
+
+	runeWidths := func(runes []rune) []float64 {
+		result := make([]float64, len(runes))
+		for i, r := range runes {
+			result[i] = widths[string(r)]
+		}
+		return result
+	}
+
+	lines := []string{}
+	lineWidths := []float64{}
+	addLine := func(line string, lineWidth float64) bool {
+		if maxLines > 0 && len(lines) >= maxLines {
+			return false
+		}
+		lines = append(lines, line)
+		lineWidths = append(lineWidths, lineWidth)
+		return true
+	}
+
+	truncated := false
+layout:
+	for _, list := range data.words {
+		line := ""
+		lineWidth := 0.0
+		for _, word := range list {
+			wordWidth := widths[word]
+			if line != "" && lineWidth+spaceWidth+wordWidth <= width {
+				line += " " + word
+				lineWidth += spaceWidth + wordWidth
+				continue
+			}
+
+			if line != "" {
+				if !addLine(line, lineWidth) {
+					truncated = true
+					break layout
+				}
+				line, lineWidth = "", 0
+			}
+
+			if wordWidth <= width || params.NoWrap {
+				line, lineWidth = word, wordWidth
+				continue
+			}
+
+			// the word is wider than the rectangle, so it is broken by characters
+			runes := []rune(word)
+			for i, w := range runeWidths(runes) {
+				if line != "" && lineWidth+w > width {
+					if !addLine(line, lineWidth) {
+						truncated = true
+						break layout
+					}
+					line, lineWidth = "", 0
+				}
+				line += string(runes[i])
+				lineWidth += w
+			}
+		}
+
+		if !addLine(line, lineWidth) {
+			truncated = true
+			break
+		}
+	}
+
+	if truncated && params.Ellipsis && len(lines) > 0 {
+		last := len(lines) - 1
+		ellipsisWidth := widths[textEllipsis]
+		runes := []rune(lines[last])
+		lineWidth := lineWidths[last]
+		if lineWidth+ellipsisWidth > width {
+			charWidths := runeWidths(runes)
+			for len(runes) > 0 && lineWidth+ellipsisWidth > width {
+				lineWidth -= charWidths[len(runes)-1]
+				runes = runes[:len(runes)-1]
+			}
+		}
+		lines[last] = strings.TrimRight(string(runes), " ") + textEllipsis
+	}
+
+	return lines, truncated
+}
+
+func (canvas *canvasData) FillTextInRect(x, y, width, height float64, text string, params TextLayoutParams) int {
+	// the text is measured first, so the size of a relative font is received by the same request
+	data := canvas.measureLayoutText(text, params)
+	fontSize := canvas.fontSizeInPx(canvas.font)
+	lineHeight := params.LineHeight
+	if lineHeight <= 0 {
+		lineHeight = defaultTextLineHeight
+	}
+	lineHeight *= fontSize
+
+	maxLines := int(math.Floor(height/lineHeight + 1e-9))
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	if params.MaxLines > 0 && params.MaxLines < maxLines {
+		maxLines = params.MaxLines
+	}
+
+	lines, _ := canvas.textLayout(width, data, params, maxLines)
+	if len(lines) == 0 {
+		return 0
+	}
+
+	textHeight := lineHeight * float64(len(lines))
+	top := y
+	switch params.VerticalAlign {
+	case BottomAlign:
+		top = y + height - textHeight
+
+	case CenterAlign:
+		top = y + (height-textHeight)/2
+	}
+	top += (lineHeight - fontSize) / 2
+
+	left := x
+	canvas.script.WriteString("\nctx.save();\nctx.textBaseline = 'top';")
+	switch params.Align {
+	case RightAlign:
+		canvas.script.WriteString("\nctx.textAlign = 'right';")
+		left = x + width
+
+	case CenterAlign:
+		canvas.script.WriteString("\nctx.textAlign = 'center';")
+		left = x + width/2
+
+	default:
+		canvas.script.WriteString("\nctx.textAlign = 'left';")
+	}
+
+	for i, line := range lines {
+		canvas.FillText(left, top+float64(i)*lineHeight, line)
+	}
+	canvas.script.WriteString("\nctx.restore();")
+
+	return len(lines)
+}
@@ -0,0 +1,116 @@
+package rui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCanvasTextLayout(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	brige := new(testBrige)
+	session.brige = brige
+	canvas := newCanvas(NewCanvasView(session, nil)).(*canvasData)
+
+	checkLayout := func(width float64, text string, params TextLayoutParams, expected ...string) {
+		count := len(brige.getters)
+		lines := canvas.TextLayout(width, text, params)
+		if strings.Join(lines, "|") != strings.Join(expected, "|") {
+			t.Errorf(`TextLayout(%g, "%s") = %q, expected %q`, width, text, lines, expected)
+		}
+		if len(brige.getters) > count+1 {
+			t.Errorf(`TextLayout(%g, "%s"): %d requests`, width, text, len(brige.getters)-count)
+		}
+	}
+
+	checkLayout(100, "one two three four", TextLayoutParams{}, "one two", "three four")
+	checkLayout(100, "one\n\ntwo", TextLayoutParams{}, "one", "", "two")
+	checkLayout(35, "abcdefgh", TextLayoutParams{}, "abc", "def", "gh")
+	checkLayout(50, "one two three", TextLayoutParams{MaxLines: 1, Ellipsis: true}, "one…")
+	checkLayout(35, "xyzuvw", TextLayoutParams{MaxLines: 1, Ellipsis: true}, "xy…")
+	checkLayout(50, "one two three", TextLayoutParams{NoWrap: true}, "one two three")
+
+	// the measured texts are cached
+	count := len(brige.getters)
+	checkLayout(100, "one two three four", TextLayoutParams{}, "one two", "three four")
+	if len(brige.getters) != count {
+		t.Error("The cached texts are measured again")
+	}
+
+	widths := canvas.TextWidths([]string{"a", "abc", "a"}, "serif", Px(12))
+	if len(widths) != 3 || widths[0] != 10 || widths[1] != 30 || widths[2] != 10 {
+		t.Errorf("TextWidths = %v", widths)
+	}
+	count = len(brige.getters)
+	if canvas.TextWidth("abc", "serif", Px(12)) != 30 || len(brige.getters) != count {
+		t.Error("The cached text width is measured again")
+	}
+}
+
+func TestCanvasFillTextInRect(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	brige := new(testBrige)
+	session.brige = brige
+	canvas := newCanvas(NewCanvasView(session, nil)).(*canvasData)
+
+	// the size of the relative font (20px) is received together with the widths
+	canvas.SetFont("sans-serif", Em(2))
+	count := canvas.FillTextInRect(0, 0, 100, 50, "one two three four five six", TextLayoutParams{Align: CenterAlign})
+	if count != 2 {
+		t.Errorf("FillTextInRect = %d, expected 2", count)
+	}
+	if len(brige.getters) != 1 {
+		t.Errorf("FillTextInRect: %d requests, expected 1", len(brige.getters))
+	}
+
+	script := canvas.script.String()
+	for _, text := range []string{
+		`ctx.textAlign = 'center';`,
+		`ctx.fillText('one two',`,
+		`ctx.fillText('three four',`,
+	} {
+		if !strings.Contains(script, text) {
+			t.Errorf("%s is not found in the script", text)
+		}
+	}
+	if strings.Contains(script, "five") {
+		t.Error("The truncated line is drawn")
+	}
+
+	if size := canvas.fontSizeInPx(canvasFont{name: "serif", size: Pt(12)}); size != 16 {
+		t.Errorf("fontSizeInPx(12pt) = %g", size)
+	}
+}
+
+func TestTextMetricsCache(t *testing.T) {
+	metrics := new(textMetrics)
+	for i := 0; i < 100; i++ {
+		font := strconv.Itoa(i) + "px serif"
+		texts := make([]string, 100)
+		widths := make([]float64, 100)
+		for k := range texts {
+			texts[k] = strconv.Itoa(k)
+			widths[k] = float64(k)
+		}
+		metrics.set(font, float64(i), texts, widths)
+	}
+
+	count := 0
+	for _, font := range metrics.fonts {
+		count += len(font.widths)
+	}
+	if count > maxTextMetricsCount || count != metrics.count {
+		t.Errorf("%d widths are cached, count = %d", count, metrics.count)
+	}
+
+	if result, missing := metrics.get("99px serif", []string{"5", "x"}); len(missing) != 1 || result[0] != 5 || result[1] >= 0 {
+		t.Errorf("get = %v, %v", result, missing)
+	}
+	if size, ok := metrics.fontSize("99px serif"); !ok || size != 99 {
+		t.Errorf("fontSize = %g", size)
+	}
+}
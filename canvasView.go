@@ -116,6 +116,12 @@ func (canvasView *canvasViewData) onResize(self View, x, y, width, height float6
 	canvasView.Redraw()
 }
 
+func (canvasView *canvasViewData) themeChanged() {
+	if canvasView.created {
+		canvasView.Redraw()
+	}
+}
+
 // RedrawCanvasView finds CanvasView with canvasViewID and redraws it
 func RedrawCanvasView(rootView View, canvasViewID string) {
 	if canvas := CanvasViewByID(rootView, canvasViewID); canvas != nil {
@@ -0,0 +1,187 @@
+package rui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChartAdapter describes the data of LineChart, BarChart, PieChart and ScatterChart
+type ChartAdapter interface {
+	// SeriesCount returns number of data series
+	SeriesCount() int
+
+	// PointCount returns number of points in the series
+	PointCount(series int) int
+
+	// Value returns the value of the point of the series.
+	// PieChart uses only the first series, each point is a slice of the pie
+	Value(series, index int) float64
+}
+
+// ChartXValues describes the horizontal coordinates of ScatterChart and LineChart points.
+// If the adapter does not implement the ChartXValues interface then the point index is used as the X coordinate
+type ChartXValues interface {
+	XValue(series, index int) float64
+}
+
+// ChartSeriesTitles describes the titles of the chart series which are displayed in the legend and tooltips.
+// To set the titles, you must either implement the ChartSeriesTitles interface in the chart adapter
+// or assign the list of titles to the "series-titles" property.
+type ChartSeriesTitles interface {
+	SeriesTitle(series int) string
+}
+
+// ChartCategories describes the labels of the chart points. They are displayed on the horizontal axis
+// of LineChart and BarChart and in the legend of PieChart.
+// To set the labels, you must either implement the ChartCategories interface in the chart adapter
+// or assign the list of labels to the "categories" property.
+type ChartCategories interface {
+	Category(index int) string
+}
+
+// ChartSeriesColors describes the colors of the chart series (the colors of slices for PieChart).
+// If the adapter does not implement the ChartSeriesColors interface then
+// the "ruiChartColor1"..."ruiChartColor8" theme colors are used
+type ChartSeriesColors interface {
+	SeriesColor(index int) Color
+}
+
+type simpleChartAdapter struct {
+	content [][]float64
+}
+
+// NewSimpleChartAdapter creates the new ChartAdapter. Each element of the content is a data series.
+// When you assign [][]float64 value to the "content" property, it is converted to SimpleChartAdapter
+func NewSimpleChartAdapter(content [][]float64) ChartAdapter {
+	if content == nil {
+		return nil
+	}
+	adapter := new(simpleChartAdapter)
+	adapter.content = content
+	return adapter
+}
+
+func (adapter *simpleChartAdapter) SeriesCount() int {
+	return len(adapter.content)
+}
+
+func (adapter *simpleChartAdapter) PointCount(series int) int {
+	if series >= 0 && series < len(adapter.content) {
+		return len(adapter.content[series])
+	}
+	return 0
+}
+
+func (adapter *simpleChartAdapter) Value(series, index int) float64 {
+	if series >= 0 && series < len(adapter.content) && index >= 0 && index < len(adapter.content[series]) {
+		return adapter.content[series][index]
+	}
+	return 0
+}
+
+func parseChartSeries(tag, text string) ([]float64, bool) {
+	values := strings.Split(text, ",")
+	result := make([]float64, 0, len(values))
+	for _, value := range values {
+		value = strings.Trim(value, " \t\n\r")
+		if value == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			invalidPropertyValue(tag, text)
+			ErrorLog(err.Error())
+			return nil, false
+		}
+		result = append(result, f)
+	}
+	return result, true
+}
+
+func valueToChartAdapter(tag string, value interface{}) (ChartAdapter, bool) {
+	switch value := value.(type) {
+	case ChartAdapter:
+		return value, true
+
+	case [][]float64:
+		return NewSimpleChartAdapter(value), true
+
+	case []float64:
+		return NewSimpleChartAdapter([][]float64{value}), true
+
+	case string:
+		if series, ok := parseChartSeries(tag, value); ok {
+			return NewSimpleChartAdapter([][]float64{series}), true
+		}
+		return nil, false
+
+	case []string:
+		content := make([][]float64, len(value))
+		for i, text := range value {
+			series, ok := parseChartSeries(tag, text)
+			if !ok {
+				return nil, false
+			}
+			content[i] = series
+		}
+		return NewSimpleChartAdapter(content), true
+
+	case []DataValue:
+		content := make([][]float64, len(value))
+		for i, val := range value {
+			if val.IsObject() {
+				notCompatibleType(tag, val)
+				return nil, false
+			}
+			series, ok := parseChartSeries(tag, val.Value())
+			if !ok {
+				return nil, false
+			}
+			content[i] = series
+		}
+		return NewSimpleChartAdapter(content), true
+	}
+
+	notCompatibleType(tag, value)
+	return nil, false
+}
+
+func valueToChartLabels(tag string, value interface{}) ([]string, bool) {
+	switch value := value.(type) {
+	case []string:
+		return value, true
+
+	case string:
+		labels := strings.Split(value, ",")
+		for i, label := range labels {
+			labels[i] = strings.Trim(label, " \t\n\r")
+		}
+		return labels, true
+
+	case []DataValue:
+		labels := make([]string, len(value))
+		for i, val := range value {
+			if val.IsObject() {
+				notCompatibleType(tag, val)
+				return nil, false
+			}
+			labels[i] = val.Value()
+		}
+		return labels, true
+
+	case []interface{}:
+		labels := make([]string, len(value))
+		for i, val := range value {
+			text, ok := val.(string)
+			if !ok {
+				notCompatibleType(tag, val)
+				return nil, false
+			}
+			labels[i] = text
+		}
+		return labels, true
+	}
+
+	notCompatibleType(tag, value)
+	return nil, false
+}
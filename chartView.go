@@ -0,0 +1,860 @@
+package rui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Categories is the constant for the "categories" property tag.
+	// The "categories" property sets the labels of the chart points ([]string or the comma separated list).
+	// The labels are displayed on the horizontal axis of LineChart and BarChart and in the legend of PieChart.
+	Categories = "categories"
+
+	// SeriesTitles is the constant for the "series-titles" property tag.
+	// The "series-titles" property sets the titles of the chart series ([]string or the comma separated list).
+	// The titles are displayed in the legend and tooltips.
+	SeriesTitles = "series-titles"
+
+	// ShowLegend is the constant for the "show-legend" property tag.
+	// The bool "show-legend" property determines whether the chart legend is displayed. Default value is true.
+	ShowLegend = "show-legend"
+
+	// ShowAxes is the constant for the "show-axes" property tag.
+	// The bool "show-axes" property determines whether the chart axes are displayed. Default value is true.
+	// Not used by PieChart.
+	ShowAxes = "show-axes"
+
+	// ShowGrid is the constant for the "show-grid" property tag.
+	// The bool "show-grid" property determines whether the horizontal grid lines are displayed. Default value is true.
+	// Not used by PieChart.
+	ShowGrid = "show-grid"
+
+	// ShowTooltips is the constant for the "show-tooltips" property tag.
+	// The bool "show-tooltips" property determines whether a tooltip with the value of the point
+	// is displayed when the mouse hovers over it. Default value is true.
+	ShowTooltips = "show-tooltips"
+)
+
+// maxChartTicks is the maximal number of the axis ticks
+const maxChartTicks = 50
+
+const (
+	lineChart = iota
+	barChart
+	pieChart
+	scatterChart
+)
+
+// Chart is the common interface of LineChart, BarChart, PieChart and ScatterChart views.
+// The chart content is set by the "content" property, which can be assigned ChartAdapter, [][]float64, []float64,
+// []string (each element is the comma separated list of values of a series), or string (one series)
+type Chart interface {
+	CanvasView
+}
+
+type chartHitRegion struct {
+	// x, y, width, height - the bounds of a bar or a point
+	x, y, width, height float64
+	// startAngle, endAngle - the angles of a pie slice. Used only if width == 0 and height < 0
+	startAngle, endAngle float64
+	series, index        int
+}
+
+type chartData struct {
+	canvasViewData
+	kind       int
+	regions    []chartHitRegion
+	hover      int
+	hoverX     float64
+	hoverY     float64
+	pieCenterX float64
+	pieCenterY float64
+	pieRadius  float64
+}
+
+// NewLineChart creates the new line chart view
+func NewLineChart(session Session, params Params) Chart {
+	return newChartWithKind(session, params, lineChart)
+}
+
+// NewBarChart creates the new bar chart view
+func NewBarChart(session Session, params Params) Chart {
+	return newChartWithKind(session, params, barChart)
+}
+
+// NewPieChart creates the new pie chart view
+func NewPieChart(session Session, params Params) Chart {
+	return newChartWithKind(session, params, pieChart)
+}
+
+// NewScatterChart creates the new scatter chart view
+func NewScatterChart(session Session, params Params) Chart {
+	return newChartWithKind(session, params, scatterChart)
+}
+
+func newChartWithKind(session Session, params Params, kind int) Chart {
+	view := new(chartData)
+	view.kind = kind
+	view.Init(session)
+	setInitParams(view, params)
+	return view
+}
+
+func newLineChart(session Session) View {
+	return NewLineChart(session, nil)
+}
+
+func newBarChart(session Session) View {
+	return NewBarChart(session, nil)
+}
+
+func newPieChart(session Session) View {
+	return NewPieChart(session, nil)
+}
+
+func newScatterChart(session Session) View {
+	return NewScatterChart(session, nil)
+}
+
+// Init initialize fields of Chart by default values
+func (chart *chartData) Init(session Session) {
+	chart.canvasViewData.Init(session)
+	chart.tag = []string{"LineChart", "BarChart", "PieChart", "ScatterChart"}[chart.kind]
+	chart.drawer = chart.draw
+	chart.hover = -1
+}
+
+func (chart *chartData) String() string {
+	return getViewString(chart)
+}
+
+func (chart *chartData) normalizeTag(tag string) string {
+	tag = strings.ToLower(tag)
+	switch tag {
+	case "titles":
+		tag = SeriesTitles
+
+	case "labels":
+		tag = Categories
+	}
+	return tag
+}
+
+func (chart *chartData) Remove(tag string) {
+	chart.remove(chart.normalizeTag(tag))
+}
+
+func (chart *chartData) remove(tag string) {
+	switch tag {
+	case Content, Categories, SeriesTitles, ShowLegend, ShowAxes, ShowGrid, ShowTooltips:
+		if _, ok := chart.properties[tag]; ok {
+			delete(chart.properties, tag)
+			chart.hover = -1
+			chart.Redraw()
+			chart.propertyChangedEvent(tag)
+		}
+
+	case DrawFunction:
+
+	default:
+		chart.canvasViewData.remove(tag)
+		chart.restoreMouseHandlers(tag)
+	}
+}
+
+func (chart *chartData) Set(tag string, value interface{}) bool {
+	return chart.set(chart.normalizeTag(tag), value)
+}
+
+func (chart *chartData) set(tag string, value interface{}) bool {
+	if value == nil {
+		chart.remove(tag)
+		return true
+	}
+
+	switch tag {
+	case Content:
+		adapter, ok := valueToChartAdapter(tag, value)
+		if !ok {
+			return false
+		}
+		chart.properties[Content] = adapter
+
+	case Categories, SeriesTitles:
+		labels, ok := valueToChartLabels(tag, value)
+		if !ok {
+			return false
+		}
+		chart.properties[tag] = labels
+
+	case ShowLegend, ShowAxes, ShowGrid, ShowTooltips:
+		if !chart.setBoolProperty(tag, value) {
+			return false
+		}
+
+	case DrawFunction:
+		ErrorLogF(`"%s" property is not supported by %s`, tag, chart.tag)
+		return false
+
+	default:
+		if !chart.canvasViewData.set(tag, value) {
+			return false
+		}
+		chart.restoreMouseHandlers(tag)
+		return true
+	}
+
+	chart.hover = -1
+	chart.Redraw()
+	chart.propertyChangedEvent(tag)
+	return true
+}
+
+// restoreMouseHandlers restores the internal mouse handlers of the chart after
+// the removing of the user "mouse-move" and "mouse-out" listeners
+func (chart *chartData) restoreMouseHandlers(tag string) {
+	if chart.created && (tag == MouseMove || tag == MouseOut) && chart.getRaw(tag) == nil {
+		js := mouseEvents[tag]
+		updateProperty(chart.htmlID(), js.jsEvent, js.jsFunc+"(this, event)", chart.session)
+	}
+}
+
+func (chart *chartData) Get(tag string) interface{} {
+	return chart.get(chart.normalizeTag(tag))
+}
+
+func (chart *chartData) get(tag string) interface{} {
+	if tag == DrawFunction {
+		return nil
+	}
+	return chart.canvasViewData.get(tag)
+}
+
+func (chart *chartData) htmlProperties(self View, buffer *strings.Builder) {
+	chart.canvasViewData.htmlProperties(self, buffer)
+	for _, tag := range []string{MouseMove, MouseOut} {
+		if chart.getRaw(tag) == nil {
+			js := mouseEvents[tag]
+			buffer.WriteString(` ` + js.jsEvent + `="` + js.jsFunc + `(this, event)"`)
+		}
+	}
+}
+
+func (chart *chartData) handleCommand(self View, command string, data DataObject) bool {
+	switch command {
+	case MouseMove:
+		chart.setHover(dataFloatProperty(data, "x"), dataFloatProperty(data, "y"))
+
+	case MouseOut:
+		if chart.hover >= 0 {
+			chart.hover = -1
+			chart.Redraw()
+		}
+	}
+
+	return chart.canvasViewData.handleCommand(self, command, data)
+}
+
+func (chart *chartData) setHover(x, y float64) {
+	hover := -1
+	if chart.boolValue(ShowTooltips) {
+		for i := len(chart.regions) - 1; i >= 0; i-- {
+			if chart.regionContains(chart.regions[i], x, y) {
+				hover = i
+				break
+			}
+		}
+	}
+
+	if hover != chart.hover {
+		chart.hover = hover
+		chart.hoverX = x
+		chart.hoverY = y
+		chart.Redraw()
+	}
+}
+
+func (chart *chartData) regionContains(region chartHitRegion, x, y float64) bool {
+	if region.width == 0 && region.height < 0 {
+		dx, dy := x-chart.pieCenterX, y-chart.pieCenterY
+		if math.Hypot(dx, dy) > chart.pieRadius {
+			return false
+		}
+		angle := math.Atan2(dy, dx)
+		for angle < region.startAngle {
+			angle += 2 * math.Pi
+		}
+		return angle <= region.endAngle
+	}
+	return x >= region.x && x <= region.x+region.width && y >= region.y && y <= region.y+region.height
+}
+
+func (chart *chartData) Redraw() {
+	if chart.created {
+		chart.canvasViewData.Redraw()
+	}
+}
+
+func (chart *chartData) boolValue(tag string) bool {
+	if value, ok := boolStyledProperty(chart, tag); ok {
+		return value
+	}
+	return true
+}
+
+func (chart *chartData) labels(tag string) []string {
+	if value, ok := chart.properties[tag]; ok {
+		if labels, ok := value.([]string); ok {
+			return labels
+		}
+	}
+	return nil
+}
+
+func (chart *chartData) seriesTitle(adapter ChartAdapter, series int) string {
+	if titles := chart.labels(SeriesTitles); series < len(titles) {
+		return titles[series]
+	}
+	if titles, ok := adapter.(ChartSeriesTitles); ok {
+		return titles.SeriesTitle(series)
+	}
+	return ""
+}
+
+func (chart *chartData) category(adapter ChartAdapter, index int) string {
+	if categories := chart.labels(Categories); index < len(categories) {
+		return categories[index]
+	}
+	if categories, ok := adapter.(ChartCategories); ok {
+		return categories.Category(index)
+	}
+	return ""
+}
+
+func (chart *chartData) seriesColor(adapter ChartAdapter, series int) Color {
+	if colors, ok := adapter.(ChartSeriesColors); ok {
+		return colors.SeriesColor(series)
+	}
+	color, _ := chart.session.Color("ruiChartColor" + strconv.Itoa(series%8+1))
+	return color
+}
+
+func (chart *chartData) xValue(adapter ChartAdapter, series, index int) float64 {
+	if x, ok := adapter.(ChartXValues); ok {
+		return x.XValue(series, index)
+	}
+	return float64(index)
+}
+
+func (chart *chartData) themeColor(tag string) Color {
+	color, _ := chart.session.Color(tag)
+	return color
+}
+
+func (chart *chartData) themeSize(tag string, defaultValue float64) float64 {
+	if size, ok := sizeConstant(chart.session, tag); ok && size.Type == SizeInPixel {
+		return size.Value
+	}
+	return defaultValue
+}
+
+func formatChartValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func isFiniteChartValue(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// chartTicks returns "nice" values of the axis ticks for the range [min, max].
+// The result contains at least two values, the first is not greater than min, the last is not less than max
+func chartTicks(min, max float64, maxCount int) []float64 {
+	if maxCount < 2 {
+		maxCount = 2
+	} else if maxCount > maxChartTicks {
+		maxCount = maxChartTicks
+	}
+
+	if !isFiniteChartValue(min) || !isFiniteChartValue(max) {
+		return []float64{0, 1}
+	}
+	if min > max {
+		min, max = max, min
+	}
+	if min == max {
+		if min == 0 {
+			max = 1
+		} else {
+			min, max = min-math.Abs(min)/2, max+math.Abs(max)/2
+		}
+	}
+
+	rough := (max - min) / float64(maxCount-1)
+	if !isFiniteChartValue(rough) || rough <= 0 {
+		return []float64{min, max}
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(rough)))
+	step := magnitude * 10
+	for _, n := range []float64{1, 2, 2.5, 5} {
+		if rough <= n*magnitude {
+			step = n * magnitude
+			break
+		}
+	}
+
+	// the ticks are computed by the index, so the step which is less than the float precision
+	// does not lead to the infinite loop
+	start := math.Floor(min / step)
+	ticks := make([]float64, 0, maxCount+2)
+	for i := 0; i <= maxCount+1; i++ {
+		value := (start + float64(i)) * step
+		if len(ticks) == 0 || value > ticks[len(ticks)-1] {
+			ticks = append(ticks, value)
+		}
+		if value >= max {
+			break
+		}
+	}
+
+	if ticks[0] > min {
+		ticks = append([]float64{min}, ticks...)
+	}
+	if ticks[len(ticks)-1] < max {
+		ticks = append(ticks, max)
+	}
+	return ticks
+}
+
+func (chart *chartData) draw(canvas Canvas) {
+	chart.regions = []chartHitRegion{}
+	adapter, ok := chart.properties[Content].(ChartAdapter)
+	if !ok || adapter == nil || adapter.SeriesCount() == 0 {
+		return
+	}
+
+	fontName := GetFontName(chart, "")
+	if fontName == "" {
+		fontName = "sans-serif"
+	}
+	fontSize := GetTextSize(chart, "")
+	if fontSize.Type == Auto {
+		fontSize = Px(chart.themeSize("ruiChartTextSize", 12))
+	}
+	canvas.SetFont(fontName, fontSize)
+	font := canvasFont{name: fontName, size: fontSize}
+	lineHeight := font.sizeInPx() * defaultTextLineHeight
+	if data, ok := canvas.(*canvasData); ok {
+		lineHeight = data.fontSizeInPx(font) * defaultTextLineHeight
+	}
+
+	padding := chart.themeSize("ruiChartPadding", 8)
+	left, top := padding, padding
+	width, height := canvas.Width()-2*padding, canvas.Height()-2*padding
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	if chart.boolValue(ShowLegend) {
+		height -= chart.drawLegend(canvas, adapter, left, top+height, width, lineHeight, fontName, fontSize)
+	}
+
+	if chart.kind == pieChart {
+		chart.drawPie(canvas, adapter, left, top, width, height)
+	} else {
+		chart.drawXYChart(canvas, adapter, left, top, width, height, lineHeight, fontName, fontSize)
+	}
+
+	if chart.hover >= 0 && chart.hover < len(chart.regions) {
+		chart.drawTooltip(canvas, adapter, chart.regions[chart.hover], lineHeight, fontName, fontSize)
+	}
+}
+
+// drawLegend draws the legend at the bottom of the chart and returns its height
+func (chart *chartData) drawLegend(canvas Canvas, adapter ChartAdapter, left, bottom, width, lineHeight float64, fontName string, fontSize SizeUnit) float64 {
+	titles := []string{}
+	colors := []Color{}
+	if chart.kind == pieChart {
+		for i := 0; i < adapter.PointCount(0); i++ {
+			titles = append(titles, chart.category(adapter, i))
+			colors = append(colors, chart.seriesColor(adapter, i))
+		}
+	} else {
+		for i := 0; i < adapter.SeriesCount(); i++ {
+			titles = append(titles, chart.seriesTitle(adapter, i))
+			colors = append(colors, chart.seriesColor(adapter, i))
+		}
+	}
+
+	empty := true
+	for _, title := range titles {
+		if title != "" {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return 0
+	}
+
+	marker := lineHeight * 0.6
+	gap := lineHeight / 2
+	widths := canvas.TextWidths(titles, fontName, fontSize)
+
+	// split the legend items into rows
+	rows := [][]int{{}}
+	rowWidth := 0.0
+	for i := range titles {
+		itemWidth := marker + gap/2 + widths[i]
+		last := len(rows) - 1
+		if len(rows[last]) > 0 && rowWidth+gap+itemWidth > width {
+			rows = append(rows, []int{})
+			last++
+			rowWidth = 0
+		}
+		if len(rows[last]) > 0 {
+			rowWidth += gap
+		}
+		rowWidth += itemWidth
+		rows[last] = append(rows[last], i)
+	}
+
+	legendHeight := lineHeight*float64(len(rows)) + gap
+	y := bottom - lineHeight*float64(len(rows))
+
+	canvas.SetTextBaseline(MiddleBaseline)
+	canvas.SetTextAlign(LeftAlign)
+	for _, row := range rows {
+		rowWidth := -gap
+		for _, i := range row {
+			rowWidth += gap + marker + gap/2 + widths[i]
+		}
+		x := left + (width-rowWidth)/2
+		for _, i := range row {
+			canvas.SetSolidColorFillStyle(colors[i])
+			canvas.FillRect(x, y+(lineHeight-marker)/2, marker, marker)
+			x += marker + gap/2
+			canvas.SetSolidColorFillStyle(chart.themeColor("ruiChartTextColor"))
+			canvas.FillText(x, y+lineHeight/2, titles[i])
+			x += widths[i] + gap
+		}
+		y += lineHeight
+	}
+
+	return legendHeight
+}
+
+func (chart *chartData) drawPie(canvas Canvas, adapter ChartAdapter, left, top, width, height float64) {
+	count := adapter.PointCount(0)
+	total := 0.0
+	for i := 0; i < count; i++ {
+		if value := adapter.Value(0, i); value > 0 && isFiniteChartValue(value) {
+			total += value
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	chart.pieRadius = math.Min(width, height) / 2
+	chart.pieCenterX = left + width/2
+	chart.pieCenterY = top + height/2
+
+	canvas.SetSolidColorStrokeStyle(chart.themeColor("ruiChartBackgroundColor"))
+	canvas.SetLineWidth(1)
+
+	angle := -math.Pi / 2
+	for i := 0; i < count; i++ {
+		value := adapter.Value(0, i)
+		if value <= 0 || !isFiniteChartValue(value) {
+			continue
+		}
+		sweep := 2 * math.Pi * value / total
+		path := NewPath()
+		path.MoveTo(chart.pieCenterX, chart.pieCenterY)
+		path.Arc(chart.pieCenterX, chart.pieCenterY, chart.pieRadius, angle, angle+sweep, true)
+		path.Close()
+		canvas.SetSolidColorFillStyle(chart.seriesColor(adapter, i))
+		canvas.FillAndStrokePath(path)
+
+		chart.regions = append(chart.regions, chartHitRegion{
+			height: -1, startAngle: angle, endAngle: angle + sweep, series: 0, index: i,
+		})
+		angle += sweep
+	}
+}
+
+func (chart *chartData) drawXYChart(canvas Canvas, adapter ChartAdapter, left, top, width, height, lineHeight float64, fontName string, fontSize SizeUnit) {
+	seriesCount := adapter.SeriesCount()
+	pointCount := 0
+	validCount := 0
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for series := 0; series < seriesCount; series++ {
+		count := adapter.PointCount(series)
+		if count > pointCount {
+			pointCount = count
+		}
+		for i := 0; i < count; i++ {
+			// NaN and infinite values are not drawn
+			if y, x := adapter.Value(series, i), chart.xValue(adapter, series, i); isFiniteChartValue(y) && isFiniteChartValue(x) {
+				minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+				minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+				validCount++
+			}
+		}
+	}
+	if validCount == 0 {
+		return
+	}
+	if chart.kind == barChart {
+		minY, maxY = math.Min(minY, 0), math.Max(maxY, 0)
+	}
+
+	yTicks := chartTicks(minY, maxY, int(height/(lineHeight*2.5)))
+	minY, maxY = yTicks[0], yTicks[len(yTicks)-1]
+
+	yLabels := make([]string, len(yTicks))
+	for i, tick := range yTicks {
+		yLabels[i] = formatChartValue(tick)
+	}
+
+	showAxes := chart.boolValue(ShowAxes)
+	gap := lineHeight / 3
+	if showAxes {
+		labelWidth := 0.0
+		for _, w := range canvas.TextWidths(yLabels, fontName, fontSize) {
+			labelWidth = math.Max(labelWidth, w)
+		}
+		left += labelWidth + gap
+		width -= labelWidth + gap
+		height -= lineHeight + gap
+		top += lineHeight / 2
+		height -= lineHeight / 2
+	}
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	scaleY := func(value float64) float64 {
+		return top + height - (value-minY)*height/(maxY-minY)
+	}
+
+	var xTicks []float64
+	var scaleX func(series, index int) float64
+	switch chart.kind {
+	case barChart:
+		band := width / float64(pointCount)
+		scaleX = func(series, index int) float64 {
+			return left + band*(float64(index)+0.5)
+		}
+
+	case lineChart:
+		if _, ok := adapter.(ChartXValues); !ok {
+			scaleX = func(series, index int) float64 {
+				if pointCount == 1 {
+					return left + width/2
+				}
+				return left + width*float64(index)/float64(pointCount-1)
+			}
+			break
+		}
+		fallthrough
+
+	default:
+		xTicks = chartTicks(minX, maxX, int(width/(lineHeight*5)))
+		minX, maxX = xTicks[0], xTicks[len(xTicks)-1]
+		scaleX = func(series, index int) float64 {
+			return left + (chart.xValue(adapter, series, index)-minX)*width/(maxX-minX)
+		}
+	}
+
+	// grid and axes
+	canvas.SetLineWidth(1)
+	if chart.boolValue(ShowGrid) {
+		canvas.SetSolidColorStrokeStyle(chart.themeColor("ruiChartGridColor"))
+		for _, tick := range yTicks {
+			y := math.Round(scaleY(tick)) + 0.5
+			canvas.DrawLine(left, y, left+width, y)
+		}
+	}
+
+	if showAxes {
+		canvas.SetSolidColorStrokeStyle(chart.themeColor("ruiChartAxisColor"))
+		canvas.DrawLine(left+0.5, top, left+0.5, top+height)
+		canvas.DrawLine(left, top+height+0.5, left+width, top+height+0.5)
+
+		canvas.SetSolidColorFillStyle(chart.themeColor("ruiChartTextColor"))
+		canvas.SetTextBaseline(MiddleBaseline)
+		canvas.SetTextAlign(RightAlign)
+		for i, tick := range yTicks {
+			canvas.FillText(left-gap, scaleY(tick), yLabels[i])
+		}
+
+		canvas.SetTextBaseline(TopBaseline)
+		canvas.SetTextAlign(CenterAlign)
+		if xTicks != nil {
+			labels := make([]string, len(xTicks))
+			for i, tick := range xTicks {
+				labels[i] = formatChartValue(tick)
+			}
+			for i, tick := range xTicks {
+				canvas.FillText(left+(tick-minX)*width/(maxX-minX), top+height+gap, labels[i])
+			}
+		} else {
+			labels := make([]string, pointCount)
+			for i := range labels {
+				labels[i] = chart.category(adapter, i)
+			}
+			maxWidth := 0.0
+			for _, w := range canvas.TextWidths(labels, fontName, fontSize) {
+				maxWidth = math.Max(maxWidth, w)
+			}
+			// skip labels if they overlap
+			step := 1
+			if maxWidth > 0 && pointCount > 1 {
+				distance := scaleX(0, 1) - scaleX(0, 0)
+				for float64(step)*distance < maxWidth+gap {
+					step++
+				}
+			}
+			for i := 0; i < pointCount; i += step {
+				canvas.FillText(scaleX(0, i), top+height+gap, labels[i])
+			}
+		}
+	}
+
+	switch chart.kind {
+	case barChart:
+		band := width / float64(pointCount)
+		barWidth := band * 0.8 / float64(seriesCount)
+		zero := scaleY(0)
+		for series := 0; series < seriesCount; series++ {
+			canvas.SetSolidColorFillStyle(chart.seriesColor(adapter, series))
+			for i := 0; i < adapter.PointCount(series); i++ {
+				value := adapter.Value(series, i)
+				if !isFiniteChartValue(value) {
+					continue
+				}
+				x := left + band*(float64(i)+0.1) + barWidth*float64(series)
+				y := scaleY(value)
+				region := chartHitRegion{x: x, y: math.Min(y, zero), width: barWidth, height: math.Abs(zero - y), series: series, index: i}
+				canvas.FillRect(region.x, region.y, region.width, region.height)
+				chart.regions = append(chart.regions, region)
+			}
+		}
+
+	default:
+		radius := chart.themeSize("ruiChartPointRadius", 3)
+		canvas.SetLineWidth(chart.themeSize("ruiChartLineWidth", 2))
+		canvas.SetLineJoin(RoundJoin)
+		for series := 0; series < seriesCount; series++ {
+			color := chart.seriesColor(adapter, series)
+			count := adapter.PointCount(series)
+			valid := func(i int) bool {
+				return isFiniteChartValue(adapter.Value(series, i)) && isFiniteChartValue(chart.xValue(adapter, series, i))
+			}
+
+			if chart.kind == lineChart && count > 1 {
+				// the line is broken at the invalid values
+				path := NewPath()
+				started := false
+				for i := 0; i < count; i++ {
+					if !valid(i) {
+						started = false
+					} else if started {
+						path.LineTo(scaleX(series, i), scaleY(adapter.Value(series, i)))
+					} else {
+						path.MoveTo(scaleX(series, i), scaleY(adapter.Value(series, i)))
+						started = true
+					}
+				}
+				canvas.SetSolidColorStrokeStyle(color)
+				canvas.StrokePath(path)
+			}
+
+			canvas.SetSolidColorFillStyle(color)
+			for i := 0; i < count; i++ {
+				if !valid(i) {
+					continue
+				}
+				x, y := scaleX(series, i), scaleY(adapter.Value(series, i))
+				canvas.FillEllipse(x, y, radius, radius, 0)
+				hit := radius * 2
+				chart.regions = append(chart.regions, chartHitRegion{x: x - hit, y: y - hit, width: 2 * hit, height: 2 * hit, series: series, index: i})
+			}
+		}
+	}
+}
+
+func (chart *chartData) drawTooltip(canvas Canvas, adapter ChartAdapter, region chartHitRegion, lineHeight float64, fontName string, fontSize SizeUnit) {
+	lines := []string{}
+	if title := chart.seriesTitle(adapter, region.series); title != "" && chart.kind != pieChart {
+		lines = append(lines, title)
+	}
+
+	value := formatChartValue(adapter.Value(region.series, region.index))
+	if chart.kind == scatterChart {
+		value = formatChartValue(chart.xValue(adapter, region.series, region.index)) + "; " + value
+	}
+	if category := chart.category(adapter, region.index); category != "" && chart.kind != scatterChart {
+		value = category + ": " + value
+	}
+	lines = append(lines, value)
+
+	padding := lineHeight / 3
+	tooltipWidth := 0.0
+	for _, w := range canvas.TextWidths(lines, fontName, fontSize) {
+		tooltipWidth = math.Max(tooltipWidth, w)
+	}
+	tooltipWidth += 2 * padding
+	tooltipHeight := lineHeight*float64(len(lines)) + 2*padding
+
+	x, y := chart.hoverX+12, chart.hoverY+12
+	if x+tooltipWidth > canvas.Width() {
+		x = chart.hoverX - 12 - tooltipWidth
+	}
+	if y+tooltipHeight > canvas.Height() {
+		y = chart.hoverY - 12 - tooltipHeight
+	}
+	x, y = math.Max(0, x), math.Max(0, y)
+
+	canvas.SetSolidColorFillStyle(chart.themeColor("ruiChartTooltipColor"))
+	canvas.SetSolidColorStrokeStyle(chart.themeColor("ruiChartAxisColor"))
+	canvas.SetLineWidth(1)
+	canvas.FillAndStrokeRoundedRect(x, y, tooltipWidth, tooltipHeight, padding)
+
+	canvas.SetSolidColorFillStyle(chart.themeColor("ruiChartTooltipTextColor"))
+	canvas.SetTextBaseline(MiddleBaseline)
+	canvas.SetTextAlign(LeftAlign)
+	for i, line := range lines {
+		canvas.FillText(x+padding, y+padding+lineHeight*(float64(i)+0.5), line)
+	}
+}
+
+// GetChartAdapter returns the ChartAdapter of the chart view.
+// If the second argument (subviewID) is "" then a value from the first argument (view) is returned.
+func GetChartAdapter(view View, subviewID string) ChartAdapter {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if adapter, ok := view.Get(Content).(ChartAdapter); ok {
+			return adapter
+		}
+	}
+	return nil
+}
+
+// ReloadChartData redraws the chart view using the current data of its adapter.
+// If the second argument (subviewID) is "" then the first argument (view) is redrawn.
+func ReloadChartData(view View, subviewID string) {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if chart, ok := view.(Chart); ok {
+		chart.Redraw()
+	}
+}
@@ -0,0 +1,155 @@
+package rui
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChartTicks(t *testing.T) {
+	checkTicks := func(min, max float64, maxCount int) []float64 {
+		ticks := chartTicks(min, max, maxCount)
+		if len(ticks) < 2 {
+			t.Errorf("chartTicks(%g, %g, %d) = %v: less than 2 ticks", min, max, maxCount, ticks)
+			return ticks
+		}
+		for i := 1; i < len(ticks); i++ {
+			if ticks[i] <= ticks[i-1] {
+				t.Errorf("chartTicks(%g, %g, %d) = %v: the ticks are not increasing", min, max, maxCount, ticks)
+				break
+			}
+		}
+		if len(ticks) > maxChartTicks+2 {
+			t.Errorf("chartTicks(%g, %g, %d): %d ticks", min, max, maxCount, len(ticks))
+		}
+		return ticks
+	}
+
+	ticks := checkTicks(0, 10, 6)
+	expected := []float64{0, 2, 4, 6, 8, 10}
+	if len(ticks) != len(expected) {
+		t.Errorf("chartTicks(0, 10, 6) = %v, expected %v", ticks, expected)
+	} else {
+		for i, tick := range ticks {
+			if tick != expected[i] {
+				t.Errorf("chartTicks(0, 10, 6) = %v, expected %v", ticks, expected)
+				break
+			}
+		}
+	}
+
+	if ticks := checkTicks(5, 5, 5); ticks[0] > 5 || ticks[len(ticks)-1] < 5 {
+		t.Errorf("chartTicks(5, 5, 5) = %v", ticks)
+	}
+
+	checkTicks(0, 0, 0)
+	checkTicks(-3.5, 7.25, 1000000)
+	checkTicks(10, -10, 4)
+	checkTicks(math.NaN(), 1, 5)
+	checkTicks(0, math.Inf(1), 5)
+	checkTicks(math.Inf(-1), math.Inf(1), 5)
+	checkTicks(-math.MaxFloat64, math.MaxFloat64, 5)
+	// the step is less than the float precision
+	checkTicks(1e17, 1e17+64, 10)
+	checkTicks(math.MaxFloat64/2, math.MaxFloat64, 5)
+}
+
+func TestChartContentValues(t *testing.T) {
+	createTestLog(t, false)
+
+	adapter, ok := valueToChartAdapter(Content, "1, 2.5, -3")
+	if !ok || adapter.SeriesCount() != 1 || adapter.PointCount(0) != 3 || adapter.Value(0, 1) != 2.5 {
+		t.Error(`valueToChartAdapter("1, 2.5, -3") failed`)
+	}
+
+	adapter, ok = valueToChartAdapter(Content, []string{"1, 2", "3, 4, 5"})
+	if !ok || adapter.SeriesCount() != 2 || adapter.PointCount(1) != 3 || adapter.Value(1, 2) != 5 {
+		t.Error(`valueToChartAdapter([]string) failed`)
+	}
+
+	if obj := ParseDataText(`_{ content = ["1, 2", "3"] }`); obj != nil {
+		if node := obj.PropertyWithTag("content"); node != nil {
+			adapter, ok = valueToChartAdapter(Content, node.ArrayElements())
+			if !ok || adapter.SeriesCount() != 2 || adapter.Value(1, 0) != 3 {
+				t.Error(`valueToChartAdapter([]DataValue) failed`)
+			}
+		}
+	}
+
+	labels, ok := valueToChartLabels(Categories, " Q1, Q2 ,Q3")
+	if !ok || len(labels) != 3 || labels[1] != "Q2" {
+		t.Errorf(`valueToChartLabels = %v`, labels)
+	}
+
+	if labels, ok = valueToChartLabels(Categories, []interface{}{"a", "b"}); !ok || len(labels) != 2 {
+		t.Errorf(`valueToChartLabels([]interface{}) = %v`, labels)
+	}
+
+	createTestLog(t, true)
+	if _, ok := valueToChartAdapter(Content, "1, x"); ok {
+		t.Error(`valueToChartAdapter("1, x") must fail`)
+	}
+	if _, ok := valueToChartAdapter(Content, 10); ok {
+		t.Error(`valueToChartAdapter(10) must fail`)
+	}
+	if _, ok := valueToChartLabels(Categories, []interface{}{"a", 1}); ok {
+		t.Error(`valueToChartLabels([]interface{}{"a", 1}) must fail`)
+	}
+}
+
+func TestChartHitTest(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	session.brige = new(testBrige)
+
+	content := [][]float64{{1, math.NaN(), 3, math.Inf(1)}, {2, 4, -1, 0}}
+	chart := NewBarChart(session, Params{Content: content, ShowTooltips: true}).(*chartData)
+	chart.frame = Frame{Width: 400, Height: 300}
+
+	chart.draw(newCanvas(chart))
+
+	// the NaN and infinite values are skipped
+	if len(chart.regions) != 6 {
+		t.Fatalf("%d hit regions, expected 6", len(chart.regions))
+	}
+
+	region := chart.regions[0]
+	if region.series != 0 || region.index != 0 {
+		t.Errorf("The first region: series = %d, index = %d", region.series, region.index)
+	}
+
+	chart.setHover(region.x+region.width/2, region.y+region.height/2)
+	if chart.hover != 0 {
+		t.Errorf("hover = %d, expected 0", chart.hover)
+	}
+
+	chart.setHover(-10, -10)
+	if chart.hover != -1 {
+		t.Errorf("hover = %d, expected -1", chart.hover)
+	}
+
+	pie := NewPieChart(session, Params{Content: []float64{1, math.NaN(), 1}}).(*chartData)
+	pie.frame = Frame{Width: 300, Height: 300}
+	pie.draw(newCanvas(pie))
+
+	if len(pie.regions) != 2 {
+		t.Fatalf("%d pie regions, expected 2", len(pie.regions))
+	}
+	// the first slice is drawn clockwise from the top
+	if !pie.regionContains(pie.regions[0], pie.pieCenterX+10, pie.pieCenterY-10) {
+		t.Error("The point is not found in the first slice")
+	}
+	if pie.regionContains(pie.regions[0], pie.pieCenterX-10, pie.pieCenterY+10) {
+		t.Error("The point is found in the first slice")
+	}
+	if pie.regionContains(pie.regions[1], pie.pieCenterX+1000, pie.pieCenterY) {
+		t.Error("The point outside the pie is found in the slice")
+	}
+
+	scatter := NewScatterChart(session, Params{Content: [][]float64{{math.NaN(), math.Inf(-1)}}}).(*chartData)
+	scatter.frame = Frame{Width: 300, Height: 300}
+	scatter.draw(newCanvas(scatter))
+	if len(scatter.regions) != 0 {
+		t.Errorf("%d regions of the chart without valid values", len(scatter.regions))
+	}
+}
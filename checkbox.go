@@ -2,6 +2,7 @@ package rui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -185,12 +186,33 @@ func (button *checkboxData) changedCheckboxState(state bool) {
 	for _, listener := range button.checkedListeners {
 		listener(button, state)
 	}
+	button.changedCheckboxImage(state)
+}
 
+func (button *checkboxData) changedCheckboxImage(state bool) {
 	buffer := allocStringBuilder()
 	defer freeStringBuilder(buffer)
 
 	button.htmlCheckbox(buffer, state)
 	button.Session().runScript(fmt.Sprintf(`updateInnerHTML('%v', '%v');`, button.htmlID()+"checkbox", buffer.String()))
+	updateProperty(button.htmlID(), "aria-checked", strconv.FormatBool(state), button.Session())
+}
+
+func (button *checkboxData) ariaRole() string {
+	return viewRole(button, "checkbox")
+}
+
+func (button *checkboxData) htmlProperties(self View, buffer *strings.Builder) {
+	button.viewsContainerData.htmlProperties(self, buffer)
+	buffer.WriteString(` aria-checked="`)
+	buffer.WriteString(strconv.FormatBool(button.checked()))
+	buffer.WriteRune('"')
+}
+
+func (button *checkboxData) themeChanged() {
+	if button.created {
+		button.changedCheckboxImage(button.checked())
+	}
 }
 
 func checkboxClickListener(view View) {
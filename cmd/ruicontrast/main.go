@@ -0,0 +1,104 @@
+// Command ruicontrast checks the contrast of the text and background colors of the theme styles
+// according to WCAG 2 level AA.
+//
+// All theme files (".rui" and ".json") of the directory are loaded, so a theme can extend
+// another theme of the directory. The violations are printed to the standard output and
+// the command exits with the status 1 if at least one violation is found.
+//
+// Usage:
+//
+//	go run github.com/anoshenko/rui/cmd/ruicontrast -themes resources/themes
+//
+// Flags:
+//
+//	-themes  the directory which contains the theme files (default "resources/themes")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anoshenko/rui"
+)
+
+func main() {
+	themesDir := flag.String("themes", "resources/themes", "the directory which contains the theme files")
+	flag.Parse()
+
+	themes, err := loadThemes(*themesDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ruicontrast:", err)
+		os.Exit(1)
+	}
+
+	count := 0
+	for _, file := range sortedKeys(themes) {
+		for _, violation := range rui.AuditThemeContrast(themes[file]) {
+			fmt.Printf("%s: %s\n", file, violation.String())
+			count++
+		}
+	}
+
+	if count > 0 {
+		fmt.Printf("%d contrast violation(s) found\n", count)
+		os.Exit(1)
+	}
+}
+
+// loadThemes loads and registers all theme files of the directory
+func loadThemes(dir string) (map[string]rui.Theme, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	themes := map[string]rui.Theme{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		name := file.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".rui" && ext != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var theme rui.Theme
+		if ext == ".json" {
+			obj, err := rui.ParseDataJSON(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", path, err.Error())
+			}
+			var ok bool
+			if theme, ok = rui.CreateThemeFromObject(obj); !ok {
+				return nil, fmt.Errorf("%s: invalid theme", path)
+			}
+		} else if theme, err = rui.CreateThemeFromTextWithError(string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err.Error())
+		}
+
+		rui.AddTheme(theme)
+		themes[path] = theme
+	}
+	return themes, nil
+}
+
+func sortedKeys(themes map[string]rui.Theme) []string {
+	keys := make([]string, 0, len(themes))
+	for key := range themes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
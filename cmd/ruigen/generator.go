@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/anoshenko/rui"
+)
+
+// viewTypes maps the view tags to the interface names which have the <Name>ByID function
+var viewTypes = map[string]string{
+	"ListLayout":   "ListLayout",
+	"StackLayout":  "StackLayout",
+	"GridLayout":   "GridLayout",
+	"ColumnLayout": "ColumnLayout",
+	"DetailsView":  "DetailsView",
+	"DropDownList": "DropDownList",
+	"TabsLayout":   "TabsLayout",
+	"ListView":     "ListView",
+	"TextView":     "TextView",
+	"Button":       "Button",
+	"Checkbox":     "Checkbox",
+	"EditView":     "EditView",
+	"ProgressBar":  "ProgressBar",
+	"ColorPicker":  "ColorPicker",
+	"NumberPicker": "NumberPicker",
+	"TimePicker":   "TimePicker",
+	"DatePicker":   "DatePicker",
+	"FilePicker":   "FilePicker",
+	"CanvasView":   "CanvasView",
+	"TableView":    "TableView",
+	"AudioPlayer":  "AudioPlayer",
+	"VideoPlayer":  "VideoPlayer",
+	"ImageView":    "ImageView",
+}
+
+type viewField struct {
+	name, id, viewType string
+}
+
+type viewFile struct {
+	resource string
+	name     string
+	fields   []viewField
+}
+
+// generate returns the Go source with the typed accessors for all files of the views directory
+func generate(viewsDir, stringsDir, packageName string) ([]byte, error) {
+	files := []viewFile{}
+	usedTexts := map[string]bool{}
+
+	err := filepath.Walk(viewsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") || !isDataFile(path) {
+			return nil
+		}
+
+		data, err := readDataFile(path)
+		if err != nil {
+			return err
+		}
+
+		resource, _ := filepath.Rel(viewsDir, path)
+		resource = filepath.ToSlash(resource)
+		file := viewFile{resource: resource, name: identifier(strings.TrimSuffix(resource, filepath.Ext(resource)))}
+
+		if data.Tag() == "Component" {
+			if content := data.PropertyObject("content"); content != nil {
+				data = content
+			}
+		}
+
+		fieldNames := map[string]bool{"Root": true}
+		ids := map[string]bool{}
+		collectViewFields(data, &file, fieldNames, ids, usedTexts)
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stringKeys, err := readStringKeys(stringsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("// Code generated by ruigen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buffer, "package %s\n\n", packageName)
+	if len(files) > 0 {
+		buffer.WriteString("import \"github.com/anoshenko/rui\"\n\n")
+	}
+
+	keys := []string{}
+	for _, key := range stringKeys {
+		if usedTexts[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) > 0 {
+		buffer.WriteString("// String resource keys used in the view files\nconst (\n")
+		names := map[string]bool{}
+		for _, key := range keys {
+			fmt.Fprintf(buffer, "\t%s = %s\n", uniqueName("String"+identifier(key), names), strconv.Quote(key))
+		}
+		buffer.WriteString(")\n\n")
+	}
+
+	for _, file := range files {
+		writeViewFile(buffer, file)
+	}
+
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting of the generated code: %w", err)
+	}
+	return code, nil
+}
+
+func isDataFile(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".rui", ".json":
+		return true
+	}
+	return false
+}
+
+func readDataFile(path string) (rui.DataObject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var object rui.DataObject
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		object, err = rui.ParseDataJSON(data)
+	} else {
+		object, err = rui.ParseDataTextWithError(string(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return object, nil
+}
+
+// collectViewFields adds the fields for all views with the "id" property and collects all text values
+func collectViewFields(object rui.DataObject, file *viewFile, fieldNames, ids, texts map[string]bool) {
+	if id, ok := object.PropertyValue("id"); ok && id != "" && !strings.Contains(id, "@") && !ids[id] {
+		ids[id] = true
+		viewType := viewTypes[object.Tag()]
+		file.fields = append(file.fields, viewField{
+			name:     uniqueName(identifier(id), fieldNames),
+			id:       id,
+			viewType: viewType,
+		})
+	}
+
+	var collectValue func(value rui.DataValue)
+	collectValue = func(value rui.DataValue) {
+		if value.IsObject() {
+			collectViewFields(value.Object(), file, fieldNames, ids, texts)
+		} else {
+			texts[value.Value()] = true
+		}
+	}
+
+	for i := 0; i < object.PropertyCount(); i++ {
+		node := object.Property(i)
+		switch node.Type() {
+		case rui.TextNode:
+			texts[node.Text()] = true
+
+		case rui.ObjectNode:
+			collectViewFields(node.Object(), file, fieldNames, ids, texts)
+
+		case rui.ArrayNode:
+			for _, value := range node.ArrayElements() {
+				collectValue(value)
+			}
+		}
+	}
+}
+
+// readStringKeys returns the sorted keys of all string resources
+func readStringKeys(stringsDir string) ([]string, error) {
+	keys := map[string]bool{}
+
+	addKeys := func(object rui.DataObject) {
+		for i := 0; i < object.PropertyCount(); i++ {
+			if node := object.Property(i); node.Type() == rui.TextNode {
+				keys[node.Tag()] = true
+			}
+		}
+	}
+
+	if _, err := os.Stat(stringsDir); err == nil {
+		err := filepath.Walk(stringsDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || strings.HasPrefix(info.Name(), ".") || !isDataFile(path) {
+				return nil
+			}
+
+			data, err := readDataFile(path)
+			if err != nil {
+				return err
+			}
+
+			if tag := data.Tag(); tag == "strings" {
+				for i := 0; i < data.PropertyCount(); i++ {
+					if node := data.Property(i); node.Type() == rui.ObjectNode {
+						addKeys(node.Object())
+					}
+				}
+			} else if strings.HasPrefix(tag, "strings:") {
+				addKeys(data)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func writeViewFile(buffer *bytes.Buffer, file viewFile) {
+	typeName := file.name + "View"
+
+	if len(file.fields) > 0 {
+		fmt.Fprintf(buffer, "// View ids of the %q view file\nconst (\n", file.resource)
+		for _, field := range file.fields {
+			fmt.Fprintf(buffer, "\t%s%sID = %s\n", file.name, field.name, strconv.Quote(field.id))
+		}
+		buffer.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(buffer, "// %s contains the views of the %q view file\ntype %s struct {\n\tRoot rui.View\n", typeName, file.resource, typeName)
+	for _, field := range file.fields {
+		viewType := field.viewType
+		if viewType == "" {
+			viewType = "View"
+		}
+		fmt.Fprintf(buffer, "\t%s rui.%s\n", field.name, viewType)
+	}
+	buffer.WriteString("}\n\n")
+
+	fmt.Fprintf(buffer, "// Create%s creates the view from the %q resource file and fills %s\n", typeName, file.resource, typeName)
+	fmt.Fprintf(buffer, "func Create%s(session rui.Session) *%s {\n", typeName, typeName)
+	fmt.Fprintf(buffer, "\troot := rui.CreateViewFromResources(session, %s)\n", strconv.Quote(file.resource))
+	buffer.WriteString("\tif root == nil {\n\t\treturn nil\n\t}\n")
+	fmt.Fprintf(buffer, "\treturn Bind%s(root)\n}\n\n", typeName)
+
+	fmt.Fprintf(buffer, "// Bind%s fills %s by the views of the root view created from the %q resource file\n", typeName, typeName, file.resource)
+	fmt.Fprintf(buffer, "func Bind%s(root rui.View) *%s {\n", typeName, typeName)
+	fmt.Fprintf(buffer, "\tresult := &%s{Root: root}\n", typeName)
+	for _, field := range file.fields {
+		viewType := field.viewType
+		if viewType == "" {
+			viewType = "View"
+		}
+		fmt.Fprintf(buffer, "\tresult.%s = rui.%sByID(root, %s%sID)\n", field.name, viewType, file.name, field.name)
+	}
+	buffer.WriteString("\treturn result\n}\n\n")
+}
+
+// identifier converts the text to the exported Go identifier: "user-name" -> "UserName"
+func identifier(text string) string {
+	buffer := new(strings.Builder)
+	upper := true
+	for _, ch := range text {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+			if buffer.Len() == 0 && unicode.IsDigit(ch) {
+				buffer.WriteRune('N')
+			}
+			if upper {
+				buffer.WriteRune(unicode.ToUpper(ch))
+				upper = false
+			} else {
+				buffer.WriteRune(ch)
+			}
+		} else {
+			upper = true
+		}
+	}
+
+	if buffer.Len() == 0 {
+		return "X"
+	}
+	return buffer.String()
+}
+
+// uniqueName adds the number to the name if it is already used
+func uniqueName(name string, used map[string]bool) string {
+	result := name
+	for n := 2; used[result]; n++ {
+		result = name + strconv.Itoa(n)
+	}
+	used[result] = true
+	return result
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	viewsDir := filepath.Join(dir, "views")
+	stringsDir := filepath.Join(dir, "strings")
+	if err := os.MkdirAll(filepath.Join(viewsDir, "dialogs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(stringsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(viewsDir, "dialogs", "login_form.rui"): `ListLayout {
+			id = login,
+			content = [
+				TextView { text = user_name_label },
+				EditView { id = user-name, hint = "Name" },
+				Button { id = submit, content = ok },
+				Button { id = submit, content = cancel },
+				LineChart { id = chart },
+			],
+		}`,
+		filepath.Join(stringsDir, "ru.rui"): `strings:ru { user_name_label = "Имя", ok = "OK", unused = "" }`,
+	}
+	for path, text := range files {
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	code, err := generate(viewsDir, stringsDir, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := string(code)
+	for _, expected := range []string{
+		"package app",
+		`StringOk            = "ok"`,
+		`StringUserNameLabel = "user_name_label"`,
+		`DialogsLoginFormUserNameID = "user-name"`,
+		"type DialogsLoginFormView struct {",
+		"UserName rui.EditView",
+		"Submit   rui.Button",
+		"Chart    rui.View",
+		`root := rui.CreateViewFromResources(session, "dialogs/login_form.rui")`,
+		"result.UserName = rui.EditViewByID(root, DialogsLoginFormUserNameID)",
+		"result.Chart = rui.ViewByID(root, DialogsLoginFormChartID)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("%q not found in the generated code:\n%s", expected, text)
+		}
+	}
+
+	if strings.Contains(text, "unused") || strings.Contains(text, "Submit2") {
+		t.Errorf("invalid generated code:\n%s", text)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	for text, expected := range map[string]string{
+		"user-name":   "UserName",
+		"login_form":  "LoginForm",
+		"dialogs/box": "DialogsBox",
+		"2fa":         "N2fa",
+		"---":         "X",
+	} {
+		if result := identifier(text); result != expected {
+			t.Errorf(`identifier("%s") = "%s", expected "%s"`, text, result, expected)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Command ruigen generates typed accessors for the views described in the resource files.
+//
+// For each file of the "views" resource directory the Go struct is generated. The struct contains
+// the root view and the fields for all views with the "id" property. The fields are filled by
+// the Create<Name>View and Bind<Name>View functions. Also constants for the view ids and for the
+// string resource keys used in the view files are generated.
+//
+// Usage:
+//
+//	//go:generate go run github.com/anoshenko/rui/cmd/ruigen -resources resources -package main -o rui_views.go
+//
+// Flags:
+//
+//	-resources  the resource directory which contains the "views" and "strings" subdirectories (default "resources")
+//	-package    the package name of the generated file (default "main")
+//	-o          the output file (default "rui_views.go")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	resourcesDir := flag.String("resources", "resources", "the resource directory")
+	packageName := flag.String("package", "main", "the package name of the generated file")
+	output := flag.String("o", "rui_views.go", "the output file")
+	flag.Parse()
+
+	code, err := generate(filepath.Join(*resourcesDir, "views"), filepath.Join(*resourcesDir, "strings"), *packageName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ruigen:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "ruigen:", err)
+		os.Exit(1)
+	}
+}
@@ -173,7 +173,11 @@ func stringToColor(text string) (Color, error) {
 		}
 	}
 
-	// TODO hsl(360,100%,50%), hsla(360,100%,50%,.5)
+	for _, name := range []string{"hsla", "hsl", "hwb"} {
+		if strings.HasPrefix(text, name) {
+			return parseCSSColorFunction(strings.TrimSuffix(name, "a"), text[len(name):])
+		}
+	}
 
 	if color, ok := colorConstants[text]; ok {
 		return color, nil
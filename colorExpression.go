@@ -0,0 +1,174 @@
+package rui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorFunctions is the list of functions that can be used in color values of a theme
+var colorFunctions = []string{"lighten", "darken", "mix", "alpha", "contrast"}
+
+// isColorExpression returns true if the text is a call of a color function, for example, "lighten(@primary, 10%)"
+func isColorExpression(text string) bool {
+	n := strings.IndexRune(text, '(')
+	if n <= 0 {
+		return false
+	}
+
+	name := strings.ToLower(strings.Trim(text[:n], " \t"))
+	for _, fn := range colorFunctions {
+		if fn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitColorArgs splits the arguments of a color function by commas outside of parentheses
+func splitColorArgs(text string) []string {
+	result := []string{}
+	level := 0
+	start := 0
+	for i, ch := range text {
+		switch ch {
+		case '(':
+			level++
+
+		case ')':
+			level--
+
+		case ',':
+			if level == 0 {
+				result = append(result, strings.Trim(text[start:i], " \t\n\r"))
+				start = i + 1
+			}
+		}
+	}
+	return append(result, strings.Trim(text[start:], " \t\n\r"))
+}
+
+// colorFraction parses a number argument of a color function: "10%" or "0.1"
+func colorFraction(text string) (float64, error) {
+	percent := strings.HasSuffix(text, "%")
+	if percent {
+		text = text[:len(text)-1]
+	}
+
+	value, err := strconv.ParseFloat(strings.Trim(text, " \t"), 64)
+	if err != nil {
+		return 0, fmt.Errorf(`invalid number "%s"`, text)
+	}
+	if percent {
+		value /= 100
+	}
+	return value, nil
+}
+
+// evalColorExpression calculates the value of the color function call. The resolve function returns
+// the value of the theme color referenced by "@name" argument
+func evalColorExpression(text string, resolve func(tag string) (Color, error)) (Color, error) {
+	text = strings.Trim(text, " \t\n\r")
+	n := strings.IndexRune(text, '(')
+	if n <= 0 || text[len(text)-1] != ')' {
+		return 0, fmt.Errorf(`invalid color expression "%s"`, text)
+	}
+
+	name := strings.ToLower(strings.Trim(text[:n], " \t"))
+	args := splitColorArgs(text[n+1 : len(text)-1])
+
+	color := func(arg string) (Color, error) {
+		switch {
+		case arg == "":
+			return 0, errors.New("empty color argument")
+
+		case isColorExpression(arg):
+			return evalColorExpression(arg, resolve)
+
+		case arg[0] == '@':
+			return resolve(arg[1:])
+		}
+		return stringToColor(arg)
+	}
+
+	checkArgs := func(min, max int) error {
+		if count := len(args); count < min || count > max {
+			return fmt.Errorf(`invalid number of arguments of "%s" function: %d`, name, count)
+		}
+		return nil
+	}
+
+	if err := checkArgs(1, 3); err != nil {
+		return 0, err
+	}
+
+	base, err := color(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	switch name {
+	case "lighten", "darken":
+		if err := checkArgs(2, 2); err != nil {
+			return 0, err
+		}
+		amount, err := colorFraction(args[1])
+		if err != nil {
+			return 0, err
+		}
+		if name == "darken" {
+			amount = -amount
+		}
+		return base.Lighten(amount), nil
+
+	case "mix":
+		if err := checkArgs(2, 3); err != nil {
+			return 0, err
+		}
+		color2, err := color(args[1])
+		if err != nil {
+			return 0, err
+		}
+		weight := 0.5
+		if len(args) == 3 {
+			if weight, err = colorFraction(args[2]); err != nil {
+				return 0, err
+			}
+		}
+		// the weight is the part of the first color as in Sass
+		return base.Blend(color2, 1-weight), nil
+
+	case "alpha":
+		if err := checkArgs(2, 2); err != nil {
+			return 0, err
+		}
+		alpha, err := colorFraction(args[1])
+		if err != nil {
+			return 0, err
+		}
+		return base.WithAlpha(alpha), nil
+
+	case "contrast":
+		if err := checkArgs(1, 3); err != nil {
+			return 0, err
+		}
+		dark, light := Color(0xFF000000), Color(0xFFFFFFFF)
+		if len(args) > 1 {
+			if dark, err = color(args[1]); err != nil {
+				return 0, err
+			}
+		}
+		if len(args) > 2 {
+			if light, err = color(args[2]); err != nil {
+				return 0, err
+			}
+		}
+		if base.ContrastRatio(dark) >= base.ContrastRatio(light) {
+			return dark, nil
+		}
+		return light, nil
+	}
+
+	return 0, fmt.Errorf(`unknown color function "%s"`, name)
+}
@@ -0,0 +1,342 @@
+package rui
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HSLColor creates the color from the hue (in degrees), the saturation (0…1), the lightness (0…1),
+// and the opacity (0…1). Values out of range are clamped, the hue is taken modulo 360
+func HSLColor(hue, saturation, lightness, alpha float64) Color {
+	s := clamp01(saturation)
+	l := clamp01(lightness)
+	c := (1 - math.Abs(2*l-1)) * s
+	r, g, b := hueToRGB(hue, c)
+	m := l - c/2
+	return rgbFloatColor(r+m, g+m, b+m, alpha)
+}
+
+// HSVColor creates the color from the hue (in degrees), the saturation (0…1), the value (0…1),
+// and the opacity (0…1). Values out of range are clamped, the hue is taken modulo 360
+func HSVColor(hue, saturation, value, alpha float64) Color {
+	v := clamp01(value)
+	c := v * clamp01(saturation)
+	r, g, b := hueToRGB(hue, c)
+	m := v - c
+	return rgbFloatColor(r+m, g+m, b+m, alpha)
+}
+
+// OKLCHColor creates the color from the OKLCH perceptual lightness (0…1), the chroma (0…0.4),
+// the hue (in degrees), and the opacity (0…1). The colors outside of the sRGB gamut are clipped
+func OKLCHColor(lightness, chroma, hue, alpha float64) Color {
+	h := hue * math.Pi / 180
+	return oklabColor(lightness, chroma*math.Cos(h), chroma*math.Sin(h), alpha)
+}
+
+// HSL returns the hue (0…360 degrees), the saturation (0…1), and the lightness (0…1) of the color
+func (color Color) HSL() (float64, float64, float64) {
+	r, g, b := color.rgbFloat()
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l := (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	return rgbHue(r, g, b, max, d), d / (1 - math.Abs(2*l-1)), l
+}
+
+// HSV returns the hue (0…360 degrees), the saturation (0…1), and the value (0…1) of the color
+func (color Color) HSV() (float64, float64, float64) {
+	r, g, b := color.rgbFloat()
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	if max == min {
+		return 0, 0, max
+	}
+
+	d := max - min
+	return rgbHue(r, g, b, max, d), d / max, max
+}
+
+// OKLCH returns the perceptual lightness (0…1), the chroma, and the hue (0…360 degrees) of the color
+// in the OKLCH color space. The hue of the gray colors is 0
+func (color Color) OKLCH() (float64, float64, float64) {
+	l, a, b := color.oklab()
+	c := math.Hypot(a, b)
+	if c < 1e-6 {
+		return l, 0, 0
+	}
+
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h
+}
+
+// WithAlpha returns the color with the given opacity (0…1)
+func (color Color) WithAlpha(alpha float64) Color {
+	return color&0x00FFFFFF | Color(colorChannel(alpha*255))<<24
+}
+
+// Blend returns the color that contains the "weight" part (0…1) of the other color
+// and the rest part of the color. All channels including the alpha channel are mixed
+func (color Color) Blend(other Color, weight float64) Color {
+	weight = clamp01(weight)
+	a1, r1, g1, b1 := color.ARGB()
+	a2, r2, g2, b2 := other.ARGB()
+	mix := func(c1, c2 uint8) Color {
+		return Color(colorChannel(float64(c1)*(1-weight) + float64(c2)*weight))
+	}
+	return mix(a1, a2)<<24 | mix(r1, r2)<<16 | mix(g1, g2)<<8 | mix(b1, b2)
+}
+
+// Lighten returns the color with the HSL lightness increased by the amount (0…1)
+func (color Color) Lighten(amount float64) Color {
+	h, s, l := color.HSL()
+	return HSLColor(h, s, l+amount, float64(color.Alpha())/255)
+}
+
+// Darken returns the color with the HSL lightness decreased by the amount (0…1)
+func (color Color) Darken(amount float64) Color {
+	return color.Lighten(-amount)
+}
+
+// Luminance returns the relative luminance (0…1) of the color as defined by WCAG 2. The alpha channel is ignored
+func (color Color) Luminance() float64 {
+	r, g, b := color.rgbFloat()
+	linear := func(c float64) float64 {
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// ContrastRatio returns the contrast ratio (1…21) of two colors as defined by WCAG 2.
+// The level AA requires at least 4.5 for a normal text and 3 for a large text
+func (color Color) ContrastRatio(other Color) float64 {
+	l1 := color.Luminance()
+	l2 := other.Luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
+
+// colorChannel rounds the value of a color channel and limits it to the range [0, 255]
+func colorChannel(value float64) int {
+	return int(math.Round(math.Max(0, math.Min(255, value))))
+}
+
+// rgbFloat returns the red, green, and blue components of the color in the range [0, 1]
+func (color Color) rgbFloat() (float64, float64, float64) {
+	_, r, g, b := color.ARGB()
+	return float64(r) / 255, float64(g) / 255, float64(b) / 255
+}
+
+// rgbFloatColor creates the color from the red, green, blue, and alpha components in the range [0, 1]
+func rgbFloatColor(r, g, b, alpha float64) Color {
+	return Color(colorChannel(alpha*255))<<24 |
+		Color(colorChannel(r*255))<<16 |
+		Color(colorChannel(g*255))<<8 |
+		Color(colorChannel(b*255))
+}
+
+// rgbHue returns the hue of the color with the red, green, blue components, the maximal component and the chroma
+func rgbHue(r, g, b, max, chroma float64) float64 {
+	var h float64
+	switch max {
+	case r:
+		h = math.Mod((g-b)/chroma+6, 6)
+	case g:
+		h = (b-r)/chroma + 2
+	default:
+		h = (r-g)/chroma + 4
+	}
+	return h * 60
+}
+
+// hueToRGB returns the red, green, and blue components of the color with the hue and the chroma without the lightness offset
+func hueToRGB(hue, chroma float64) (float64, float64, float64) {
+	h := math.Mod(hue, 360)
+	if h < 0 {
+		h += 360
+	}
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+
+	switch {
+	case h < 60:
+		return chroma, x, 0
+	case h < 120:
+		return x, chroma, 0
+	case h < 180:
+		return 0, chroma, x
+	case h < 240:
+		return 0, x, chroma
+	case h < 300:
+		return x, 0, chroma
+	}
+	return chroma, 0, x
+}
+
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+// oklab returns the L, a, b components of the color in the OKLab color space
+func (color Color) oklab() (float64, float64, float64) {
+	r, g, b := color.rgbFloat()
+	r = srgbToLinear(r)
+	g = srgbToLinear(g)
+	b = srgbToLinear(b)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+// oklabColor creates the color from the L, a, b components of the OKLab color space
+func oklabColor(L, a, b, alpha float64) Color {
+	l := L + 0.3963377774*a + 0.2158037573*b
+	m := L - 0.1055613458*a - 0.0638541728*b
+	s := L - 0.0894841775*a - 1.2914855480*b
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return rgbFloatColor(
+		linearToSRGB(clamp01(4.0767416621*l-3.3077115913*m+0.2309699292*s)),
+		linearToSRGB(clamp01(-1.2684380046*l+2.6097574011*m-0.3413193965*s)),
+		linearToSRGB(clamp01(-0.0041960863*l-0.7034186147*m+1.7076147010*s)),
+		alpha)
+}
+
+// parseCSSColorFunction parses the arguments of hsl(), hsla(), and hwb() CSS functions.
+// Both the comma separated and the space separated ("hsl(120deg 50% 50% / 0.5)") syntaxes are supported
+func parseCSSColorFunction(name, args string) (Color, error) {
+	args = strings.Trim(args, " \t")
+	count := len(args)
+	if count < 2 || args[0] != '(' || args[count-1] != ')' {
+		return 0, errors.New(`Invalid arguments of "` + name + `" color function: ` + args)
+	}
+	args = args[1 : count-1]
+
+	alphaText := ""
+	if n := strings.IndexRune(args, '/'); n >= 0 {
+		alphaText = strings.Trim(args[n+1:], " \t")
+		args = args[:n]
+	}
+
+	var values []string
+	if strings.ContainsRune(args, ',') {
+		values = strings.Split(args, ",")
+		for i, value := range values {
+			values[i] = strings.Trim(value, " \t")
+		}
+	} else {
+		values = strings.Fields(args)
+	}
+
+	if len(values) == 4 && alphaText == "" {
+		alphaText = values[3]
+		values = values[:3]
+	}
+	if len(values) != 3 {
+		return 0, errors.New(`Invalid number of arguments of "` + name + `" color function`)
+	}
+
+	hue, err := parseCSSHue(values[0])
+	if err != nil {
+		return 0, err
+	}
+
+	percent := func(text string) (float64, error) {
+		if !strings.HasSuffix(text, "%") {
+			return 0, errors.New(`Invalid percentage value "` + text + `"`)
+		}
+		value, err := strconv.ParseFloat(text[:len(text)-1], 64)
+		return value / 100, err
+	}
+
+	v1, err := percent(values[1])
+	if err != nil {
+		return 0, err
+	}
+	v2, err := percent(values[2])
+	if err != nil {
+		return 0, err
+	}
+
+	alpha := 1.0
+	if alphaText != "" {
+		if strings.HasSuffix(alphaText, "%") {
+			alpha, err = percent(alphaText)
+		} else {
+			alpha, err = strconv.ParseFloat(alphaText, 64)
+		}
+		if err != nil {
+			return 0, errors.New(`Invalid alpha value "` + alphaText + `"`)
+		}
+	}
+
+	if name == "hwb" {
+		white, black := clamp01(v1), clamp01(v2)
+		if white+black >= 1 {
+			gray := white / (white + black)
+			return rgbFloatColor(gray, gray, gray, alpha), nil
+		}
+		value := 1 - black
+		return HSVColor(hue, 1-white/value, value, alpha), nil
+	}
+
+	return HSLColor(hue, v1, v2, alpha), nil
+}
+
+// parseCSSHue parses the hue of CSS color function. A number without a unit is the angle in degrees
+func parseCSSHue(text string) (float64, error) {
+	for _, unit := range []struct {
+		suffix string
+		scale  float64
+	}{
+		{"deg", 1},
+		{"grad", 0.9},
+		{"rad", 180 / math.Pi},
+		{"turn", 360},
+	} {
+		if strings.HasSuffix(text, unit.suffix) {
+			value, err := strconv.ParseFloat(text[:len(text)-len(unit.suffix)], 64)
+			if err != nil {
+				return 0, errors.New(`Invalid hue value "` + text + `"`)
+			}
+			return value * unit.scale, nil
+		}
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, errors.New(`Invalid hue value "` + text + `"`)
+	}
+	return value, nil
+}
@@ -2,6 +2,7 @@ package rui
 
 import (
 	"bytes"
+	"math"
 	"testing"
 )
 
@@ -118,3 +119,119 @@ func TestColorSetData(t *testing.T) {
 		}
 	}
 }
+
+func TestColorSpaces(t *testing.T) {
+	createTestLog(t, false)
+
+	near := func(a, b, eps float64) bool {
+		return math.Abs(a-b) <= eps
+	}
+
+	if h, s, l := Color(0xFFFF8000).HSL(); !near(h, 30.118, 1e-3) || s != 1 || l != 0.5 {
+		t.Errorf("HSL(#FF8000) = %g, %g, %g", h, s, l)
+	}
+	if h, s, v := Color(0xFF336699).HSV(); !near(h, 210, 1e-9) || !near(s, 2.0/3, 1e-9) || !near(v, 0.6, 1e-9) {
+		t.Errorf("HSV(#336699) = %g, %g, %g", h, s, v)
+	}
+
+	oklchTests := []struct {
+		color   Color
+		l, c, h float64
+	}{
+		{0xFFFFFFFF, 1, 0, 0},
+		{0xFF000000, 0, 0, 0},
+		{0xFFFF0000, 0.62796, 0.25768, 29.2339},
+		{0xFF00FF00, 0.86644, 0.29483, 142.4953},
+		{0xFF0000FF, 0.45201, 0.31321, 264.0520},
+	}
+	for _, test := range oklchTests {
+		l, c, h := test.color.OKLCH()
+		if !near(l, test.l, 1e-4) || !near(c, test.c, 1e-4) || !near(h, test.h, 1e-2) {
+			t.Errorf("OKLCH(%s) = %g, %g, %g, expected %g, %g, %g", test.color.String(), l, c, h, test.l, test.c, test.h)
+		}
+		if color := OKLCHColor(test.l, test.c, test.h, 1); color != test.color {
+			t.Errorf("OKLCHColor(%g, %g, %g) = %s, expected %s", test.l, test.c, test.h, color.String(), test.color.String())
+		}
+	}
+
+	constructorTests := []struct {
+		color    Color
+		expected Color
+	}{
+		{HSLColor(30, 1, 0.5, 1), 0xFFFF8000},
+		{HSLColor(210, 0.5, 0.4, 1), 0xFF336699},
+		{HSLColor(-150, 0.5, 0.4, 0.5), 0x80336699},
+		{HSVColor(210, 2.0/3, 0.6, 1), 0xFF336699},
+		{HSVColor(0, 0, 1, 1), 0xFFFFFFFF},
+		{Color(0xFFFF0000).Lighten(0.2), 0xFFFF6666},
+		{Color(0xFFFF0000).Darken(0.2), 0xFF990000},
+		{Color(0xFF000000).Blend(0xFFFFFFFF, 0.3), 0xFF4D4D4D},
+		{Color(0xFFFF0000).WithAlpha(0.5), 0x80FF0000},
+	}
+	for i, test := range constructorTests {
+		if test.color != test.expected {
+			t.Errorf("%d: result %s, expected %s", i, test.color.String(), test.expected.String())
+		}
+	}
+
+	for r := 0; r < 256; r += 15 {
+		for g := 0; g < 256; g += 15 {
+			for b := 0; b < 256; b += 15 {
+				color := Color(0xFF000000 | r<<16 | g<<8 | b)
+				if h, s, l := color.HSL(); HSLColor(h, s, l, 1) != color {
+					t.Errorf("HSL conversion of %s is lossy", color.String())
+				}
+				if h, s, v := color.HSV(); HSVColor(h, s, v, 1) != color {
+					t.Errorf("HSV conversion of %s is lossy", color.String())
+				}
+				if l, c, h := color.OKLCH(); OKLCHColor(l, c, h, 1) != color {
+					t.Errorf("OKLCH conversion of %s is lossy", color.String())
+				}
+			}
+		}
+	}
+
+	if l := Color(0xFFFFFFFF).Luminance(); l != 1 {
+		t.Errorf("Luminance(white) = %g", l)
+	}
+	if l := Color(0xFF000000).Luminance(); l != 0 {
+		t.Errorf("Luminance(black) = %g", l)
+	}
+	if ratio := Color(0xFFFFFFFF).ContrastRatio(0xFF000000); ratio != 21 {
+		t.Errorf("ContrastRatio(white, black) = %g", ratio)
+	}
+	if ratio := Color(0xFF777777).ContrastRatio(0xFFFFFFFF); !near(ratio, 4.478, 1e-3) {
+		t.Errorf("ContrastRatio(#777777, white) = %g", ratio)
+	}
+}
+
+func TestColorFunctionParsing(t *testing.T) {
+	createTestLog(t, false)
+
+	testData := []struct{ src, result string }{
+		{"hsl(210, 50%, 40%)", "rgb(51,102,153)"},
+		{"HSLA(210, 50%, 40%, 0.5)", "rgba(51,102,153,.50)"},
+		{"hsl(210deg 50% 40%)", "rgb(51,102,153)"},
+		{"hsl(0.5turn 100% 50% / 25%)", "rgba(0,255,255,.25)"},
+		{"hsl(3.14159265rad 100% 50%)", "rgb(0,255,255)"},
+		{"hsl(200grad 100% 50%)", "rgb(0,255,255)"},
+		{"hwb(210 20% 40%)", "rgb(51,102,153)"},
+		{"hwb(0 60% 60% / .5)", "rgba(128,128,128,.50)"},
+	}
+
+	for _, data := range testData {
+		color, ok := StringToColor(data.src)
+		if !ok {
+			t.Errorf(`StringToColor("%s") fail`, data.src)
+		} else if result := color.cssString(); result != data.result {
+			t.Errorf(`StringToColor("%s") = "%s", expected: "%s"`, data.src, result, data.result)
+		}
+	}
+
+	createTestLog(t, true)
+	for _, data := range []string{"hsl(210, 50, 40%)", "hsl(210, 50%)", "hwb(x 10% 10%)", "hsl 210 50% 40%"} {
+		if color, ok := StringToColor(data); ok {
+			t.Errorf(`StringToColor("%s") success, result = %s`, data, color.String())
+		}
+	}
+}
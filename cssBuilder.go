@@ -170,7 +170,7 @@ func (builder *cssStyleBuilder) startMedia(rule string) {
 	if builder.buffer == nil {
 		builder.init()
 	}
-	builder.buffer.WriteString(`@media screen`)
+	builder.buffer.WriteString(`@media `)
 	builder.buffer.WriteString(rule)
 	builder.buffer.WriteString(` {\n`)
 	builder.media = true
@@ -176,6 +176,10 @@ func (customView *CustomViewData) htmlTag() string {
 	return customView.superView.htmlTag()
 }
 
+func (customView *CustomViewData) ariaRole() string {
+	return customView.superView.ariaRole()
+}
+
 func (customView *CustomViewData) closeHTMLTag() bool {
 	return customView.superView.closeHTMLTag()
 }
@@ -0,0 +1,180 @@
+package rui
+
+import "strings"
+
+// SessionDarkThemeListener is the listener interface of a session theme change event.
+// The event occurs when the dark theme is switched on or off by SetDarkTheme or by the client
+// operating system settings ("dark-theme-changed" event). The interface can be implemented
+// by SessionContent and by views (for example, custom views)
+type SessionDarkThemeListener interface {
+	OnDarkThemeChanged(session Session)
+}
+
+// themeDependentView is implemented by views which contain content depending on theme colors
+// outside of the view style
+type themeDependentView interface {
+	themeChanged()
+}
+
+func (session *sessionData) SetDarkTheme(dark bool) {
+	if dark == session.darkTheme {
+		return
+	}
+
+	session.darkTheme = dark
+	session.checkboxOff = ""
+	session.checkboxOn = ""
+	session.radiobuttonOff = ""
+	session.radiobuttonOn = ""
+
+	if session.themeVariables {
+		// the theme colors are CSS variables which are overridden by the dark theme class.
+		// Only the theme rules and the styles of views which contain other theme references are rewritten
+		if !session.ignoreViewUpdates() {
+			buffer := allocStringBuilder()
+			defer freeStringBuilder(buffer)
+
+			session.writeThemeClassesScript(buffer)
+			session.writeThemeChangesScript(buffer)
+			session.writeThemeReferencesScript(buffer)
+			session.runScript(buffer.String())
+		}
+	} else if !session.ignoreViewUpdates() {
+		buffer := allocStringBuilder()
+		defer freeStringBuilder(buffer)
+
+		session.writeStylesScript(buffer)
+		if session.rootView != nil {
+			writeViewsStyleScript(session.rootView, buffer)
+		}
+		if session.popups != nil {
+			for _, popup := range session.popups.popups {
+				writeViewsStyleScript(popup.rootView(), buffer)
+			}
+		}
+		session.runScript(buffer.String())
+	}
+
+	if session.rootView != nil {
+		viewsThemeChanged(session.rootView, session)
+	}
+	if session.popups != nil {
+		for _, popup := range session.popups.popups {
+			viewsThemeChanged(popup.rootView(), session)
+		}
+	}
+	if session.content != nil {
+		if listener, ok := session.content.(SessionDarkThemeListener); ok {
+			listener.OnDarkThemeChanged(session)
+		}
+	}
+}
+
+// updateThemeStyles updates the page styles and the theme dependent views without re-rendering of views
+func (session *sessionData) updateThemeStyles() {
+	session.checkboxOff = ""
+	session.checkboxOn = ""
+	session.radiobuttonOff = ""
+	session.radiobuttonOn = ""
+
+	if !session.ignoreViewUpdates() {
+		buffer := allocStringBuilder()
+		defer freeStringBuilder(buffer)
+
+		if session.themeVariables {
+			session.writeThemeChangesScript(buffer)
+			session.writeThemeReferencesScript(buffer)
+		} else {
+			session.writeStylesScript(buffer)
+		}
+		if buffer.Len() > 0 {
+			session.runScript(buffer.String())
+		}
+	}
+
+	if session.rootView != nil {
+		themeDependentViewsChanged(session.rootView)
+	}
+	if session.popups != nil {
+		for _, popup := range session.popups.popups {
+			themeDependentViewsChanged(popup.rootView())
+		}
+	}
+}
+
+func (session *sessionData) handleDarkThemeChanged(data DataObject) {
+	if value, ok := data.PropertyValue("dark"); ok {
+		session.SetDarkTheme(value == "1" || value == "true")
+	}
+}
+
+// writeThemeReferencesScript writes the script which updates the styles of views of the root view and popups
+// which contain the theme references not output as the theme CSS variables
+func (session *sessionData) writeThemeReferencesScript(buffer *strings.Builder) {
+	if session.rootView != nil {
+		session.writeThemeReferencesStyleScript(session.rootView, buffer)
+	}
+	if session.popups != nil {
+		for _, popup := range session.popups.popups {
+			session.writeThemeReferencesStyleScript(popup.rootView(), buffer)
+		}
+	}
+}
+
+// writeViewsStyleScript writes the script which updates the inline styles of all views of the tree
+func writeViewsStyleScript(view View, buffer *strings.Builder) {
+	if view == nil {
+		return
+	}
+
+	builder := viewCSSBuilder{buffer: allocStringBuilder()}
+	view.cssStyle(view, &builder)
+
+	buffer.WriteString(`updateCSSStyle('`)
+	buffer.WriteString(view.htmlID())
+	buffer.WriteString(`', '`)
+	buffer.WriteString(builder.finish())
+	buffer.WriteString("');\n")
+
+	if container, ok := view.(ParanetView); ok {
+		for _, subview := range container.Views() {
+			writeViewsStyleScript(subview, buffer)
+		}
+	}
+}
+
+// themeDependentViewsChanged updates the theme dependent content of the views
+func themeDependentViewsChanged(view View) {
+	if dependent, ok := view.(themeDependentView); ok {
+		dependent.themeChanged()
+	}
+
+	if container, ok := view.(ParanetView); ok {
+		for _, subview := range container.Views() {
+			if subview != nil {
+				themeDependentViewsChanged(subview)
+			}
+		}
+	}
+}
+
+// viewsThemeChanged updates the theme dependent content of the views and notifies the listeners
+func viewsThemeChanged(view View, session Session) {
+	if view == nil {
+		return
+	}
+
+	if dependent, ok := view.(themeDependentView); ok {
+		dependent.themeChanged()
+	}
+
+	if container, ok := view.(ParanetView); ok {
+		for _, subview := range container.Views() {
+			viewsThemeChanged(subview, session)
+		}
+	}
+
+	if listener, ok := view.(SessionDarkThemeListener); ok {
+		listener.OnDarkThemeChanged(session)
+	}
+}
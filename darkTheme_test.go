@@ -0,0 +1,45 @@
+package rui
+
+import "testing"
+
+type darkThemeTestContent struct {
+	changed []bool
+}
+
+func (content *darkThemeTestContent) CreateRootView(session Session) View {
+	return nil
+}
+
+func (content *darkThemeTestContent) OnDarkThemeChanged(session Session) {
+	content.changed = append(content.changed, session.DarkTheme())
+}
+
+func TestSetDarkTheme(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	session.rootView = NewListLayout(session, Params{
+		Content: []View{NewCheckbox(session, Params{Checked: true}), NewCanvasView(session, nil)},
+	})
+	content := new(darkThemeTestContent)
+	session.content = content
+
+	lightImage := session.checkboxOnImage()
+	session.SetDarkTheme(true)
+	session.SetDarkTheme(true)
+	if !session.DarkTheme() {
+		t.Error("DarkTheme() = false")
+	}
+	if session.checkboxOnImage() == lightImage {
+		t.Error("The checkbox image is not updated")
+	}
+
+	session.handleDarkThemeChanged(ParseDataText(`dark-theme-changed{session=1,dark=0}`))
+	if session.DarkTheme() {
+		t.Error("DarkTheme() = true")
+	}
+
+	if len(content.changed) != 2 || !content.changed[0] || content.changed[1] {
+		t.Errorf("Invalid dark theme change events: %v", content.changed)
+	}
+}
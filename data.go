@@ -1,6 +1,7 @@
 package rui
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 )
@@ -32,6 +33,8 @@ const (
 	ObjectNode = 1
 	// ArrayNode - node is the pair "tag - object". Syntax: <tag> = [...]
 	ArrayNode = 2
+	// NullNode - node is the pair "tag - null". Such nodes are created only by ParseDataJSON from JSON null values
+	NullNode = 3
 )
 
 // DataNode interface of a data node
@@ -180,6 +183,9 @@ func (node *dataNode) Type() int {
 	if node.array != nil {
 		return ArrayNode
 	}
+	if value, ok := node.value.(*jsonValue); ok && value.kind == jsonNull {
+		return NullNode
+	}
 	if node.value.IsObject() {
 		return ObjectNode
 	}
@@ -221,8 +227,84 @@ func (node *dataNode) ArrayElements() []DataValue {
 	return []DataValue{}
 }
 
-// ParseDataText - parse text and return DataNode
+// DataParseError describes an error of the data text parsing
+type DataParseError struct {
+	// Line - the line number of the error (starting from 1)
+	Line int
+	// Column - the position of the error in the line (starting from 1)
+	Column int
+	// Expected - the description of the expected token. Empty if unknown
+	Expected string
+	// Message - the error description
+	Message string
+	// Snippet - the source line with the error and the "^" marker under the error position
+	Snippet string
+}
+
+func newDataParseError(data []rune, pos int, expected, message string) *DataParseError {
+	if pos > len(data) {
+		pos = len(data)
+	}
+
+	err := &DataParseError{Line: 1, Expected: expected, Message: message}
+	lineStart := 0
+	for i := 0; i < pos; i++ {
+		if data[i] == '\n' {
+			err.Line++
+			lineStart = i + 1
+		}
+	}
+	err.Column = pos - lineStart + 1
+
+	lineEnd := lineStart
+	for lineEnd < len(data) && data[lineEnd] != '\n' {
+		lineEnd++
+	}
+
+	marker := make([]rune, 0, pos-lineStart+1)
+	for _, ch := range data[lineStart:pos] {
+		if ch == '\t' {
+			marker = append(marker, '\t')
+		} else {
+			marker = append(marker, ' ')
+		}
+	}
+	err.Snippet = string(data[lineStart:lineEnd]) + "\n" + string(marker) + "^"
+	return err
+}
+
+// Error returns the one-line description of the error. The source line with the error is stored in the Snippet field
+func (err *DataParseError) Error() string {
+	text := fmt.Sprintf("%s (line: %d, column: %d)", err.Message, err.Line, err.Column)
+	if err.Expected != "" && !strings.HasPrefix(err.Message, "expected") {
+		text += ", expected " + err.Expected
+	}
+	return text
+}
+
+// ParseDataText - parse text and return DataNode. Errors are written to the error log.
+// In case of a recoverable error (for example, an invalid escape sequence) the parsed part of data is returned
 func ParseDataText(text string) DataObject {
+	obj, err := parseDataText(text)
+	if err != nil {
+		ErrorLog(err.Error())
+	}
+	return obj
+}
+
+// ParseDataTextWithError - parse text and return DataNode. In case of any error
+// the result is nil and *DataParseError
+func ParseDataTextWithError(text string) (DataObject, error) {
+	obj, err := parseDataText(text)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// parseDataText returns the parsed data and the first error. The data is not nil if the parsing
+// was continued after the error
+func parseDataText(text string) (DataObject, *DataParseError) {
 
 	if strings.ContainsAny(text, "\r") {
 		text = strings.Replace(text, "\r\n", "\n", -1)
@@ -231,8 +313,13 @@ func ParseDataText(text string) DataObject {
 	data := append([]rune(text), rune(0))
 	pos := 0
 	size := len(data) - 1
-	line := 1
-	lineStart := 0
+
+	var parseErr *DataParseError
+	fail := func(errPos int, expected, message string) {
+		if parseErr == nil {
+			parseErr = newDataParseError(data[:size], errPos, expected, message)
+		}
+	}
 
 	skipSpaces := func(skipNewLine bool) {
 		for pos < size {
@@ -241,8 +328,6 @@ func ParseDataText(text string) DataObject {
 				if !skipNewLine {
 					return
 				}
-				line++
-				lineStart = pos + 1
 
 			case '/':
 				if pos+1 < size {
@@ -255,19 +340,16 @@ func ParseDataText(text string) DataObject {
 						pos--
 
 					case '*':
+						commentPos := pos
 						pos += 3
 						for {
 							if pos >= size {
-								ErrorLog("Unexpected end of file")
+								fail(commentPos, "'*/'", "unexpected end of text in the comment")
 								return
 							}
 							if data[pos-1] == '*' && data[pos] == '/' {
 								break
 							}
-							if data[pos-1] == '\n' {
-								line++
-								lineStart = pos
-							}
 							pos++
 						}
 
@@ -297,7 +379,7 @@ func ParseDataText(text string) DataObject {
 			for data[pos] != '`' {
 				pos++
 				if pos >= size {
-					ErrorLog("Unexpected end of text")
+					fail(startPos-1, "'`'", "unexpected end of text")
 					return string(data[startPos:size]), false
 				}
 			}
@@ -319,7 +401,7 @@ func ParseDataText(text string) DataObject {
 					pos++
 				}
 				if pos >= size {
-					ErrorLog("Unexpected end of text")
+					fail(startPos-1, "'"+string(stopSymbol)+"'", "unexpected end of text")
 					return string(data[startPos:size]), false
 				}
 			}
@@ -334,11 +416,12 @@ func ParseDataText(text string) DataObject {
 			buffer := make([]rune, pos-startPos+1)
 			n1 := 0
 			n2 := startPos
+			escapePos := startPos
 
 			invalidEscape := func() (string, bool) {
 				str := string(data[startPos:pos])
+				fail(escapePos, "", "invalid escape sequence")
 				pos++
-				ErrorLogF("Invalid escape sequence in \"%s\" (position %d)", str, n2-2-startPos)
 				return str, false
 			}
 
@@ -347,6 +430,7 @@ func ParseDataText(text string) DataObject {
 					buffer[n1] = data[n2]
 					n2++
 				} else {
+					escapePos = n2
 					n2 += 2
 					switch data[n2-1] {
 					case 'n':
@@ -409,7 +493,7 @@ func ParseDataText(text string) DataObject {
 
 					default:
 						str := string(data[startPos:pos])
-						ErrorLogF("Invalid escape sequence in \"%s\" (position %d)", str, n2-2-startPos)
+						fail(escapePos, "", "invalid escape sequence")
 						return str, false
 					}
 				}
@@ -440,7 +524,7 @@ func ParseDataText(text string) DataObject {
 		endPos := pos
 		skipSpaces(false)
 		if startPos == endPos {
-			ErrorLog("empty tag")
+			fail(startPos, "tag name", "empty tag")
 			return "", false
 		}
 		return string(data[startPos:endPos]), true
@@ -459,7 +543,7 @@ func ParseDataText(text string) DataObject {
 
 		skipSpaces(true)
 		if data[pos] != '=' {
-			ErrorLogF("expected '=' after a tag name (line: %d, position: %d)", line, pos-lineStart)
+			fail(pos, "'='", "expected '=' after a tag name")
 			return nil
 		}
 
@@ -484,7 +568,7 @@ func ParseDataText(text string) DataObject {
 			return node
 
 		case '}', ']', '=':
-			ErrorLogF("Expected '[', '{' or a tag name after '=' (line: %d, position: %d)", line, pos-lineStart)
+			fail(pos, "'[', '{' or a tag name", "expected '[', '{' or a tag name after '='")
 			return nil
 
 		default:
@@ -512,7 +596,7 @@ func ParseDataText(text string) DataObject {
 
 	parseObject = func(tag string) DataObject {
 		if data[pos] != '{' {
-			ErrorLogF("Expected '{' (line: %d, position: %d)", line, pos-lineStart)
+			fail(pos, "'{'", "expected '{'")
 			return nil
 		}
 		pos++
@@ -540,7 +624,7 @@ func ParseDataText(text string) DataObject {
 				skipSpaces(true)
 				return obj
 			} else if data[pos] != ',' && data[pos] != '\n' {
-				ErrorLogF(`Expected '}', '\n' or ',' (line: %d, position: %d)`, line, pos-lineStart)
+				fail(pos, `'}', '\n' or ','`, `Expected '}', '\n' or ','`)
 				return nil
 			}
 			if data[pos] != '\n' {
@@ -553,7 +637,7 @@ func ParseDataText(text string) DataObject {
 			}
 		}
 
-		ErrorLog("Unexpected end of text")
+		fail(pos, "'}'", "unexpected end of text")
 		return nil
 	}
 
@@ -603,7 +687,7 @@ func ParseDataText(text string) DataObject {
 			case ']', ',', '\n':
 
 			default:
-				ErrorLogF("Expected ']' or ',' (line: %d, position: %d)", line, pos-lineStart)
+				fail(pos, "']' or ','", "expected ']' or ','")
 				return nil
 			}
 
@@ -620,12 +704,17 @@ func ParseDataText(text string) DataObject {
 			*/
 		}
 
-		ErrorLog("Unexpected end of text")
+		fail(pos, "']'", "unexpected end of text")
 		return nil
 	}
 
+	var obj DataObject
 	if tag, ok := parseTag(); ok {
-		return parseObject(tag)
+		obj = parseObject(tag)
 	}
-	return nil
+
+	if obj == nil && parseErr == nil {
+		fail(pos, "", "invalid data")
+	}
+	return obj, parseErr
 }
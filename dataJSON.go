@@ -0,0 +1,350 @@
+package rui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// JSONTagKey is the name of the JSON object member that stores the tag of DataObject.
+// A JSON object without this member is converted to DataObject with the "_" tag.
+// A property with the same name is written to JSON as "\_tag" ("\\_tag" for "\_tag" and so on)
+const JSONTagKey = "_tag"
+
+const (
+	jsonNumber = iota
+	jsonBool
+	jsonNull
+)
+
+// jsonValue is a JSON number, boolean, or null. The value keeps the JSON type,
+// so it is written back by DataObjectToJSON without quotes
+type jsonValue struct {
+	value string
+	kind  int
+}
+
+func (value *jsonValue) Value() string {
+	return value.value
+}
+
+func (value *jsonValue) IsObject() bool {
+	return false
+}
+
+func (value *jsonValue) Object() DataObject {
+	return nil
+}
+
+// jsonArrayValue is an array nested into a JSON array. Value returns the compact JSON text of the array
+type jsonArrayValue struct {
+	array []DataValue
+}
+
+func (value *jsonArrayValue) Value() string {
+	buffer := new(bytes.Buffer)
+	writeJSONArray(value.array, buffer)
+	return buffer.String()
+}
+
+func (value *jsonArrayValue) IsObject() bool {
+	return false
+}
+
+func (value *jsonArrayValue) Object() DataObject {
+	return nil
+}
+
+// ParseDataJSON converts JSON text to DataObject. The root value must be a JSON object.
+// The tag of each object is taken from the "_tag" member. Other members are converted to the properties
+// in the same order: strings, numbers and booleans to TextNode, null to NullNode, objects to ObjectNode,
+// arrays to ArrayNode. Numbers, booleans, nulls and nested arrays keep the JSON type, so DataObjectToJSON
+// writes them back unchanged
+func ParseDataJSON(data []byte) (DataObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, errors.New("JSON data must be an object")
+	}
+
+	object, err := parseJSONObject(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("JSON data, offset %d: unexpected data after the end of the object", decoder.InputOffset())
+	}
+	return object, nil
+}
+
+// parseJSONObject reads the members of the JSON object. The opening '{' must be already read
+func parseJSONObject(decoder *json.Decoder) (DataObject, error) {
+	object := new(dataObject)
+	object.tag = "_"
+	object.property = []DataNode{}
+	tagged := false
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("JSON data, offset %d: invalid object key", decoder.InputOffset())
+		}
+
+		if token, err = decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		if key == JSONTagKey {
+			tag, ok := token.(string)
+			if !ok || tag == "" {
+				return nil, fmt.Errorf(`JSON data, offset %d: the value of "%s" must be a non-empty string`, decoder.InputOffset(), JSONTagKey)
+			}
+			if tagged {
+				return nil, fmt.Errorf(`JSON data, offset %d: duplicate "%s" member`, decoder.InputOffset(), JSONTagKey)
+			}
+			object.tag = tag
+			tagged = true
+			continue
+		}
+
+		node := new(dataNode)
+		node.tag = jsonKeyToTag(key)
+
+		switch token {
+		case json.Delim('['):
+			if node.array, err = parseJSONArray(decoder, key); err != nil {
+				return nil, err
+			}
+
+		default:
+			if node.value, err = jsonTokenToDataValue(decoder, token, key); err != nil {
+				return nil, err
+			}
+		}
+		object.setNode(node)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// parseJSONArray reads the elements of the JSON array. The opening '[' must be already read
+func parseJSONArray(decoder *json.Decoder, key string) ([]DataValue, error) {
+	array := []DataValue{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value DataValue
+		if token == json.Delim('[') {
+			nested, err := parseJSONArray(decoder, key)
+			if err != nil {
+				return nil, err
+			}
+			value = &jsonArrayValue{array: nested}
+		} else if value, err = jsonTokenToDataValue(decoder, token, key); err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return array, nil
+}
+
+func jsonTokenToDataValue(decoder *json.Decoder, token json.Token, key string) (DataValue, error) {
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			return parseJSONObject(decoder)
+		}
+
+	case string:
+		return &dataStringValue{value: token}, nil
+
+	case json.Number:
+		return &jsonValue{value: token.String(), kind: jsonNumber}, nil
+
+	case bool:
+		if token {
+			return &jsonValue{value: "true", kind: jsonBool}, nil
+		}
+		return &jsonValue{value: "false", kind: jsonBool}, nil
+
+	case nil:
+		return &jsonValue{kind: jsonNull}, nil
+	}
+
+	return nil, fmt.Errorf(`JSON data, offset %d: invalid value of "%s"`, decoder.InputOffset(), key)
+}
+
+// DataObjectToJSON converts DataObject to indented JSON text. The tag of each object
+// is written to the "_tag" member (the "_" tag is omitted). Text values are written as JSON strings,
+// the numbers, booleans, nulls and nested arrays created by ParseDataJSON are written with their JSON type.
+// The result of ParseDataJSON for this text is equal to the source object
+func DataObjectToJSON(object DataObject) string {
+	if object == nil {
+		return "{}"
+	}
+
+	buffer := new(bytes.Buffer)
+	writeDataObjectJSON(object, buffer)
+
+	result := new(bytes.Buffer)
+	if err := json.Indent(result, buffer.Bytes(), "", "\t"); err != nil {
+		ErrorLog(err.Error())
+		return buffer.String()
+	}
+	return result.String()
+}
+
+func writeJSONString(text string, buffer *bytes.Buffer) {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(text); err == nil {
+		buffer.Truncate(buffer.Len() - 1) // removes the trailing line feed
+	} else {
+		ErrorLog(err.Error())
+		buffer.WriteString(`""`)
+	}
+}
+
+// jsonKeyToTag converts the JSON member name to the property tag: "\_tag" is converted to "_tag" and so on
+func jsonKeyToTag(key string) string {
+	if strings.HasPrefix(key, `\`) && strings.TrimLeft(key, `\`) == JSONTagKey {
+		return key[1:]
+	}
+	return key
+}
+
+// tagToJSONKey converts the property tag to the JSON member name, so the tag does not collide with JSONTagKey
+func tagToJSONKey(tag string) string {
+	if strings.TrimLeft(tag, `\`) == JSONTagKey {
+		return `\` + tag
+	}
+	return tag
+}
+
+func writeDataValueJSON(value DataValue, buffer *bytes.Buffer) {
+	switch value := value.(type) {
+	case *jsonValue:
+		if value.kind == jsonNull {
+			buffer.WriteString("null")
+		} else {
+			buffer.WriteString(value.value)
+		}
+
+	case *jsonArrayValue:
+		writeJSONArray(value.array, buffer)
+
+	default:
+		if value.IsObject() {
+			writeDataObjectJSON(value.Object(), buffer)
+		} else {
+			writeJSONString(value.Value(), buffer)
+		}
+	}
+}
+
+func writeJSONArray(array []DataValue, buffer *bytes.Buffer) {
+	buffer.WriteRune('[')
+	for i, value := range array {
+		if i > 0 {
+			buffer.WriteRune(',')
+		}
+		writeDataValueJSON(value, buffer)
+	}
+	buffer.WriteRune(']')
+}
+
+func writeDataObjectJSON(object DataObject, buffer *bytes.Buffer) {
+	buffer.WriteRune('{')
+	comma := false
+	if tag := object.Tag(); tag != "_" && tag != "" {
+		writeJSONString(JSONTagKey, buffer)
+		buffer.WriteRune(':')
+		writeJSONString(tag, buffer)
+		comma = true
+	}
+
+	for i := 0; i < object.PropertyCount(); i++ {
+		node := object.Property(i)
+		if node == nil {
+			continue
+		}
+		if comma {
+			buffer.WriteRune(',')
+		}
+		comma = true
+
+		writeJSONString(tagToJSONKey(node.Tag()), buffer)
+		buffer.WriteRune(':')
+
+		switch node.Type() {
+		case ArrayNode:
+			writeJSONArray(node.ArrayElements(), buffer)
+
+		case ObjectNode:
+			writeDataObjectJSON(node.Object(), buffer)
+
+		default:
+			if n, ok := node.(*dataNode); ok && n.value != nil {
+				writeDataValueJSON(n.value, buffer)
+			} else {
+				writeJSONString(node.Text(), buffer)
+			}
+		}
+	}
+	buffer.WriteRune('}')
+}
+
+// isDataFile returns true if the file name has ".rui" or ".json" extension
+func isDataFile(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".rui", ".json":
+		return true
+	}
+	return false
+}
+
+// parseDataFile parses the content of a resource file. JSON is used for files with ".json" extension,
+// the rui text format for all others. Errors are written to the log together with the file name
+func parseDataFile(filename string, data []byte) DataObject {
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		object, err := ParseDataJSON(data)
+		if err != nil {
+			ErrorLogF(`"%s": %s`, filename, err.Error())
+			return nil
+		}
+		return object
+	}
+	object, err := ParseDataTextWithError(string(data))
+	if err != nil {
+		ErrorLogF(`"%s": %s`, filename, err.Error())
+		return nil
+	}
+	return object
+}
@@ -0,0 +1,240 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestParseDataJSON(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	text := `{
+	"_tag": "ListLayout",
+	"id": "list",
+	"width": 100,
+	"visible": true,
+	"empty": null,
+	"border": { "style": "solid", "width": "1px" },
+	"content": [
+		{ "_tag": "TextView", "text": "Hello \"world\"" },
+		"plain text",
+		{ "_tag": "Button", "content": [ "one", "two" ] }
+	]
+}`
+
+	obj, err := ParseDataJSON([]byte(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Tag() != "ListLayout" {
+		t.Errorf(`obj.Tag() = "%s"`, obj.Tag())
+	}
+	if obj.PropertyCount() != 6 {
+		t.Errorf(`obj.PropertyCount() = %d`, obj.PropertyCount())
+	}
+
+	for i, tag := range []string{"id", "width", "visible", "empty", "border", "content"} {
+		if node := obj.Property(i); node == nil || node.Tag() != tag {
+			t.Errorf(`property %d must be "%s"`, i, tag)
+		}
+	}
+
+	if value, ok := obj.PropertyValue("width"); !ok || value != "100" {
+		t.Errorf(`"width" = "%s"`, value)
+	}
+	if value, ok := obj.PropertyValue("visible"); !ok || value != "true" {
+		t.Errorf(`"visible" = "%s"`, value)
+	}
+
+	if node := obj.PropertyWithTag("empty"); node == nil || node.Type() != NullNode || node.Text() != "" {
+		t.Error(`invalid "empty" node`)
+	}
+
+	if border := obj.PropertyObject("border"); border == nil || border.Tag() != "_" || border.PropertyCount() != 2 {
+		t.Error(`invalid "border" object`)
+	}
+
+	if node := obj.PropertyWithTag("content"); node == nil || node.Type() != ArrayNode || node.ArraySize() != 3 {
+		t.Error(`invalid "content" array`)
+	} else {
+		if value := node.ArrayElement(0); !value.IsObject() || value.Object().Tag() != "TextView" {
+			t.Error(`invalid "content" element 0`)
+		} else if text, _ := value.Object().PropertyValue("text"); text != `Hello "world"` {
+			t.Errorf(`invalid text: "%s"`, text)
+		}
+		if value := node.ArrayElement(1); value.IsObject() || value.Value() != "plain text" {
+			t.Error(`invalid "content" element 1`)
+		}
+		if value := node.ArrayElement(2); !value.IsObject() || value.Object().PropertyWithTag("content").ArraySize() != 2 {
+			t.Error(`invalid "content" element 2`)
+		}
+	}
+
+	failText := []string{
+		``,
+		`[]`,
+		`{ "_tag": 1 }`,
+		`{ "_tag": "A", "_tag": "B" }`,
+		`{ "a": "1" } {}`,
+		`{ "a": "1" `,
+	}
+
+	for _, text := range failText {
+		if _, err := ParseDataJSON([]byte(text)); err == nil {
+			t.Errorf("ParseDataJSON(`%s`) must return an error", text)
+		}
+	}
+}
+
+func TestDataObjectToJSON(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	text := `ListLayout {
+	id = list,
+	border = _{ style = solid, width = 1px },
+	content = [
+		TextView { text = "<Hello> \"world\"\n" },
+		"plain text",
+		Button { content = [ one, two ] },
+	],
+	empty = [],
+}`
+
+	obj := ParseDataText(text)
+	if obj == nil {
+		t.Fatal("ParseDataText error")
+	}
+
+	json := DataObjectToJSON(obj)
+	expected := `{
+	"_tag": "ListLayout",
+	"id": "list",
+	"border": {
+		"style": "solid",
+		"width": "1px"
+	},
+	"content": [
+		{
+			"_tag": "TextView",
+			"text": "<Hello> \"world\"\n"
+		},
+		"plain text",
+		{
+			"_tag": "Button",
+			"content": [
+				"one",
+				"two"
+			]
+		}
+	],
+	"empty": []
+}`
+	if json != expected {
+		t.Errorf("DataObjectToJSON result:\n%s\nexpected:\n%s", json, expected)
+	}
+
+	obj2, err := ParseDataJSON([]byte(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if json2 := DataObjectToJSON(obj2); json2 != json {
+		t.Errorf("Round trip result:\n%s", json2)
+	}
+}
+
+func TestDataJSONRoundTrip(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	json := `{
+	"_tag": "Data",
+	"number": -1.5e3,
+	"int": 100,
+	"bool": false,
+	"null": null,
+	"text": "100",
+	"\\_tag": "member",
+	"\\\\_tag": "member2",
+	"array": [
+		1,
+		true,
+		null,
+		"text",
+		[
+			1,
+			[
+				2,
+				"3"
+			],
+			[]
+		],
+		{
+			"x": 0
+		}
+	]
+}`
+
+	obj, err := ParseDataJSON([]byte(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Tag() != "Data" {
+		t.Errorf(`obj.Tag() = "%s"`, obj.Tag())
+	}
+	if value, ok := obj.PropertyValue("_tag"); !ok || value != "member" {
+		t.Errorf(`"_tag" = "%s"`, value)
+	}
+	if value, ok := obj.PropertyValue("\\_tag"); !ok || value != "member2" {
+		t.Errorf(`"\\_tag" = "%s"`, value)
+	}
+	if node := obj.PropertyWithTag("array"); node == nil || node.ArraySize() != 6 {
+		t.Error(`invalid "array" node`)
+	} else if value := node.ArrayElement(4); value.IsObject() || value.Value() != `[1,[2,"3"],[]]` {
+		t.Errorf(`nested array = "%s"`, value.Value())
+	}
+
+	if result := DataObjectToJSON(obj); result != json {
+		t.Errorf("Round trip result:\n%s\nexpected:\n%s", result, json)
+	}
+
+	// the text property with the "_tag" name does not change the object tag
+	obj = ParseDataText(`Data { _tag = Button }`)
+	if obj == nil {
+		t.Fatal("ParseDataText error")
+	}
+	if obj2, err := ParseDataJSON([]byte(DataObjectToJSON(obj))); err != nil {
+		t.Error(err)
+	} else if obj2.Tag() != "Data" {
+		t.Errorf(`obj2.Tag() = "%s"`, obj2.Tag())
+	} else if value, _ := obj2.PropertyValue("_tag"); value != "Button" {
+		t.Errorf(`"_tag" = "%s"`, value)
+	}
+}
+
+func TestUnmarshalDataJSONNull(t *testing.T) {
+	obj, err := ParseDataJSON([]byte(`{ "width": 100, "height": null }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := struct {
+		Width  int
+		Height int
+	}{Height: 10}
+	if err := UnmarshalData(obj, &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Width != 100 || value.Height != 10 {
+		t.Errorf("UnmarshalData result: %v", value)
+	}
+}
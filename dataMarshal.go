@@ -0,0 +1,635 @@
+package rui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	colorType      = reflect.TypeOf(Color(0))
+	sizeUnitType   = reflect.TypeOf(SizeUnit{})
+	angleUnitType  = reflect.TypeOf(AngleUnit{})
+	boundsType     = reflect.TypeOf(Bounds{})
+	timeType       = reflect.TypeOf(time.Time{})
+	dataObjectType = reflect.TypeOf((*DataObject)(nil)).Elem()
+)
+
+// dataField is a DataNode or an array element
+type dataField struct {
+	nodeType int
+	text     string
+	object   DataObject
+	array    []DataValue
+}
+
+func nodeToDataField(node DataNode) dataField {
+	switch node.Type() {
+	case ArrayNode:
+		return dataField{nodeType: ArrayNode, array: node.ArrayElements()}
+
+	case ObjectNode:
+		return dataField{nodeType: ObjectNode, object: node.Object()}
+	}
+	return dataField{nodeType: TextNode, text: node.Text()}
+}
+
+func valueToDataField(value DataValue) dataField {
+	if value.IsObject() {
+		return dataField{nodeType: ObjectNode, object: value.Object()}
+	}
+	return dataField{nodeType: TextNode, text: value.Value()}
+}
+
+// dataStructField describes a struct field bound to a DataObject property
+type dataStructField struct {
+	tag       string
+	index     []int
+	omitEmpty bool
+}
+
+// dataStructFields returns the fields of the struct type. The property tag is taken from
+// the "rui" struct tag, if it is absent then the field name is used. Fields with `rui:"-"` are skipped.
+// The fields of embedded structs without the "rui" tag are treated as fields of the outer struct.
+// The fields of an embedded pointer to an unexported struct type are skipped
+func dataStructFields(structType reflect.Type) []dataStructField {
+	result := []dataStructField{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		ruiTag, hasTag := field.Tag.Lookup("rui")
+		if ruiTag == "-" {
+			continue
+		}
+
+		if field.Anonymous && !hasTag {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				if field.PkgPath != "" {
+					// the embedded pointer to an unexported struct type can not be set (as in encoding/json)
+					continue
+				}
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && !isDataSimpleType(fieldType) {
+				for _, embedded := range dataStructFields(fieldType) {
+					embedded.index = append([]int{i}, embedded.index...)
+					result = append(result, embedded)
+				}
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+
+		structField := dataStructField{tag: field.Name, index: []int{i}}
+		if hasTag {
+			params := strings.Split(ruiTag, ",")
+			if name := strings.Trim(params[0], " "); name != "" {
+				structField.tag = name
+			}
+			for _, param := range params[1:] {
+				if strings.Trim(param, " ") == "omitempty" {
+					structField.omitEmpty = true
+				}
+			}
+		}
+		result = append(result, structField)
+	}
+	return result
+}
+
+// isDataSimpleType returns true for the struct types which are stored in a text node
+func isDataSimpleType(valueType reflect.Type) bool {
+	switch valueType {
+	case colorType, sizeUnitType, angleUnitType, boundsType, timeType:
+		return true
+	}
+	return false
+}
+
+// UnmarshalData stores the properties of the DataObject in the value pointed to by v.
+// v must be a non-nil pointer to a struct or to a map with string keys.
+// The property tags are defined by `rui:"tag"` struct tags (the field name is used if the tag is absent).
+// Nested structs and maps are read from objects, slices from arrays (or from a comma separated text).
+// Color, SizeUnit, AngleUnit, Bounds and time.Time values are parsed by the same rules as the view properties
+func UnmarshalData(object DataObject, v interface{}) error {
+	if object == nil {
+		return errors.New("UnmarshalData: object is nil")
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("UnmarshalData: v must be a non-nil pointer")
+	}
+
+	return unmarshalDataField(dataField{nodeType: ObjectNode, object: object}, value.Elem(), object.Tag())
+}
+
+func unmarshalDataError(path string, field dataField, err error) error {
+	switch field.nodeType {
+	case TextNode:
+		return fmt.Errorf(`Invalid value "%s" of the "%s" property: %s`, field.text, path, err.Error())
+	}
+	return fmt.Errorf(`Invalid value of the "%s" property: %s`, path, err.Error())
+}
+
+func unmarshalDataField(field dataField, value reflect.Value, path string) error {
+	valueType := value.Type()
+
+	if valueType.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(valueType.Elem()))
+		}
+		return unmarshalDataField(field, value.Elem(), path)
+	}
+
+	if valueType.Kind() == reflect.Interface {
+		switch field.nodeType {
+		case ObjectNode:
+			if reflect.TypeOf(field.object).AssignableTo(valueType) {
+				value.Set(reflect.ValueOf(field.object))
+				return nil
+			}
+
+		case ArrayNode:
+			if reflect.TypeOf(field.array).AssignableTo(valueType) {
+				value.Set(reflect.ValueOf(field.array))
+				return nil
+			}
+
+		default:
+			if reflect.TypeOf(field.text).AssignableTo(valueType) {
+				value.Set(reflect.ValueOf(field.text))
+				return nil
+			}
+		}
+		return unmarshalDataError(path, field, fmt.Errorf("the value can not be assigned to %s", valueType.String()))
+	}
+
+	if valueType == boundsType {
+		var bounds Bounds
+		var err error
+		switch field.nodeType {
+		case ObjectNode:
+			bounds, err = dataObjectToBounds(field.object)
+
+		case TextNode:
+			bounds, err = textToBounds(field.text)
+
+		default:
+			err = errors.New("an array can not be converted to Bounds")
+		}
+		if err != nil {
+			return unmarshalDataError(path, field, err)
+		}
+		value.Set(reflect.ValueOf(bounds))
+		return nil
+	}
+
+	switch field.nodeType {
+	case ObjectNode:
+		switch valueType.Kind() {
+		case reflect.Struct:
+			if !isDataSimpleType(valueType) {
+				return unmarshalDataStruct(field.object, value, path)
+			}
+
+		case reflect.Map:
+			return unmarshalDataMap(field.object, value, path)
+		}
+		return unmarshalDataError(path, field, fmt.Errorf("an object can not be converted to %s", valueType.String()))
+
+	case ArrayNode:
+		if valueType.Kind() == reflect.Slice {
+			return unmarshalDataSlice(field.array, value, path)
+		}
+		return unmarshalDataError(path, field, fmt.Errorf("an array can not be converted to %s", valueType.String()))
+	}
+
+	if valueType.Kind() == reflect.Slice && valueType.Elem().Kind() != reflect.Uint8 {
+		array := []DataValue{}
+		for _, text := range strings.Split(field.text, ",") {
+			if text = strings.Trim(text, " \t\n\r"); text != "" {
+				array = append(array, &dataStringValue{value: text})
+			}
+		}
+		return unmarshalDataSlice(array, value, path)
+	}
+
+	if err := textToReflectValue(field.text, value); err != nil {
+		return unmarshalDataError(path, field, err)
+	}
+	return nil
+}
+
+func unmarshalDataStruct(object DataObject, value reflect.Value, path string) error {
+	for _, structField := range dataStructFields(value.Type()) {
+		node := object.PropertyWithTag(structField.tag)
+		if node == nil {
+			for i := 0; i < object.PropertyCount(); i++ {
+				if prop := object.Property(i); prop != nil && strings.EqualFold(prop.Tag(), structField.tag) {
+					node = prop
+					break
+				}
+			}
+			if node == nil {
+				continue
+			}
+		}
+		if node.Type() == NullNode {
+			continue
+		}
+
+		fieldValue := value
+		for _, index := range structField.index {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			fieldValue = fieldValue.Field(index)
+		}
+
+		if err := unmarshalDataField(nodeToDataField(node), fieldValue, path+"."+structField.tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalDataMap(object DataObject, value reflect.Value, path string) error {
+	mapType := value.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return fmt.Errorf(`The "%s" property: the key type of %s must be string`, path, mapType.String())
+	}
+
+	if value.IsNil() {
+		value.Set(reflect.MakeMap(mapType))
+	}
+
+	for i := 0; i < object.PropertyCount(); i++ {
+		if node := object.Property(i); node != nil && node.Type() != NullNode {
+			elem := reflect.New(mapType.Elem()).Elem()
+			if err := unmarshalDataField(nodeToDataField(node), elem, path+"."+node.Tag()); err != nil {
+				return err
+			}
+			value.SetMapIndex(reflect.ValueOf(node.Tag()).Convert(mapType.Key()), elem)
+		}
+	}
+	return nil
+}
+
+func unmarshalDataSlice(array []DataValue, value reflect.Value, path string) error {
+	slice := reflect.MakeSlice(value.Type(), len(array), len(array))
+	for i, element := range array {
+		if err := unmarshalDataField(valueToDataField(element), slice.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+	return nil
+}
+
+func textToReflectValue(text string, value reflect.Value) error {
+	switch value.Type() {
+	case colorType:
+		color, err := stringToColor(text)
+		if err == nil {
+			value.SetUint(uint64(color))
+		}
+		return err
+
+	case sizeUnitType:
+		size, err := stringToSizeUnit(text)
+		if err == nil {
+			value.Set(reflect.ValueOf(size))
+		}
+		return err
+
+	case angleUnitType:
+		angle, err := stringToAngleUnit(text)
+		if err == nil {
+			value.Set(reflect.ValueOf(angle))
+		}
+		return err
+
+	case timeType:
+		t, err := textToTime(text)
+		if err == nil {
+			value.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+
+	text = strings.Trim(text, " \t\n\r")
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "true", "yes", "on", "1":
+			value.SetBool(true)
+
+		case "false", "no", "off", "0":
+			value.SetBool(false)
+
+		default:
+			return errors.New("invalid bool value")
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+
+	case reflect.Slice:
+		// []byte
+		value.SetBytes([]byte(text))
+
+	default:
+		return fmt.Errorf("%s type is not supported", value.Type().String())
+	}
+	return nil
+}
+
+// textToTime parses the date/time text. RFC 3339 format and the formats of DatePicker and TimePicker are supported
+func textToTime(text string) (time.Time, error) {
+	text = strings.Trim(text, " \t\n\r")
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return t, nil
+	}
+	if strings.ContainsRune(text, ':') {
+		return time.Parse(timeFormatForText(text), text)
+	}
+	return time.Parse(dateFormatForText(text), text)
+}
+
+func textToBounds(text string) (Bounds, error) {
+	values := split4Values(text)
+	switch len(values) {
+	case 1:
+		size, err := stringToSizeUnit(values[0])
+		if err != nil {
+			return DefaultBounds(), err
+		}
+		return Bounds{Top: size, Right: size, Bottom: size, Left: size}, nil
+
+	case 4:
+		sizes := [4]SizeUnit{}
+		for i, value := range values {
+			size, err := stringToSizeUnit(value)
+			if err != nil {
+				return DefaultBounds(), err
+			}
+			sizes[i] = size
+		}
+		return Bounds{Top: sizes[0], Right: sizes[1], Bottom: sizes[2], Left: sizes[3]}, nil
+	}
+	return DefaultBounds(), errors.New("1 or 4 values are expected")
+}
+
+func dataObjectToBounds(object DataObject) (Bounds, error) {
+	bounds := DefaultBounds()
+	for _, side := range []struct {
+		tag  string
+		size *SizeUnit
+	}{
+		{Top, &bounds.Top},
+		{Right, &bounds.Right},
+		{Bottom, &bounds.Bottom},
+		{Left, &bounds.Left},
+	} {
+		if text, ok := object.PropertyValue(side.tag); ok {
+			size, err := stringToSizeUnit(text)
+			if err != nil {
+				return DefaultBounds(), err
+			}
+			*side.size = size
+		}
+	}
+	return bounds, nil
+}
+
+// MarshalData converts the struct (or the pointer to a struct, or the map with string keys) to DataObject.
+// The property tags are defined by `rui:"tag"` struct tags (the field name is used if the tag is absent).
+// `rui:"tag,omitempty"` skips the field if it has the zero value. Nil pointers, maps, slices and interfaces
+// are always skipped. The tag of the result object is the name of the struct type
+// (or "_" for maps and anonymous structs), the tag of nested objects is "_"
+func MarshalData(v interface{}) DataObject {
+	value := reflect.ValueOf(v)
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		ErrorLog("MarshalData: the value is nil")
+		return nil
+	}
+
+	if object, ok := value.Interface().(DataObject); ok {
+		return object
+	}
+
+	tag := value.Type().Name()
+	if tag == "" {
+		tag = "_"
+	}
+
+	object := NewDataObject(tag)
+	switch value.Kind() {
+	case reflect.Struct:
+		if !isDataSimpleType(value.Type()) {
+			marshalDataStruct(value, object)
+			return object
+		}
+
+	case reflect.Map:
+		marshalDataMap(value, object)
+		return object
+	}
+
+	ErrorLogF("MarshalData: %s type is not supported", value.Type().String())
+	return nil
+}
+
+func marshalDataStruct(value reflect.Value, object DataObject) {
+	for _, structField := range dataStructFields(value.Type()) {
+		fieldValue := value
+		for _, index := range structField.index {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					fieldValue = reflect.Value{}
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			fieldValue = fieldValue.Field(index)
+		}
+
+		if !fieldValue.IsValid() || (structField.omitEmpty && fieldValue.IsZero()) {
+			continue
+		}
+		marshalDataProperty(structField.tag, fieldValue, object)
+	}
+}
+
+func marshalDataMap(value reflect.Value, object DataObject) {
+	if value.Type().Key().Kind() != reflect.String {
+		ErrorLogF("MarshalData: the key type of %s must be string", value.Type().String())
+		return
+	}
+
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	for _, key := range keys {
+		marshalDataProperty(key.String(), value.MapIndex(key), object)
+	}
+}
+
+func marshalDataProperty(tag string, value reflect.Value, object DataObject) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		if value.Type().Implements(dataObjectType) {
+			object.SetPropertyObject(tag, value.Interface().(DataObject))
+			return
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		if value.IsNil() {
+			return
+		}
+		array := make([]DataValue, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if element := marshalDataValue(value.Index(i)); element != nil {
+				array = append(array, element)
+			} else {
+				ErrorLogF(`MarshalData: the element %d of the "%s" property is not supported`, i, tag)
+			}
+		}
+		node := new(dataNode)
+		node.tag = tag
+		node.array = array
+		object.(*dataObject).setNode(node)
+		return
+	}
+
+	if value.Kind() == reflect.Map && value.IsNil() {
+		return
+	}
+
+	switch element := marshalDataValue(value); {
+	case element == nil:
+		ErrorLogF(`MarshalData: the "%s" property of %s type is not supported`, tag, value.Type().String())
+
+	case element.IsObject():
+		object.SetPropertyObject(tag, element.Object())
+
+	default:
+		object.SetPropertyValue(tag, element.Value())
+	}
+}
+
+// marshalDataValue converts the value to a text or an object. Returns nil if the value type is not supported
+func marshalDataValue(value reflect.Value) DataValue {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		if value.Type().Implements(dataObjectType) {
+			return value.Interface().(DataObject)
+		}
+		value = value.Elem()
+	}
+
+	text := func(text string) DataValue {
+		return &dataStringValue{value: text}
+	}
+
+	switch value.Type() {
+	case colorType:
+		return text(value.Interface().(Color).String())
+
+	case sizeUnitType:
+		return text(value.Interface().(SizeUnit).String())
+
+	case angleUnitType:
+		return text(value.Interface().(AngleUnit).String())
+
+	case boundsType:
+		bounds := value.Interface().(Bounds)
+		return text(bounds.String())
+
+	case timeType:
+		t := value.Interface().(time.Time)
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 && t.Location() == time.UTC {
+			return text(t.Format("2006-01-02"))
+		}
+		return text(t.Format(time.RFC3339Nano))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return text(value.String())
+
+	case reflect.Bool:
+		return text(strconv.FormatBool(value.Bool()))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return text(strconv.FormatInt(value.Int(), 10))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return text(strconv.FormatUint(value.Uint(), 10))
+
+	case reflect.Float32, reflect.Float64:
+		return text(strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()))
+
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return text(string(value.Bytes()))
+		}
+
+	case reflect.Struct:
+		object := NewDataObject("_")
+		marshalDataStruct(value, object)
+		return object
+
+	case reflect.Map:
+		object := NewDataObject("_")
+		marshalDataMap(value, object)
+		return object
+	}
+
+	return nil
+}
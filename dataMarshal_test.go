@@ -0,0 +1,231 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDataServer struct {
+	Host    string `rui:"host"`
+	Port    int    `rui:"port"`
+	Enabled bool   `rui:"enabled,omitempty"`
+}
+
+type testDataBase struct {
+	Name string `rui:"name"`
+}
+
+type testDataConfig struct {
+	testDataBase
+	Server   testDataServer            `rui:"server"`
+	Backup   *testDataServer           `rui:"backup"`
+	Mirrors  []testDataServer          `rui:"mirrors"`
+	Tags     []string                  `rui:"tags"`
+	Limits   map[string]float64        `rui:"limits"`
+	Color    Color                     `rui:"color"`
+	Size     SizeUnit                  `rui:"size"`
+	Angle    AngleUnit                 `rui:"angle"`
+	Padding  Bounds                    `rui:"padding"`
+	Date     time.Time                 `rui:"date"`
+	Hidden   string                    `rui:"-"`
+	Servers  map[string]testDataServer `rui:"servers"`
+	internal int
+}
+
+type testDataHidden struct {
+	A int
+}
+
+type TestDataEmbedPtr struct {
+	*testDataHidden
+	B int
+}
+
+func TestUnmarshalData(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	text := `config {
+		name = test,
+		server = _{ host = localhost, port = 8080, enabled = yes },
+		backup = _{ host = backup, port = 8081 },
+		mirrors = [ _{ host = m1, port = 1 }, _{ host = m2, port = 2 } ],
+		tags = "a, b, c",
+		limits = _{ cpu = 0.5, memory = 1024 },
+		color = #FF102030,
+		size = 1.5em,
+		angle = 90deg,
+		padding = _{ top = 4px, left = 8px },
+		date = 2022-05-17,
+		Hidden = hidden,
+		servers = _{ one = _{ host = h1, port = 11 } },
+	}`
+
+	obj := ParseDataText(text)
+	if obj == nil {
+		t.Fatal("ParseDataText error")
+	}
+
+	var config testDataConfig
+	if err := UnmarshalData(obj, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Name != "test" {
+		t.Errorf(`config.Name = "%s"`, config.Name)
+	}
+	if config.Server != (testDataServer{Host: "localhost", Port: 8080, Enabled: true}) {
+		t.Errorf(`config.Server = %v`, config.Server)
+	}
+	if config.Backup == nil || *config.Backup != (testDataServer{Host: "backup", Port: 8081}) {
+		t.Errorf(`config.Backup = %v`, config.Backup)
+	}
+	if len(config.Mirrors) != 2 || config.Mirrors[1].Host != "m2" || config.Mirrors[1].Port != 2 {
+		t.Errorf(`config.Mirrors = %v`, config.Mirrors)
+	}
+	if len(config.Tags) != 3 || config.Tags[0] != "a" || config.Tags[2] != "c" {
+		t.Errorf(`config.Tags = %v`, config.Tags)
+	}
+	if len(config.Limits) != 2 || config.Limits["cpu"] != 0.5 || config.Limits["memory"] != 1024 {
+		t.Errorf(`config.Limits = %v`, config.Limits)
+	}
+	if config.Color != 0xFF102030 {
+		t.Errorf(`config.Color = %s`, config.Color.String())
+	}
+	if config.Size != Em(1.5) {
+		t.Errorf(`config.Size = %s`, config.Size.String())
+	}
+	if config.Angle != Deg(90) {
+		t.Errorf(`config.Angle = %s`, config.Angle.String())
+	}
+	if config.Padding != (Bounds{Top: Px(4), Right: AutoSize(), Bottom: AutoSize(), Left: Px(8)}) {
+		t.Errorf(`config.Padding = %s`, config.Padding.String())
+	}
+	if !config.Date.Equal(time.Date(2022, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`config.Date = %s`, config.Date.String())
+	}
+	if config.Hidden != "" {
+		t.Errorf(`config.Hidden = "%s"`, config.Hidden)
+	}
+	if config.Servers["one"].Port != 11 {
+		t.Errorf(`config.Servers = %v`, config.Servers)
+	}
+
+	failText := []string{
+		`config { server = _{ port = port } }`,
+		`config { color = red1 }`,
+		`config { size = 10 }`,
+		`config { server = text }`,
+		`config { name = [a, b] }`,
+		`config { padding = "1px, 2px" }`,
+	}
+
+	for _, text := range failText {
+		var config testDataConfig
+		if err := UnmarshalData(ParseDataText(text), &config); err == nil {
+			t.Errorf("UnmarshalData(`%s`) must return an error", text)
+		}
+	}
+
+	if err := UnmarshalData(obj, config); err == nil {
+		t.Error("UnmarshalData must return an error for a non-pointer value")
+	}
+
+	// the fields of the embedded pointer to an unexported struct type are skipped
+	var embed TestDataEmbedPtr
+	if err := UnmarshalData(ParseDataText("x { A = 1, B = 2 }"), &embed); err != nil {
+		t.Error(err)
+	} else if embed.testDataHidden != nil || embed.B != 2 {
+		t.Errorf("UnmarshalData of the embedded pointer: %v", embed)
+	}
+	obj = MarshalData(TestDataEmbedPtr{testDataHidden: &testDataHidden{A: 1}, B: 2})
+	if _, ok := obj.PropertyValue("A"); ok {
+		t.Error("MarshalData must skip the fields of the embedded pointer to an unexported struct")
+	}
+}
+
+func TestMarshalData(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	config := testDataConfig{
+		testDataBase: testDataBase{Name: "test"},
+		Server:       testDataServer{Host: "localhost", Port: 8080},
+		Mirrors:      []testDataServer{{Host: "m1", Port: 1, Enabled: true}},
+		Tags:         []string{"a", "b"},
+		Limits:       map[string]float64{"memory": 1024, "cpu": 0.5},
+		Color:        0xFF102030,
+		Size:         Em(1.5),
+		Angle:        Deg(90),
+		Padding:      Bounds{Top: Px(4), Right: Px(4), Bottom: Px(4), Left: Px(4)},
+		Date:         time.Date(2022, 5, 17, 0, 0, 0, 0, time.UTC),
+		Hidden:       "hidden",
+	}
+
+	obj := MarshalData(&config)
+	if obj == nil {
+		t.Fatal("MarshalData error")
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	writeDataObject(obj, buffer)
+
+	expected := `testDataConfig{name=test,server=_{host=localhost,port=8080},mirrors=[_{host=m1,port=1,enabled=true}],` +
+		`tags=[a,b],limits=_{cpu=0.5,memory=1024},color=#FF102030,size=1.5em,angle=90deg,padding=4px,date=2022-05-17}`
+	if text := buffer.String(); text != expected {
+		t.Errorf("MarshalData result:\n%s\nexpected:\n%s", text, expected)
+	}
+
+	var config2 testDataConfig
+	if err := UnmarshalData(obj, &config2); err != nil {
+		t.Fatal(err)
+	}
+	config.Hidden = ""
+	if config2.Name != config.Name || config2.Server != config.Server || config2.Mirrors[0] != config.Mirrors[0] ||
+		config2.Color != config.Color || config2.Size != config.Size || config2.Angle != config.Angle ||
+		config2.Padding != config.Padding || !config2.Date.Equal(config.Date) || config2.Limits["cpu"] != 0.5 {
+		t.Errorf("Round trip result: %v", config2)
+	}
+}
+
+// writeDataObject writes the object in the compact rui text format
+func writeDataObject(obj DataObject, buffer *strings.Builder) {
+	buffer.WriteString(obj.Tag())
+	buffer.WriteRune('{')
+	for i := 0; i < obj.PropertyCount(); i++ {
+		if i > 0 {
+			buffer.WriteRune(',')
+		}
+		node := obj.Property(i)
+		buffer.WriteString(node.Tag())
+		buffer.WriteRune('=')
+		switch node.Type() {
+		case ObjectNode:
+			writeDataObject(node.Object(), buffer)
+
+		case ArrayNode:
+			buffer.WriteRune('[')
+			for k, value := range node.ArrayElements() {
+				if k > 0 {
+					buffer.WriteRune(',')
+				}
+				if value.IsObject() {
+					writeDataObject(value.Object(), buffer)
+				} else {
+					buffer.WriteString(value.Value())
+				}
+			}
+			buffer.WriteRune(']')
+
+		default:
+			buffer.WriteString(node.Text())
+		}
+	}
+	buffer.WriteRune('}')
+}
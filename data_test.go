@@ -1,6 +1,7 @@
 package rui
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -209,3 +210,98 @@ func TestParseDataText(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDataTextWithError(t *testing.T) {
+
+	type testError struct {
+		text         string
+		line, column int
+		expected     string
+		snippet      string
+	}
+
+	tests := []testError{
+		{
+			text:     "obj {\n\tkey1 = val1,\n\tkey2 val2\n}",
+			line:     3,
+			column:   7,
+			expected: "'='",
+			snippet:  "\tkey2 val2\n\t     ^",
+		},
+		{
+			text:     "obj { key = [ val1, val2 }",
+			line:     1,
+			column:   26,
+			expected: "']' or ','",
+			snippet:  "obj { key = [ val1, val2 }\n                         ^",
+		},
+		{
+			text:     "obj {\n key = \"val\n}",
+			line:     2,
+			column:   8,
+			expected: `'"'`,
+			snippet:  " key = \"val\n       ^",
+		},
+		{
+			text:    "obj {\r\n key = \"\\z\" }",
+			line:    2,
+			column:  9,
+			snippet: " key = \"\\z\" }\n        ^",
+		},
+		{
+			text:     "obj { key = val /* comment }",
+			line:     1,
+			column:   17,
+			expected: "'*/'",
+			snippet:  "obj { key = val /* comment }\n                ^",
+		},
+	}
+
+	for _, test := range tests {
+		obj, err := ParseDataTextWithError(test.text)
+		if obj != nil {
+			t.Errorf("result ParseDataTextWithError(%q) must be nil", test.text)
+		}
+
+		parseErr, ok := err.(*DataParseError)
+		if !ok {
+			t.Errorf("ParseDataTextWithError(%q) must return *DataParseError", test.text)
+			continue
+		}
+
+		if parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("ParseDataTextWithError(%q): line %d, column %d. Need: line %d, column %d",
+				test.text, parseErr.Line, parseErr.Column, test.line, test.column)
+		}
+		if parseErr.Expected != test.expected {
+			t.Errorf("ParseDataTextWithError(%q): expected %q. Need: %q", test.text, parseErr.Expected, test.expected)
+		}
+		if parseErr.Snippet != test.snippet {
+			t.Errorf("ParseDataTextWithError(%q): snippet %q. Need: %q", test.text, parseErr.Snippet, test.snippet)
+		}
+		if text := parseErr.Error(); strings.ContainsRune(text, '\n') {
+			t.Errorf("ParseDataTextWithError(%q): the error text is not one line: %q", test.text, text)
+		}
+	}
+
+	if _, err := ParseDataTextWithError("obj {\n key2 val2 }"); err == nil || err.Error() != "expected '=' after a tag name (line: 2, column: 7)" {
+		t.Errorf("Invalid error text: %v", err)
+	}
+
+	if obj, err := ParseDataTextWithError("obj { key = val }"); obj == nil || err != nil {
+		t.Error("ParseDataTextWithError error")
+	}
+
+	// ParseDataText returns the parsed data in case of a recoverable error, ParseDataTextWithError returns the error
+	text := "obj { key = val }\n/* comment"
+	SetErrorLog(func(text string) {})
+	defer SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+	if obj := ParseDataText(text); obj == nil || obj.PropertyCount() != 1 {
+		t.Errorf("ParseDataText(%q) must return the parsed data", text)
+	}
+	if obj, err := ParseDataTextWithError(text); obj != nil || err == nil {
+		t.Errorf("ParseDataTextWithError(%q) must return an error", text)
+	}
+}
@@ -118,6 +118,47 @@ func (picker *datePickerData) Set(tag string, value interface{}) bool {
 	return picker.set(picker.normalizeTag(tag), value)
 }
 
+// dateFormatForText returns the layout for time.Parse that matches the date text.
+// Supported formats: "20060102", "2006-01-02", "02-Jan-2006", "Jan-02-2006", "Jan-02-06",
+// "January 02, 2006", "02 January 2006", "01/02/2006", "01/02/06", and "010206"
+func dateFormatForText(text string) string {
+	format := "20060102"
+	if strings.ContainsRune(text, '-') {
+		if part := strings.Split(text, "-"); len(part) == 3 {
+			if part[0] != "" && part[0][0] > '9' {
+				if len(part[2]) == 2 {
+					format = "Jan-02-06"
+				} else {
+					format = "Jan-02-2006"
+				}
+			} else if part[1] != "" && part[1][0] > '9' {
+				format = "02-Jan-2006"
+			} else {
+				format = "2006-01-02"
+			}
+		}
+	} else if strings.ContainsRune(text, ' ') {
+		if part := strings.Split(text, " "); len(part) == 3 {
+			if part[0] != "" && part[0][0] > '9' {
+				format = "January 02, 2006"
+			} else {
+				format = "02 January 2006"
+			}
+		}
+	} else if strings.ContainsRune(text, '/') {
+		if part := strings.Split(text, "/"); len(part) == 3 {
+			if len(part[2]) == 2 {
+				format = "01/02/06"
+			} else {
+				format = "01/02/2006"
+			}
+		}
+	} else if len(text) == 6 {
+		format = "010206"
+	}
+	return format
+}
+
 func (picker *datePickerData) set(tag string, value interface{}) bool {
 	if value == nil {
 		picker.remove(tag)
@@ -132,41 +173,7 @@ func (picker *datePickerData) set(tag string, value interface{}) bool {
 
 		case string:
 			if text, ok := picker.Session().resolveConstants(value); ok {
-				format := "20060102"
-				if strings.ContainsRune(text, '-') {
-					if part := strings.Split(text, "-"); len(part) == 3 {
-						if part[0] != "" && part[0][0] > '9' {
-							if len(part[2]) == 2 {
-								format = "Jan-02-06"
-							} else {
-								format = "Jan-02-2006"
-							}
-						} else if part[1] != "" && part[1][0] > '9' {
-							format = "02-Jan-2006"
-						} else {
-							format = "2006-01-02"
-						}
-					}
-				} else if strings.ContainsRune(text, ' ') {
-					if part := strings.Split(text, " "); len(part) == 3 {
-						if part[0] != "" && part[0][0] > '9' {
-							format = "January 02, 2006"
-						} else {
-							format = "02 January 2006"
-						}
-					}
-				} else if strings.ContainsRune(text, '/') {
-					if part := strings.Split(text, "/"); len(part) == 3 {
-						if len(part[2]) == 2 {
-							format = "01/02/06"
-						} else {
-							format = "01/02/2006"
-						}
-					}
-				} else if len(text) == 6 {
-					format = "010206"
-				}
-
+				format := dateFormatForText(text)
 				if date, err := time.Parse(format, text); err == nil {
 					picker.properties[tag] = value
 					return date, true
@@ -313,10 +320,17 @@ func (picker *datePickerData) htmlTag() string {
 	return "input"
 }
 
+func (picker *datePickerData) languageChanged() {
+	if picker.created {
+		updateLangAttribute(picker.htmlID(), picker.session)
+	}
+}
+
 func (picker *datePickerData) htmlProperties(self View, buffer *strings.Builder) {
 	picker.viewData.htmlProperties(self, buffer)
 
 	buffer.WriteString(` type="date"`)
+	htmlLangAttribute(picker.session, buffer)
 
 	if min, ok := getDateProperty(picker, DatePickerMin, Min); ok {
 		buffer.WriteString(` min="`)
@@ -41,7 +41,7 @@ GridLayout {
 		Resizable {
 			row = 3, side = top, background-color = lightgrey, height = 200px,
 			content = EditView {
-				id = audioPlayerEventsLog, type = multiline, read-only = true, wrap = true,
+				id = audioPlayerEventsLog, type = multiline, readonly = true, wrap = true,
 			}
 		},
 	]
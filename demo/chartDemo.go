@@ -0,0 +1,59 @@
+package main
+
+import "github.com/anoshenko/rui"
+
+const chartDemoText = `
+GridLayout {
+	width = 100%, height = 100%, cell-width = "1fr, 1fr", cell-height = "1fr, 1fr",
+	content = [
+		LineChart {
+			id = lineChart, margin = 8px,
+			content = ["12, 19, 3, 5, 2, 3", "8, 4, 10, 12, 7, 9"],
+			categories = "Jan, Feb, Mar, Apr, May, Jun",
+			series-titles = "2021, 2022",
+		},
+		BarChart {
+			id = barChart, column = 1, margin = 8px,
+			content = ["12, 19, 3, 5, 2, 3", "8, 4, 10, 12, 7, 9"],
+			categories = "Jan, Feb, Mar, Apr, May, Jun",
+			series-titles = "2021, 2022",
+		},
+		PieChart {
+			id = pieChart, row = 1, margin = 8px,
+			content = "35, 25, 20, 12, 8",
+			categories = "Chrome, Safari, Edge, Firefox, Other",
+		},
+		ScatterChart {
+			id = scatterChart, row = 1, column = 1, margin = 8px, show-legend = false,
+		}
+	]
+}
+`
+
+type scatterDemoAdapter struct{}
+
+func (adapter scatterDemoAdapter) SeriesCount() int {
+	return 1
+}
+
+func (adapter scatterDemoAdapter) PointCount(series int) int {
+	return 20
+}
+
+func (adapter scatterDemoAdapter) Value(series, index int) float64 {
+	return float64((index*37)%23) - 5
+}
+
+func (adapter scatterDemoAdapter) XValue(series, index int) float64 {
+	return float64(index) * 1.5
+}
+
+func createChartDemo(session rui.Session) rui.View {
+	view := rui.CreateViewFromText(session, chartDemoText)
+	if view == nil {
+		return nil
+	}
+
+	rui.Set(view, "scatterChart", rui.Content, scatterDemoAdapter{})
+	return view
+}
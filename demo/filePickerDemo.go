@@ -15,7 +15,7 @@ GridLayout {
 			id = fileDownload, row = 0, column = 1, content = "Download file", disabled = true,
 		}
 		EditView {
-			id = selectedFileData, row = 1, column = 0:1, type = multiline, read-only = true, wrap = true,
+			id = selectedFileData, row = 1, column = 0:1, type = multiline, readonly = true, wrap = true,
 		}
 	]
 }
@@ -88,6 +88,7 @@ func createDemo(session rui.Session) rui.SessionContent {
 		{"EditView", createEditDemo, nil},
 		{"ImageView", createImageViewDemo, nil},
 		{"Canvas", createCanvasDemo, nil},
+		{"Charts", createChartDemo, nil},
 		{"VideoPlayer", createVideoPlayerDemo, nil},
 		{"AudioPlayer", createAudioPlayerDemo, nil},
 		{"Popups", createPopupDemo, nil},
@@ -30,7 +30,7 @@ GridLayout {
 		Resizable {
 			row = 1, side = top, background-color = lightgrey, height = 200px,
 			content = EditView {
-				id = mouseEventsLog, type = multiline, read-only = true, wrap = true,
+				id = mouseEventsLog, type = multiline, readonly = true, wrap = true,
 			}
 		},
 	]
@@ -29,7 +29,7 @@ GridLayout {
 		Resizable {
 			row = 1, side = top, background-color = lightgrey, height = 200px,
 			content = EditView {
-				id = pointerEventsLog, type = multiline, read-only = true, wrap = true,
+				id = pointerEventsLog, type = multiline, readonly = true, wrap = true,
 			}
 		},
 	]
@@ -30,7 +30,7 @@ GridLayout {
 		Resizable {
 			row = 1, side = top, background-color = lightgrey, height = 300px,
 			content = EditView {
-				id = touchEventsLog, type = multiline, read-only = true, wrap = true,
+				id = touchEventsLog, type = multiline, readonly = true, wrap = true,
 			}
 		},
 	]
@@ -44,7 +44,7 @@ GridLayout {
 		Resizable {
 			row = 3, side = top, background-color = lightgrey, height = 200px,
 			content = EditView {
-				id = videoPlayerEventsLog, type = multiline, read-only = true, wrap = true,
+				id = videoPlayerEventsLog, type = multiline, readonly = true, wrap = true,
 			}
 		},
 	]
@@ -117,7 +117,7 @@ func (detailsView *detailsViewData) set(tag string, value interface{}) bool {
 			}
 		}
 
-	case NotTranslate:
+	case NotTranslate, TextArgs:
 		if !detailsView.viewData.set(tag, value) {
 			return false
 		}
@@ -141,6 +141,14 @@ func (detailsView *detailsViewData) get(tag string) interface{} {
 	return detailsView.viewsContainerData.get(tag)
 }
 
+func (detailsView *detailsViewData) languageChanged() {
+	if detailsView.created && !GetNotTranslate(detailsView, "") {
+		if _, ok := detailsView.getRaw(Summary).(string); ok {
+			updateInnerHTML(detailsView.htmlID(), detailsView.Session())
+		}
+	}
+}
+
 func (detailsView *detailsViewData) htmlTag() string {
 	return "details"
 }
@@ -158,7 +166,7 @@ func (detailsView *detailsViewData) htmlSubviews(self View, buffer *strings.Buil
 		switch value := value.(type) {
 		case string:
 			if !GetNotTranslate(detailsView, "") {
-				value, _ = detailsView.session.GetString(value)
+				value = viewText(detailsView, value)
 			}
 			buffer.WriteString("<summary>")
 			buffer.WriteString(value)
@@ -376,6 +376,12 @@ func (list *dropDownListData) getItems() []string {
 	return list.items
 }
 
+func (list *dropDownListData) languageChanged() {
+	if list.created && len(list.items) > 0 && !GetNotTranslate(list, "") {
+		updateInnerHTML(list.htmlID(), list.session)
+	}
+}
+
 func (list *dropDownListData) htmlTag() string {
 	return "select"
 }
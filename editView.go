@@ -436,6 +436,14 @@ func (edit *editViewData) textChanged(newText string) {
 	edit.propertyChangedEvent(Text)
 }
 
+func (edit *editViewData) languageChanged() {
+	if edit.created {
+		if hint := GetHint(edit, ""); hint != "" {
+			updateProperty(edit.htmlID(), "placeholder", hint, edit.session)
+		}
+	}
+}
+
 func (edit *editViewData) htmlTag() string {
 	if GetEditViewType(edit, "") == MultiLineText {
 		return "textarea"
@@ -552,7 +560,8 @@ func (edit *editViewData) handleCommand(self View, command string, data DataObje
 	return edit.viewData.handleCommand(self, command, data)
 }
 
-// GetText returns a text of the EditView subview.
+// GetText returns a text of the EditView subview. Numbers and dates assigned to the "text" property
+// of TextView are formatted according to the session locale (see the "group-digits" property).
 // If the second argument (subviewID) is "" then a text of the first argument (view) is returned.
 func GetText(view View, subviewID string) string {
 	if subviewID != "" {
@@ -563,12 +572,13 @@ func GetText(view View, subviewID string) string {
 			if text, ok := value.(string); ok {
 				return text
 			}
+			return viewLocale(view).FormatValue(value)
 		}
 	}
 	return ""
 }
 
-// GetHint returns a hint text of the subview.
+// GetHint returns a hint text of the subview. The hint is translated by the string resources of the session language.
 // If the second argument (subviewID) is "" then a text of the first argument (view) is returned.
 func GetHint(view View, subviewID string) string {
 	if subviewID != "" {
@@ -576,12 +586,12 @@ func GetHint(view View, subviewID string) string {
 	}
 	if view != nil {
 		if text, ok := stringProperty(view, Hint, view.Session()); ok {
-			return text
+			return viewText(view, text)
 		}
 		if value := valueFromStyle(view, Hint); value != nil {
 			if text, ok := value.(string); ok {
 				if text, ok = view.Session().resolveConstants(text); ok {
-					return text
+					return viewText(view, text)
 				}
 			}
 		}
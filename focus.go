@@ -0,0 +1,149 @@
+package rui
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// TabIndex is the constant for the "tab-index" property tag.
+	// The "tab-index" int property defines the order of the view in the sequential keyboard navigation (Tab key).
+	// A negative value means that the view can receive focus (see FocusView) but is skipped by the keyboard navigation.
+	// 0 means that the view is placed in the navigation order according to its position in the document.
+	// Positive values define the order of the view: views with a lower value are visited first,
+	// views with 0 are visited after all views with positive values.
+	// If the property is set then the view can receive focus, the "focusable" property is not required.
+	TabIndex = "tab-index"
+	// FocusGroup is the constant for the "focus-group" property tag.
+	// The "focus-group" int property makes the container a focus group (for example, a toolbar).
+	// The focusable subviews of the group are one stop of the keyboard navigation: Tab key moves focus
+	// to the last focused subview of the group, the arrow keys move focus between the subviews of the group.
+	// Valid values are NoneFocusGroup (0), HorizontalFocusGroup (1), VerticalFocusGroup (2), and BothFocusGroup (3).
+	FocusGroup = "focus-group"
+)
+
+const (
+	// NoneFocusGroup - value of the "focus-group" property: the view is not a focus group
+	NoneFocusGroup = 0
+	// HorizontalFocusGroup - value of the "focus-group" property: the focus is moved by the left and right arrow keys
+	HorizontalFocusGroup = 1
+	// VerticalFocusGroup - value of the "focus-group" property: the focus is moved by the up and down arrow keys
+	VerticalFocusGroup = 2
+	// BothFocusGroup - value of the "focus-group" property: the focus is moved by all arrow keys
+	BothFocusGroup = 3
+)
+
+// GetTabIndex returns the value of the "tab-index" property of the view. If the property is not set then
+// 0 is returned for a focusable view and -1 for a not focusable one.
+// If the second argument (subviewID) is "" then a value of the first argument (view) is returned
+func GetTabIndex(view View, subviewID string) int {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if index, ok := intProperty(view, TabIndex, view.Session(), 0); ok {
+			return index
+		}
+		if view.Focusable() {
+			return 0
+		}
+	}
+	return -1
+}
+
+// GetFocusGroup returns the value of the "focus-group" property of the view: NoneFocusGroup (0),
+// HorizontalFocusGroup (1), VerticalFocusGroup (2), or BothFocusGroup (3).
+// If the second argument (subviewID) is "" then a value of the first argument (view) is returned
+func GetFocusGroup(view View, subviewID string) int {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if value, ok := enumProperty(view, FocusGroup, view.Session(), NoneFocusGroup); ok {
+			return value
+		}
+	}
+	return NoneFocusGroup
+}
+
+// viewTabIndex returns the value of the "tabindex" attribute of the view.
+// The second result is false if the attribute is not needed
+func viewTabIndex(view View, disabled bool) (int, bool) {
+	if disabled {
+		return 0, false
+	}
+	if index, ok := intProperty(view, TabIndex, view.Session(), 0); ok {
+		return index, true
+	}
+	return 0, view.Focusable()
+}
+
+func focusHtml(view View, disabled bool, buffer *strings.Builder) {
+	if index, ok := viewTabIndex(view, disabled); ok {
+		buffer.WriteString(`tabindex="`)
+		buffer.WriteString(strconv.Itoa(index))
+		buffer.WriteString(`" `)
+	}
+
+	if group := GetFocusGroup(view, ""); group != NoneFocusGroup {
+		buffer.WriteString(`data-focusgroup="`)
+		buffer.WriteString(enumProperties[FocusGroup].cssValues[group])
+		buffer.WriteString(`" `)
+	}
+}
+
+// updateFocusProperty updates the attribute of the view on the client side after the change of
+// "tab-index" or "focus-group" property
+func updateFocusProperty(view View, tag string) {
+	htmlID := view.htmlID()
+	session := view.Session()
+
+	switch tag {
+	case TabIndex:
+		if index, ok := viewTabIndex(view, IsDisabled(view, "")); ok {
+			updateProperty(htmlID, "tabindex", strconv.Itoa(index), session)
+		} else {
+			removeProperty(htmlID, "tabindex", session)
+		}
+		// the focus event handlers of TableView with the selection are set by the "selection-mode" property
+		if table, ok := view.(TableView); !ok || GetTableSelectionMode(table, "") == NoneSelection {
+			updateFocusEvents(view)
+		}
+
+	case FocusGroup:
+		if group := GetFocusGroup(view, ""); group != NoneFocusGroup {
+			updateProperty(htmlID, "data-focusgroup", enumProperties[FocusGroup].cssValues[group], session)
+		} else {
+			removeProperty(htmlID, "data-focusgroup", session)
+		}
+	}
+}
+
+func (session *sessionData) FocusedView() View {
+	if session.focusedView == nil {
+		return nil
+	}
+	// the view is searched in the view tree because it could be removed without the lost focus event.
+	// Also the focus event of CustomView is received by its super view, so the custom view is returned
+	view := session.viewByHTMLID(session.focusedView.htmlID())
+	if view == nil {
+		session.focusedView = nil
+	}
+	return view
+}
+
+func (session *sessionData) FocusNextView() {
+	session.runScript(`focusNextElement(false);`)
+}
+
+func (session *sessionData) FocusPrevView() {
+	session.runScript(`focusNextElement(true);`)
+}
+
+func (session *sessionData) viewFocusChanged(view View, focused bool) {
+	if focused {
+		session.focusedView = view
+	} else if session.focusedView == view {
+		session.focusedView = nil
+	}
+}
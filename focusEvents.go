@@ -125,6 +125,21 @@ func (view *viewData) removeFocusListener(tag string) {
 	}
 }
 
+// updateFocusEvents adds or removes the focus event handlers on the client side after the change
+// of the view focusability. The handler of the event with listeners is not removed
+func updateFocusEvents(view View) {
+	htmlID := view.htmlID()
+	session := view.Session()
+	focusable := view.Focusable()
+	for tag, js := range focusEvents {
+		if focusable || view.getRaw(tag) != nil {
+			updateProperty(htmlID, js.jsEvent, js.jsFunc+"(this, event)", session)
+		} else {
+			removeProperty(htmlID, js.jsEvent, session)
+		}
+	}
+}
+
 func getFocusListeners(view View, subviewID string, tag string) []func(View) {
 	if subviewID != "" {
 		view = ViewByID(view, subviewID)
@@ -0,0 +1,139 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTabIndexAndFocusGroup(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	first := NewTextView(session, Params{Text: "first", TabIndex: 2})
+	second := NewTextView(session, Params{Text: "second", TabIndex: "-1"})
+	plain := NewTextView(session, Params{Text: "plain"})
+	toolbar := NewListLayout(session, Params{
+		FocusGroup: "horizontal",
+		Content:    []View{first, second, plain},
+	})
+	session.rootView = toolbar
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(toolbar, buffer)
+	html := buffer.String()
+
+	for _, attr := range []string{
+		`data-focusgroup="horizontal"`,
+		`data-disabled="0" tabindex="2"`,
+		`tabindex="-1"`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+
+	if !first.Focusable() || plain.Focusable() {
+		t.Error("Invalid result of Focusable() of the view with tab-index")
+	}
+	if GetTabIndex(second, "") != -1 || GetTabIndex(plain, "") != -1 || GetTabIndex(NewButton(session, nil), "") != 0 {
+		t.Error("Invalid result of GetTabIndex")
+	}
+	if GetFocusGroup(toolbar, "") != HorizontalFocusGroup || GetFocusGroup(first, "") != NoneFocusGroup {
+		t.Error("Invalid result of GetFocusGroup")
+	}
+}
+
+func TestFocusedView(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	edit := NewEditView(session, nil)
+	button := NewButton(session, nil)
+	session.rootView = NewListLayout(session, Params{Content: []View{edit, button}})
+
+	if session.FocusedView() != nil {
+		t.Error("FocusedView() must be nil before the focus event")
+	}
+
+	edit.handleCommand(edit, FocusEvent, nil)
+	if session.FocusedView() != edit || !edit.HasFocus() {
+		t.Error("Invalid result of FocusedView() after the focus event")
+	}
+
+	// the lost focus event of other view does not change the focused view
+	button.handleCommand(button, LostFocusEvent, nil)
+	if session.FocusedView() != edit {
+		t.Error("Invalid result of FocusedView() after the lost focus event of other view")
+	}
+
+	edit.handleCommand(edit, LostFocusEvent, nil)
+	if session.FocusedView() != nil {
+		t.Error("FocusedView() must be nil after the lost focus event")
+	}
+
+	// the custom view is returned instead of its super view, the removed view is not returned
+	button.handleCommand(button, FocusEvent, nil)
+	if session.FocusedView() != button {
+		t.Error("Invalid result of FocusedView() after the focus event of Button")
+	}
+	session.rootView.(ViewsContainer).RemoveView(1)
+	if session.FocusedView() != nil {
+		t.Error("FocusedView() must be nil after the removing of the focused view")
+	}
+}
+
+func TestFocusPropertyUpdate(t *testing.T) {
+	createTestLog(t, false)
+
+	brige := new(testBrige)
+	session := new(sessionData)
+	session.brige = brige
+
+	text := NewTextView(session, Params{ID: "text", Text: "text"})
+	table := NewTableView(session, Params{ID: "table", Content: [][]string{{"1", "2"}}})
+	session.rootView = NewListLayout(session, Params{Content: []View{text, table}})
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(session.rootView, buffer)
+
+	// the focus event handlers are added with the tab-index at runtime
+	brige.scripts = nil
+	text.Set(TabIndex, 3)
+	script := strings.Join(brige.scripts, "\n")
+	for _, expected := range []string{
+		`updateProperty('` + text.htmlID() + `', 'tabindex', '3');`,
+		`updateProperty('` + text.htmlID() + `', 'onfocus', 'focusEvent(this, event)');`,
+		`updateProperty('` + text.htmlID() + `', 'onblur', 'blurEvent(this, event)');`,
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("%q not found in:\n%s", expected, script)
+		}
+	}
+
+	brige.scripts = nil
+	text.Remove(TabIndex)
+	script = strings.Join(brige.scripts, "\n")
+	if !strings.Contains(script, `removeProperty('`+text.htmlID()+`', 'onfocus');`) {
+		t.Errorf("the focus event handler is not removed:\n%s", script)
+	}
+
+	// the selection mode of TableView keeps the tab-index
+	table.Set(TabIndex, 5)
+	brige.scripts = nil
+	table.Set(SelectionMode, CellSelection)
+	script = strings.Join(brige.scripts, "\n")
+	if !strings.Contains(script, `updateProperty('`+table.htmlID()+`', 'tabindex', '5');`) ||
+		!strings.Contains(script, `'onfocus', 'tableViewFocusEvent(this, event)'`) ||
+		strings.Contains(script, `'focusEvent(this, event)'`) {
+		t.Errorf("invalid update of the table focus properties:\n%s", script)
+	}
+
+	brige.scripts = nil
+	table.Set(SelectionMode, NoneSelection)
+	script = strings.Join(brige.scripts, "\n")
+	if !strings.Contains(script, `updateProperty('`+table.htmlID()+`', 'tabindex', '5');`) ||
+		!strings.Contains(script, `updateProperty('`+table.htmlID()+`', 'onfocus', 'focusEvent(this, event)');`) {
+		t.Errorf("invalid update of the table focus properties:\n%s", script)
+	}
+}
@@ -0,0 +1,437 @@
+package rui
+
+import (
+	"embed"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const fontsDir = "fonts"
+
+// fontFace describes one @font-face rule
+type fontFace struct {
+	family       string
+	src          []string
+	weight       int
+	style        string
+	display      string
+	unicodeRange string
+}
+
+type fontResourceList struct {
+	faces []fontFace
+	css   string
+	mutex sync.Mutex
+}
+
+var fontResources fontResourceList
+
+// fontFormats defines the order of font files in the "src" list and their formats
+var fontFormats = []struct{ ext, format string }{
+	{".woff2", "woff2"},
+	{".woff", "woff"},
+	{".ttf", "truetype"},
+	{".otf", "opentype"},
+}
+
+func isFontFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, format := range fontFormats {
+		if format.ext == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func fontWeightByName(name string) (int, bool) {
+	switch strings.ToLower(name) {
+	case "thin", "hairline":
+		return 100, true
+
+	case "extralight", "ultralight":
+		return 200, true
+
+	case "light":
+		return 300, true
+
+	case "", "regular", "normal", "book":
+		return 400, true
+
+	case "medium":
+		return 500, true
+
+	case "semibold", "demibold":
+		return 600, true
+
+	case "bold":
+		return 700, true
+
+	case "extrabold", "ultrabold":
+		return 800, true
+
+	case "black", "heavy":
+		return 900, true
+	}
+
+	if n, err := strconv.Atoi(name); err == nil && n >= 1 && n <= 1000 {
+		return n, true
+	}
+	return 0, false
+}
+
+// parseFontFileName gets the family, the weight and the style from the file name.
+// The file name format: Family[-Weight][Italic|Oblique].ext, for example,
+// "Roboto.woff2", "Roboto-Bold.woff2", "Roboto-LightItalic.ttf", "Roboto-Italic.woff", "Roboto-300.woff2"
+func parseFontFileName(filename string) fontFace {
+	name := filepath.Base(filename)
+	name = name[:len(name)-len(filepath.Ext(name))]
+	face := fontFace{family: name, weight: 400, style: "normal"}
+
+	index := strings.LastIndex(name, "-")
+	if index <= 0 {
+		return face
+	}
+
+	suffix := name[index+1:]
+	style := "normal"
+	lowSuffix := strings.ToLower(suffix)
+	for _, tag := range []string{"italic", "oblique"} {
+		if strings.HasSuffix(lowSuffix, tag) {
+			style = tag
+			suffix = suffix[:len(suffix)-len(tag)]
+			break
+		}
+	}
+
+	if weight, ok := fontWeightByName(suffix); ok {
+		face.family = name[:index]
+		face.weight = weight
+		face.style = style
+	}
+	return face
+}
+
+// fontFileURL returns the URL of the file located in the "fonts" directory
+func fontFileURL(filename string) string {
+	if strings.Contains(filename, "://") || strings.HasPrefix(filename, "/") {
+		return filename
+	}
+	return fontsDir + "/" + filename
+}
+
+// addFontFiles registers the font files (names relative to the "fonts" directory) and descriptor objects.
+// The files mentioned in descriptors are not registered by their names
+func (fonts *fontResourceList) addFontFiles(files []string, descriptors []DataObject) {
+	fonts.mutex.Lock()
+	defer fonts.mutex.Unlock()
+
+	described := map[string]bool{}
+	for _, descriptor := range descriptors {
+		for _, face := range parseFontDescriptor(descriptor) {
+			for _, src := range face.src {
+				described[src] = true
+			}
+			fonts.addFace(face)
+		}
+	}
+
+	sort.Strings(files)
+	for _, file := range files {
+		if !described[file] {
+			face := parseFontFileName(file)
+			face.src = []string{file}
+			fonts.addFace(face)
+		}
+	}
+	fonts.css = ""
+}
+
+// addFace adds the face. The sources of faces with the same family, weight and style are joined
+func (fonts *fontResourceList) addFace(face fontFace) {
+	for i, f := range fonts.faces {
+		if f.family == face.family && f.weight == face.weight && f.style == face.style &&
+			f.unicodeRange == face.unicodeRange {
+			for _, src := range face.src {
+				exists := false
+				for _, s := range f.src {
+					if s == src {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					f.src = append(f.src, src)
+				}
+			}
+			if face.display != "" {
+				f.display = face.display
+			}
+			fonts.faces[i] = f
+			return
+		}
+	}
+	fonts.faces = append(fonts.faces, face)
+}
+
+// parseFontDescriptor parses the descriptor of fonts. Format:
+//
+//	fonts {
+//		"Family name" = [
+//			_{ src = "file.woff2", weight = bold, style = italic, display = swap, unicode-range = "U+0000-00FF" },
+//			...
+//		],
+//		...
+//	}
+func parseFontDescriptor(descriptor DataObject) []fontFace {
+	result := []fontFace{}
+	if descriptor == nil {
+		return result
+	}
+	if descriptor.Tag() != "fonts" {
+		ErrorLogF(`Invalid font descriptor tag "%s". The "fonts" tag is expected`, descriptor.Tag())
+		return result
+	}
+
+	parseFace := func(family string, obj DataObject) {
+		face := fontFace{family: family, weight: 400, style: "normal"}
+		for i := 0; i < obj.PropertyCount(); i++ {
+			node := obj.Property(i)
+			switch tag := strings.ToLower(node.Tag()); tag {
+			case "src":
+				if node.Type() == ArrayNode {
+					for _, value := range node.ArrayElements() {
+						if !value.IsObject() {
+							face.src = append(face.src, value.Value())
+						}
+					}
+				} else {
+					for _, src := range strings.Split(node.Text(), ",") {
+						if src = strings.Trim(src, " \t\n\r"); src != "" {
+							face.src = append(face.src, src)
+						}
+					}
+				}
+
+			case "weight":
+				if weight, ok := fontWeightByName(node.Text()); ok {
+					face.weight = weight
+				} else {
+					ErrorLogF(`Invalid weight "%s" of the "%s" font`, node.Text(), family)
+				}
+
+			case "style":
+				switch style := strings.ToLower(node.Text()); style {
+				case "normal", "italic", "oblique":
+					face.style = style
+
+				default:
+					ErrorLogF(`Invalid style "%s" of the "%s" font`, node.Text(), family)
+				}
+
+			case "display":
+				face.display = node.Text()
+
+			case "unicode-range":
+				face.unicodeRange = node.Text()
+
+			default:
+				ErrorLogF(`Unknown font descriptor property "%s"`, node.Tag())
+			}
+		}
+
+		if len(face.src) == 0 {
+			ErrorLogF(`The "src" of the "%s" font is not defined`, family)
+		} else {
+			result = append(result, face)
+		}
+	}
+
+	for i := 0; i < descriptor.PropertyCount(); i++ {
+		node := descriptor.Property(i)
+		switch node.Type() {
+		case ObjectNode:
+			parseFace(node.Tag(), node.Object())
+
+		case ArrayNode:
+			for _, value := range node.ArrayElements() {
+				if value.IsObject() {
+					parseFace(node.Tag(), value.Object())
+				}
+			}
+
+		default:
+			ErrorLogF(`Invalid description of the "%s" font`, node.Tag())
+		}
+	}
+	return result
+}
+
+func scanEmbedFontsDir(fs *embed.FS, dir string) {
+	files := []string{}
+	descriptors := []DataObject{}
+
+	var scan func(dir, prefix string)
+	scan = func(dir, prefix string) {
+		if entries, err := fs.ReadDir(dir); err == nil {
+			for _, entry := range entries {
+				name := entry.Name()
+				path := dir + "/" + name
+				if entry.IsDir() {
+					scan(path, prefix+name+"/")
+				} else if isFontFile(name) {
+					files = append(files, prefix+name)
+				} else if isDataFile(name) {
+					if data, err := fs.ReadFile(path); err == nil {
+						if descriptor := parseDataFile(path, data); descriptor != nil {
+							descriptors = append(descriptors, descriptor)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	scan(dir, "")
+	fontResources.addFontFiles(files, descriptors)
+}
+
+func scanFontsDir(path string) {
+	files := []string{}
+	descriptors := []DataObject{}
+
+	var scan func(path, prefix string)
+	scan = func(path, prefix string) {
+		if entries, err := ioutil.ReadDir(path); err == nil {
+			for _, entry := range entries {
+				name := entry.Name()
+				if name[0] == '.' {
+					continue
+				}
+				newPath := path + "/" + name
+				if entry.IsDir() {
+					scan(newPath, prefix+name+"/")
+				} else if isFontFile(name) {
+					files = append(files, prefix+name)
+				} else if isDataFile(name) {
+					if data, err := ioutil.ReadFile(newPath); err == nil {
+						if descriptor := parseDataFile(newPath, data); descriptor != nil {
+							descriptors = append(descriptors, descriptor)
+						}
+					} else {
+						ErrorLog(err.Error())
+					}
+				}
+			}
+		}
+	}
+
+	scan(path, "")
+	fontResources.addFontFiles(files, descriptors)
+}
+
+// isWebFont returns true if the font family is registered in the "fonts" resource directory
+func (fonts *fontResourceList) isWebFont(family string) bool {
+	fonts.mutex.Lock()
+	defer fonts.mutex.Unlock()
+
+	for _, face := range fonts.faces {
+		if strings.EqualFold(face.family, family) {
+			return true
+		}
+	}
+	return false
+}
+
+// cssText returns @font-face rules of all registered fonts
+func (fonts *fontResourceList) cssText() string {
+	fonts.mutex.Lock()
+	defer fonts.mutex.Unlock()
+
+	if fonts.css != "" || len(fonts.faces) == 0 {
+		return fonts.css
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	for _, face := range fonts.faces {
+		buffer.WriteString("@font-face {\n\tfont-family: '")
+		buffer.WriteString(face.family)
+		buffer.WriteString("';\n\tsrc: ")
+
+		sources := make([]string, len(face.src))
+		copy(sources, face.src)
+		formatIndex := func(src string) int {
+			ext := strings.ToLower(filepath.Ext(src))
+			for i, format := range fontFormats {
+				if format.ext == ext {
+					return i
+				}
+			}
+			return len(fontFormats)
+		}
+		sort.SliceStable(sources, func(i, j int) bool {
+			return formatIndex(sources[i]) < formatIndex(sources[j])
+		})
+
+		for i, src := range sources {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			url := fontFileURL(src)
+			if !strings.Contains(src, "://") && !strings.HasPrefix(src, "/") {
+				url = ResourceURL(url)
+			}
+			buffer.WriteString(`url('`)
+			buffer.WriteString(url)
+			buffer.WriteString(`')`)
+			if n := formatIndex(src); n < len(fontFormats) {
+				buffer.WriteString(` format('`)
+				buffer.WriteString(fontFormats[n].format)
+				buffer.WriteString(`')`)
+			}
+		}
+
+		buffer.WriteString(";\n\tfont-weight: ")
+		buffer.WriteString(strconv.Itoa(face.weight))
+		buffer.WriteString(";\n\tfont-style: ")
+		buffer.WriteString(face.style)
+		buffer.WriteString(";\n\tfont-display: ")
+		if face.display != "" {
+			buffer.WriteString(face.display)
+		} else {
+			buffer.WriteString("swap")
+		}
+		if face.unicodeRange != "" {
+			buffer.WriteString(";\n\tunicode-range: ")
+			buffer.WriteString(face.unicodeRange)
+		}
+		buffer.WriteString(";\n}\n")
+	}
+
+	fonts.css = buffer.String()
+	return fonts.css
+}
+
+// AllFontResources returns the sorted list of font families registered in the "fonts" resource directory
+func AllFontResources() []string {
+	fontResources.mutex.Lock()
+	defer fontResources.mutex.Unlock()
+
+	families := map[string]bool{}
+	for _, face := range fontResources.faces {
+		families[face.family] = true
+	}
+
+	result := make([]string, 0, len(families))
+	for family := range families {
+		result = append(result, family)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,67 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestParseFontFileName(t *testing.T) {
+	tests := []struct {
+		filename, family string
+		weight           int
+		style            string
+	}{
+		{"Roboto.woff2", "Roboto", 400, "normal"},
+		{"Roboto-Bold.woff2", "Roboto", 700, "normal"},
+		{"Roboto-LightItalic.ttf", "Roboto", 300, "italic"},
+		{"sub/Roboto-Italic.woff", "Roboto", 400, "italic"},
+		{"Corp Sans-300.otf", "Corp Sans", 300, "normal"},
+		{"Open-Sans.woff2", "Open-Sans", 400, "normal"},
+	}
+
+	for _, test := range tests {
+		face := parseFontFileName(test.filename)
+		if face.family != test.family || face.weight != test.weight || face.style != test.style {
+			t.Errorf(`parseFontFileName("%s") = %s, %d, %s`, test.filename, face.family, face.weight, face.style)
+		}
+	}
+}
+
+func TestFontResources(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	descriptor := ParseDataText(`fonts {
+		"Corp Sans" = [
+			_{ src = "https://example.com/corp.woff2", weight = bold, style = italic },
+			_{ src = "https://example.com/corp-light.woff2", weight = 300, display = block },
+		],
+	}`)
+
+	var fonts fontResourceList
+	fonts.addFontFiles([]string{"https://example.com/corp.woff2"}, []DataObject{descriptor})
+
+	expected := `@font-face {
+	font-family: 'Corp Sans';
+	src: url('https://example.com/corp.woff2') format('woff2');
+	font-weight: 700;
+	font-style: italic;
+	font-display: swap;
+}
+@font-face {
+	font-family: 'Corp Sans';
+	src: url('https://example.com/corp-light.woff2') format('woff2');
+	font-weight: 300;
+	font-style: normal;
+	font-display: block;
+}
+`
+	if css := fonts.cssText(); css != expected {
+		t.Errorf("cssText result:\n%s\nexpected:\n%s", css, expected)
+	}
+
+	if !fonts.isWebFont("corp sans") || fonts.isWebFont("Roboto") {
+		t.Error("isWebFont error")
+	}
+}
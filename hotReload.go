@@ -0,0 +1,283 @@
+package rui
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HotReloadContent is the optional interface of SessionContent which is used in the hot reload mode
+// (see StartHotReload). If SessionContent implements this interface then OnViewsReload is called
+// when the files of the "views" resource directory are changed. The "views" argument contains the names
+// of changed files (relative to the "views" directory). If the function returns true then the root view
+// is recreated by the CreateRootView function of SessionContent
+type HotReloadContent interface {
+	OnViewsReload(session Session, views []string) bool
+}
+
+type hotReloadFile struct {
+	modTime time.Time
+	size    int64
+}
+
+type hotReloadData struct {
+	stop   chan bool
+	files  map[string]hotReloadFile
+	active bool
+	mutex  sync.Mutex
+}
+
+var hotReload hotReloadData
+
+// StartHotReload starts the development mode in which the "themes", "strings", and "views" subdirectories
+// of the resource directory (see SetResourcePath) are polled with the given interval.
+// Changed themes and string tables are registered again, after that the theme CSS and the content
+// of all live sessions are updated. Views are recreated only for the sessions which content implements
+// the HotReloadContent interface. Resources embedded by AddEmbedResources are not watched
+func StartHotReload(interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	hotReload.mutex.Lock()
+	defer hotReload.mutex.Unlock()
+
+	if hotReload.active {
+		return
+	}
+
+	if resources.path == "" {
+		ErrorLog("StartHotReload: the resource path is not set")
+		return
+	}
+
+	hotReload.active = true
+	hotReload.stop = make(chan bool)
+	hotReload.files = scanHotReloadFiles()
+
+	go func(stop chan bool) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+
+			case <-ticker.C:
+				checkHotReloadFiles()
+			}
+		}
+	}(hotReload.stop)
+}
+
+// StopHotReload stops the development mode started by StartHotReload
+func StopHotReload() {
+	hotReload.mutex.Lock()
+	defer hotReload.mutex.Unlock()
+
+	if hotReload.active {
+		hotReload.active = false
+		close(hotReload.stop)
+		hotReload.files = nil
+	}
+}
+
+func isHotReloadActive() bool {
+	hotReload.mutex.Lock()
+	defer hotReload.mutex.Unlock()
+	return hotReload.active
+}
+
+// scanHotReloadFiles returns the modification times and sizes of the watched files.
+// The map key is the file path relative to the resource directory
+func scanHotReloadFiles() map[string]hotReloadFile {
+	result := map[string]hotReloadFile{}
+	for _, dir := range []string{themeDir, stringsDir, viewDir} {
+		root := resources.path + dir
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || strings.HasPrefix(info.Name(), ".") || !isDataFile(path) {
+				return nil
+			}
+			if rel, err := filepath.Rel(resources.path, path); err == nil {
+				result[filepath.ToSlash(rel)] = hotReloadFile{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return result
+}
+
+func checkHotReloadFiles() {
+	hotReload.mutex.Lock()
+	if !hotReload.active {
+		hotReload.mutex.Unlock()
+		return
+	}
+
+	files := scanHotReloadFiles()
+	changed := []string{}
+	for path, file := range files {
+		if old, ok := hotReload.files[path]; !ok || old != file {
+			changed = append(changed, path)
+		}
+	}
+	for path := range hotReload.files {
+		if _, ok := files[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	hotReload.files = files
+	hotReload.mutex.Unlock()
+
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		applyHotReload(changed)
+	}
+}
+
+func applyHotReload(changed []string) {
+	themesChanged := false
+	stringsChanged := false
+	views := []string{}
+
+	for _, path := range changed {
+		DebugLogF(`Resource file "%s" is changed`, path)
+		switch {
+		case strings.HasPrefix(path, themeDir+"/"):
+			themesChanged = true
+
+		case strings.HasPrefix(path, stringsDir+"/"):
+			stringsChanged = true
+
+		case strings.HasPrefix(path, viewDir+"/"):
+			views = append(views, path[len(viewDir)+1:])
+		}
+	}
+
+	if themesChanged {
+		reloadThemes()
+	}
+
+	if stringsChanged {
+		reloadStrings()
+	}
+
+	if len(views) > 0 {
+		reloadViewComponents()
+	}
+
+	for _, app := range allApps() {
+		for _, session := range app.allSessions() {
+			session.hotReload(views)
+		}
+	}
+}
+
+// embedResourceDirs returns the paths of the resource subdirectories with the given name
+func embedResourceDirs(fs *embed.FS, name string) []string {
+	result := []string{}
+	for _, dir := range embedRootDirs(fs) {
+		if dir == name {
+			result = append(result, dir)
+		} else if dir != imageDir && dir != themeDir && dir != viewDir && dir != rawDir && dir != stringsDir && dir != fontsDir {
+			if stat, err := fs.Open(dir + "/" + name); err == nil {
+				stat.Close()
+				result = append(result, dir+"/"+name)
+			}
+		}
+	}
+	return result
+}
+
+// reloadThemes registers all themes again. The new themes replace the old ones when they are loaded completely
+func reloadThemes() {
+	updateThemes(func(themes *themeList) {
+		themes.base = NewTheme("")
+		if theme, ok := CreateThemeFromText(defaultThemeText); ok {
+			themes.base = theme
+		}
+		themes.themes = map[string]Theme{}
+
+		for _, fs := range resources.embedFS {
+			for _, dir := range embedResourceDirs(fs, themeDir) {
+				scanEmbedThemesDir(fs, dir, themes)
+			}
+		}
+		scanThemesDir(resources.path+themeDir, themes)
+
+		for _, theme := range resources.addedThemes {
+			themes.add(theme)
+		}
+	})
+}
+
+// reloadStrings loads all string resources again. The new tables replace the old ones when they are loaded completely
+func reloadStrings() {
+	updateStrings(func(list *stringList) {
+		list.strings = map[string]map[string]string{}
+		list.plurals = map[string]map[string]map[string]string{}
+
+		for _, fs := range resources.embedFS {
+			for _, dir := range embedResourceDirs(fs, stringsDir) {
+				scanEmbedStringsDir(fs, dir, list)
+			}
+		}
+		scanStringsDir(resources.path+stringsDir, list)
+
+		for _, data := range resources.addedStrings {
+			list.load(data)
+		}
+	})
+}
+
+func (session *sessionData) hotReload(views []string) {
+	if session.events != nil {
+		data := NewDataObject("hot-reload")
+		node := new(dataNode)
+		node.tag = "views"
+		node.array = make([]DataValue, len(views))
+		for i, view := range views {
+			node.array[i] = &dataStringValue{value: view}
+		}
+		data.(*dataObject).setNode(node)
+
+		select {
+		case session.events <- data:
+		default:
+			ErrorLogF("Session #%d: the event queue is full, the hot reload is skipped", session.sessionID)
+		}
+	}
+}
+
+func (session *sessionData) handleHotReload(data DataObject) {
+	if !isHotReloadActive() {
+		return
+	}
+
+	if session.customTheme != nil {
+		if name := session.customTheme.Name(); name != "" {
+			if theme, ok := getTheme(name); ok {
+				session.customTheme = theme
+			}
+		}
+	}
+	session.currentTheme = nil
+
+	if node := data.PropertyWithTag("views"); node != nil && node.ArraySize() > 0 {
+		views := make([]string, 0, node.ArraySize())
+		for _, value := range node.ArrayElements() {
+			views = append(views, value.Value())
+		}
+
+		if content, ok := session.content.(HotReloadContent); ok && content.OnViewsReload(session, views) {
+			session.setContent(session.content, session)
+		}
+	}
+
+	session.reload()
+}
@@ -0,0 +1,122 @@
+package rui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestHotReload(t *testing.T) {
+	createTestLog(t, false)
+
+	dir, err := ioutil.TempDir("", "rui-hot-reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, text string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("themes/test.rui", `theme { name = testHotReload, colors = _{ fileColor = #FF000001 } }`)
+	writeFile("strings/test.rui", `strings:eo { hotReloadText = "first" }`)
+	if err := os.Mkdir(filepath.Join(dir, imageDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPath := resources.path
+	oldAdded := resources.addedThemes
+	oldAddedStrings := resources.addedStrings
+	oldDefault, oldThemes := defaultTheme, resources.themes
+	oldStrings, oldPlurals := stringResources, pluralResources
+	defer func() {
+		resources.path = oldPath
+		resources.addedThemes = oldAdded
+		resources.addedStrings = oldAddedStrings
+		defaultTheme, resources.themes = oldDefault, oldThemes
+		stringResources, pluralResources = oldStrings, oldPlurals
+	}()
+
+	addedTheme := NewTheme("testHotReload")
+	addedTheme.SetColor("addedColor", "#FF000010", "")
+	AddTheme(addedTheme)
+	if err := AddStringsFromText(`strings:eo { hotReloadAdded = "added" }`); err != nil {
+		t.Fatal(err)
+	}
+
+	SetResourcePath(dir)
+
+	checkColor := func(tag string, expected Color) {
+		theme, ok := getTheme("testHotReload")
+		if !ok {
+			t.Fatal(`"testHotReload" theme not found`)
+		}
+		if color, _ := theme.Color(tag); color != expected.String() {
+			t.Errorf(`"%s" color = "%s", expected "%s"`, tag, color, expected.String())
+		}
+	}
+
+	checkColor("fileColor", 0xFF000001)
+	checkColor("addedColor", 0xFF000010)
+	if text, _ := GetString("hotReloadText", "eo"); text != "first" {
+		t.Errorf(`GetString = "%s", expected "first"`, text)
+	}
+
+	hotReload.mutex.Lock()
+	hotReload.active = true
+	hotReload.stop = make(chan bool)
+	hotReload.files = scanHotReloadFiles()
+	hotReload.mutex.Unlock()
+	defer StopHotReload()
+
+	writeFile("themes/test.rui", `theme { name = testHotReload, colors = _{ fileColor = #FF000002, newColor = #FF000003 } }`)
+	writeFile("strings/test.rui", `strings:eo { hotReloadText = "second text" }`)
+
+	// the resources are read concurrently with the reload
+	var wait sync.WaitGroup
+	stop := make(chan bool)
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				lookupString("hotReloadText", "eo", nil)
+				if theme, ok := getTheme("testHotReload"); ok {
+					theme.Color("fileColor")
+				}
+				getDefaultTheme().Color("ruiTextColor")
+			}
+		}
+	}()
+
+	checkHotReloadFiles()
+	close(stop)
+	wait.Wait()
+
+	checkColor("fileColor", 0xFF000002)
+	checkColor("newColor", 0xFF000003)
+	checkColor("addedColor", 0xFF000010)
+	if text, _ := GetString("hotReloadText", "eo"); text != "second text" {
+		t.Errorf(`GetString = "%s", expected "second text"`, text)
+	}
+	if text, _ := GetString("hotReloadAdded", "eo"); text != "added" {
+		t.Errorf(`GetString of the added string after the reload = "%s", expected "added"`, text)
+	}
+
+	// the added theme object is not changed by the registration
+	if color, _ := addedTheme.Color("fileColor"); color != "" {
+		t.Errorf(`The added theme contains "fileColor" = "%s"`, color)
+	}
+}
@@ -267,6 +267,12 @@ func GetImageViewSource(view View, subviewID string) string {
 	return ""
 }
 
+func (imageView *imageViewData) languageChanged() {
+	if imageView.created && imageView.getRaw(AltText) != nil {
+		updateInnerHTML(imageView.htmlID(), imageView.session)
+	}
+}
+
 // GetImageViewAltText returns an alternative text description of an ImageView subview.
 // If the second argument (subviewID) is "" then a left position of the first argument (view) is returned
 func GetImageViewAltText(view View, subviewID string) string {
@@ -277,8 +283,7 @@ func GetImageViewAltText(view View, subviewID string) string {
 	if view != nil {
 		if value := view.getRaw(AltText); value != nil {
 			if text, ok := value.(string); ok {
-				text, _ = view.Session().GetString(text)
-				return text
+				return viewText(view, text)
 			}
 		}
 	}
@@ -0,0 +1,57 @@
+package rui
+
+// SessionLanguageListener is the listener interface of a session language change event.
+// The interface can be implemented by SessionContent and by views (for example, custom views).
+// The event occurs after all texts of string resources are updated
+type SessionLanguageListener interface {
+	OnLanguageChanged(session Session)
+}
+
+// languageDependentView is implemented by views which contain texts of string resources
+// or values formatted according to the session locale
+type languageDependentView interface {
+	languageChanged()
+}
+
+// viewsLanguageChanged updates the views of the tree in the browser and notifies the listeners
+func viewsLanguageChanged(view View, session Session) {
+	if view == nil {
+		return
+	}
+
+	if dependent, ok := view.(languageDependentView); ok {
+		dependent.languageChanged()
+	}
+
+	if container, ok := view.(ParanetView); ok {
+		for _, subview := range container.Views() {
+			viewsLanguageChanged(subview, session)
+		}
+	}
+
+	if listener, ok := view.(SessionLanguageListener); ok {
+		listener.OnLanguageChanged(session)
+	}
+}
+
+func (session *sessionData) onLanguageChanged() {
+	if session.title != "" {
+		session.SetTitle(session.title)
+	}
+
+	if session.rootView != nil {
+		viewsLanguageChanged(session.rootView, session)
+	}
+
+	if session.popups != nil {
+		for _, popup := range session.popups.popups {
+			viewsLanguageChanged(popup.rootView(), session)
+		}
+	}
+
+	if session.content != nil {
+		if listener, ok := session.content.(SessionLanguageListener); ok {
+			listener.OnLanguageChanged(session)
+		}
+	}
+}
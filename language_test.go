@@ -0,0 +1,86 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+type languageTestView struct {
+	View
+	changed []string
+}
+
+func (view *languageTestView) OnLanguageChanged(session Session) {
+	view.changed = append(view.changed, session.Language())
+}
+
+type languageTestContent struct {
+	languageTestView
+}
+
+func (content *languageTestContent) CreateRootView(session Session) View {
+	return nil
+}
+
+func TestSetLanguage(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	view := &languageTestView{View: NewTextView(session, Params{Text: "hello"})}
+	session.rootView = NewListLayout(session, Params{
+		Content: []View{NewTextView(session, nil), view},
+	})
+	content := new(languageTestContent)
+	session.content = content
+
+	session.SetLanguage("ru")
+	session.SetLanguage("ru")
+	session.SetLanguage("de")
+
+	for _, changed := range [][]string{view.changed, content.changed} {
+		if len(changed) != 2 || changed[0] != "ru" || changed[1] != "de" {
+			t.Errorf("Invalid language change events: %v", changed)
+		}
+	}
+}
+
+func TestLanguageChangedUpdates(t *testing.T) {
+	createTestLog(t, false)
+
+	loadStringResources(ParseDataText(`strings:eo {
+		testHello = "Saluton",
+		testTitle = "Titolo",
+		testButton = "Butono",
+	}`))
+	defer func() {
+		delete(stringResources, "eo")
+		delete(pluralResources, "eo")
+	}()
+
+	brige := new(testBrige)
+	session := new(sessionData)
+	session.brige = brige
+
+	text := NewTextView(session, Params{Text: "testHello"})
+	session.rootView = text
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(text, buffer)
+
+	popup := NewPopup(NewTextView(session, Params{Text: "content"}), Params{
+		Title:   "testTitle",
+		Buttons: []PopupButton{{Title: "testButton"}},
+	})
+	session.popupManager().showPopup(popup)
+
+	brige.scripts = nil
+	session.SetLanguage("eo")
+	script := strings.Join(brige.scripts, "\n")
+
+	// the texts of the root view and of the popup title and buttons are updated
+	for _, expected := range []string{"Saluton", "Titolo", "Butono"} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("%q not found in:\n%s", expected, script)
+		}
+	}
+}
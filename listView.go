@@ -259,6 +259,9 @@ func (listView *listViewData) set(tag string, value interface{}) bool {
 		result := listView.viewData.set(tag, value)
 		if result && listView.created {
 			updateInnerHTML(listView.htmlID(), listView.session)
+			if tag == Orientation {
+				listView.updateAriaOrientation()
+			}
 		}
 		return result
 
@@ -820,20 +823,27 @@ func (listView *listViewData) checkboxSubviews(self View, buffer *strings.Builde
 			buffer.WriteRune(' ')
 			buffer.WriteString(listView.currentInactiveStyle())
 		}
-		buffer.WriteString(`" onclick="listItemClickEvent(this, event)" data-left="0" data-top="0" data-width="0" data-height="0" style="display: grid; justify-items: stretch; align-items: stretch;`)
-		listView.itemSize(self, buffer)
-		buffer.WriteString(`">`)
-		buffer.WriteString(itemDiv)
 
 		checked := false
 		for _, index := range checkedItems {
 			if index == i {
-				buffer.WriteString(onDiv)
 				checked = true
 				break
 			}
 		}
-		if !checked {
+
+		buffer.WriteString(`" role="option" aria-selected="`)
+		buffer.WriteString(strconv.FormatBool(i == current))
+		buffer.WriteString(`" aria-checked="`)
+		buffer.WriteString(strconv.FormatBool(checked))
+		buffer.WriteString(`" onclick="listItemClickEvent(this, event)" data-left="0" data-top="0" data-width="0" data-height="0" style="display: grid; justify-items: stretch; align-items: stretch;`)
+		listView.itemSize(self, buffer)
+		buffer.WriteString(`">`)
+		buffer.WriteString(itemDiv)
+
+		if checked {
+			buffer.WriteString(onDiv)
+		} else {
 			buffer.WriteString(offDiv)
 		}
 		buffer.WriteString(contentDiv)
@@ -876,6 +886,8 @@ func (listView *listViewData) noneCheckboxSubviews(self View, buffer *strings.Bu
 			buffer.WriteRune(' ')
 			buffer.WriteString(listView.currentInactiveStyle())
 		}
+		buffer.WriteString(`" role="option" aria-selected="`)
+		buffer.WriteString(strconv.FormatBool(i == current))
 		buffer.WriteString(`" `)
 		buffer.WriteString(itemStyle)
 		buffer.WriteString(`>`)
@@ -927,6 +939,7 @@ func (listView *listViewData) updateCheckboxItem(index int, checked bool) {
 	buffer.WriteString(`</div></div>');`)
 
 	session.runScript(buffer.String())
+	updateProperty(listView.htmlID()+"-"+strconv.Itoa(index), "aria-checked", strconv.FormatBool(checked), session)
 }
 
 func (listView *listViewData) htmlProperties(self View, buffer *strings.Builder) {
@@ -943,12 +956,35 @@ func (listView *listViewData) htmlProperties(self View, buffer *strings.Builder)
 		buffer.WriteString(listView.htmlID())
 		buffer.WriteRune('-')
 		buffer.WriteString(strconv.Itoa(current))
+		buffer.WriteString(`" aria-activedescendant="`)
+		buffer.WriteString(listView.htmlID())
+		buffer.WriteRune('-')
+		buffer.WriteString(strconv.Itoa(current))
 		buffer.WriteRune('"')
 	}
 
+	switch GetListOrientation(listView, "") {
+	case StartToEndOrientation, EndToStartOrientation:
+		buffer.WriteString(` aria-orientation="horizontal"`)
+	}
+
 	listView.viewData.htmlProperties(self, buffer)
 }
 
+func (listView *listViewData) updateAriaOrientation() {
+	switch GetListOrientation(listView, "") {
+	case StartToEndOrientation, EndToStartOrientation:
+		updateProperty(listView.htmlID(), "aria-orientation", "horizontal", listView.session)
+
+	default:
+		removeProperty(listView.htmlID(), "aria-orientation", listView.session)
+	}
+}
+
+func (listView *listViewData) ariaRole() string {
+	return viewRole(listView, "listbox")
+}
+
 /*
 func (listView *listViewData) cssStyle(self View, builder cssBuilder) {
 	listView.viewData.cssStyle(self, builder)
@@ -974,7 +1010,7 @@ func (listView *listViewData) htmlSubviews(self View, buffer *strings.Builder) {
 		defer listView.session.setIgnoreViewUpdates(false)
 	}
 
-	buffer.WriteString(`<div style="display: flex; align-content: stretch;`)
+	buffer.WriteString(`<div role="none" style="display: flex; align-content: stretch;`)
 
 	wrap := GetListWrap(listView, "")
 	orientation := GetListOrientation(listView, "")
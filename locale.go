@@ -0,0 +1,337 @@
+package rui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale describes the rules of the number, currency and date formatting of a language.
+// The built-in rules can be replaced by RegisterLocale or by the following keys of the string resources:
+// "locale-decimal-separator", "locale-group-separator", "locale-currency-symbol", "locale-currency-format",
+// "locale-date-format", "locale-time-format", and "locale-first-day-of-week"
+type Locale struct {
+	// Language is the ISO 639-1 code of the language, for example, "en" or "ru-RU"
+	Language string
+	// DecimalSeparator separates the integer and fraction parts of a number
+	DecimalSeparator string
+	// GroupSeparator separates the groups of three digits of the integer part of a number
+	GroupSeparator string
+	// CurrencySymbol is the default currency symbol
+	CurrencySymbol string
+	// CurrencyFormat is the pattern of a currency value: "¤" is replaced by the currency symbol
+	// and "#" is replaced by the formatted number, for example, "¤#" or "# ¤"
+	CurrencyFormat string
+	// DateFormat is the date layout in the Go time package format, for example, "01/02/2006"
+	DateFormat string
+	// TimeFormat is the time layout in the Go time package format, for example, "15:04"
+	TimeFormat string
+	// FirstDayOfWeek is the first day of a week: time.Sunday, time.Monday, etc.
+	FirstDayOfWeek time.Weekday
+}
+
+var locales = struct {
+	list  map[string]Locale
+	mutex sync.RWMutex
+}{
+	list: map[string]Locale{},
+}
+
+func init() {
+	const nbsp = "\u00a0"
+
+	builtin := func(decimal, group, currency, currencyFormat, date, timeFormat string, firstDay time.Weekday, langs ...string) {
+		for _, lang := range langs {
+			locales.list[lang] = Locale{
+				Language:         lang,
+				DecimalSeparator: decimal,
+				GroupSeparator:   group,
+				CurrencySymbol:   currency,
+				CurrencyFormat:   currencyFormat,
+				DateFormat:       date,
+				TimeFormat:       timeFormat,
+				FirstDayOfWeek:   firstDay,
+			}
+		}
+	}
+
+	builtin(".", ",", "$", "¤#", "01/02/2006", "3:04 PM", time.Sunday, "en", "en-us")
+	builtin(".", ",", "£", "¤#", "02/01/2006", "15:04", time.Monday, "en-gb")
+	builtin(",", ".", "€", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "de", "nl")
+	builtin(",", ".", "kr.", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "da")
+	builtin(",", ".", "₺", "¤#", "02.01.2006", "15:04", time.Monday, "tr")
+	builtin(",", "'", "CHF", "¤"+nbsp+"#", "02.01.2006", "15:04", time.Monday, "de-ch")
+	builtin(",", nbsp, "€", "#"+nbsp+"¤", "02/01/2006", "15:04", time.Monday, "fr", "pt")
+	builtin(",", ".", "€", "#"+nbsp+"¤", "02/01/2006", "15:04", time.Monday, "es", "it", "el")
+	builtin(",", nbsp, "₽", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "ru")
+	builtin(",", nbsp, "₴", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "uk")
+	builtin(",", nbsp, "Br", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "be")
+	builtin(",", nbsp, "zł", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "pl")
+	builtin(",", nbsp, "Kč", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "cs")
+	builtin(",", nbsp, "kr", "#"+nbsp+"¤", "2006-01-02", "15:04", time.Monday, "sv", "nb", "no")
+	builtin(",", nbsp, "€", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Monday, "fi")
+	builtin(".", ",", "¥", "¤#", "2006/01/02", "15:04", time.Sunday, "ja", "zh")
+	builtin(".", ",", "₩", "¤#", "2006. 01. 02.", "15:04", time.Sunday, "ko")
+	builtin(".", ",", "₪", "#"+nbsp+"¤", "02.01.2006", "15:04", time.Sunday, "he")
+	builtin(".", ",", "$", "¤#", "02/01/2006", "15:04", time.Saturday, "ar")
+	builtin(",", ".", "R$", "¤"+nbsp+"#", "02/01/2006", "15:04", time.Sunday, "pt-br")
+}
+
+// RegisterLocale sets the formatting rules of the language defined by the Language field
+func RegisterLocale(locale Locale) {
+	if lang := strings.ToLower(strings.ReplaceAll(locale.Language, "_", "-")); lang != "" {
+		locales.mutex.Lock()
+		locales.list[lang] = locale
+		locales.mutex.Unlock()
+	}
+}
+
+// GetLocale returns the formatting rules of the language. The rules of the language without
+// the region subtag ("ru-RU" -> "ru") or English rules are used if the language is not registered.
+// The result contains the overrides from the string resources of the language
+func GetLocale(lang string) Locale {
+	key := strings.ToLower(strings.ReplaceAll(strings.Trim(lang, " \t"), "_", "-"))
+	base := ""
+	if n := strings.IndexRune(key, '-'); n > 0 {
+		base = key[:n]
+	}
+
+	locales.mutex.RLock()
+	locale, ok := locales.list[key]
+	if !ok && base != "" {
+		locale, ok = locales.list[base]
+	}
+	if !ok {
+		locale = locales.list["en"]
+	}
+	locales.mutex.RUnlock()
+
+	locale.Language = lang
+	if base != "" {
+		locale.applyStrings(base)
+	}
+	locale.applyStrings(lang)
+	return locale
+}
+
+// applyStrings replaces the rules by the "locale-..." string resources of the language
+func (locale *Locale) applyStrings(lang string) {
+	table, _, ok := languageStrings(lang)
+	if !ok {
+		return
+	}
+
+	for key, field := range map[string]*string{
+		"locale-decimal-separator": &locale.DecimalSeparator,
+		"locale-group-separator":   &locale.GroupSeparator,
+		"locale-currency-symbol":   &locale.CurrencySymbol,
+		"locale-currency-format":   &locale.CurrencyFormat,
+		"locale-date-format":       &locale.DateFormat,
+		"locale-time-format":       &locale.TimeFormat,
+	} {
+		if text, ok := table[key]; ok {
+			*field = text
+		}
+	}
+
+	if text, ok := table["locale-first-day-of-week"]; ok {
+		text = strings.ToLower(strings.Trim(text, " \t"))
+		if n, err := strconv.Atoi(text); err == nil && n >= 0 && n < 7 {
+			locale.FirstDayOfWeek = time.Weekday(n)
+		} else {
+			found := false
+			for day := time.Sunday; day <= time.Saturday; day++ {
+				if strings.ToLower(day.String()) == text {
+					locale.FirstDayOfWeek = day
+					found = true
+					break
+				}
+			}
+			if !found {
+				ErrorLogF(`Invalid value "%s" of the "locale-first-day-of-week" string of the "%s" language`, text, lang)
+			}
+		}
+	}
+}
+
+// groupDigits inserts the group separator into the string of digits
+func (locale Locale) groupDigits(digits string) string {
+	if locale.GroupSeparator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	first := len(digits) % 3
+	if first > 0 {
+		buffer.WriteString(digits[:first])
+	}
+	for i := first; i < len(digits); i += 3 {
+		if i > 0 {
+			buffer.WriteString(locale.GroupSeparator)
+		}
+		buffer.WriteString(digits[i : i+3])
+	}
+	return buffer.String()
+}
+
+// localizeNumber converts the number in the Go format ("-1234.5") to the locale format
+func (locale Locale) localizeNumber(text string) string {
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+		text = text[1:]
+	}
+
+	fraction := ""
+	if n := strings.IndexRune(text, '.'); n >= 0 {
+		fraction = locale.DecimalSeparator + text[n+1:]
+		text = text[:n]
+	}
+	return sign + locale.groupDigits(text) + fraction
+}
+
+// FormatFloat returns the text of the number with the locale separators.
+// The precision is the number of digits after the decimal separator, -1 means the minimal necessary number of digits
+func (locale Locale) FormatFloat(value float64, precision int) string {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return locale.localizeNumber(strconv.FormatFloat(value, 'f', precision, 64))
+}
+
+// FormatInt returns the text of the integer with the locale group separator
+func (locale Locale) FormatInt(value int) string {
+	return locale.localizeNumber(strconv.Itoa(value))
+}
+
+// FormatNumber returns the text of the number of any int or float type. For other types "" and false are returned
+func (locale Locale) FormatNumber(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case float32:
+		if math.IsInf(float64(value), 0) || math.IsNaN(float64(value)) {
+			return strconv.FormatFloat(float64(value), 'g', -1, 32), true
+		}
+		return locale.localizeNumber(strconv.FormatFloat(float64(value), 'f', -1, 32)), true
+
+	case float64:
+		return locale.FormatFloat(value, -1), true
+	}
+
+	if n, ok := isInt(value); ok {
+		return locale.FormatInt(n), true
+	}
+	return "", false
+}
+
+// FormatCurrency returns the text of the amount with two fraction digits and the currency symbol.
+// If the symbol is "" then the CurrencySymbol of the locale is used
+func (locale Locale) FormatCurrency(value float64, symbol string) string {
+	if symbol == "" {
+		symbol = locale.CurrencySymbol
+	}
+
+	number := locale.FormatFloat(math.Abs(value), 2)
+	format := locale.CurrencyFormat
+	if format == "" {
+		format = "¤#"
+	}
+	text := strings.Replace(strings.Replace(format, "#", number, 1), "¤", symbol, 1)
+	if value < 0 {
+		return "-" + text
+	}
+	return text
+}
+
+// FormatDate returns the text of the date according to the DateFormat of the locale
+func (locale Locale) FormatDate(value time.Time) string {
+	return value.Format(locale.DateFormat)
+}
+
+// FormatTime returns the text of the time according to the TimeFormat of the locale
+func (locale Locale) FormatTime(value time.Time) string {
+	return value.Format(locale.TimeFormat)
+}
+
+// FormatDateTime returns the text of the date and the time according to the locale
+func (locale Locale) FormatDateTime(value time.Time) string {
+	return value.Format(locale.DateFormat + " " + locale.TimeFormat)
+}
+
+// FormatValue returns the text of a number or a time.Time value. The date without time
+// is formatted by FormatDate, otherwise FormatDateTime is used. For other types fmt.Sprint is used
+func (locale Locale) FormatValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+
+	case time.Time:
+		if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 && value.Nanosecond() == 0 {
+			return locale.FormatDate(value)
+		}
+		return locale.FormatDateTime(value)
+	}
+
+	if text, ok := locale.FormatNumber(value); ok {
+		return text
+	}
+	return fmt.Sprint(value)
+}
+
+func (session *sessionData) Locale() Locale {
+	return GetLocale(session.Language())
+}
+
+// IsGroupDigits returns the value of the "group-digits" property of TableView or TextView.
+// If the second argument (subviewID) is "" then a value from the first argument (view) is returned.
+func IsGroupDigits(view View, subviewID string) bool {
+	if subviewID != "" {
+		view = ViewByID(view, subviewID)
+	}
+	if view != nil {
+		if group, ok := boolStyledProperty(view, GroupDigits); ok {
+			return group
+		}
+	}
+	return false
+}
+
+// viewLocale returns the session locale of the view without the group separator
+// if the "group-digits" property of the view is not set to true
+func viewLocale(view View) Locale {
+	locale := view.Session().Locale()
+	if !IsGroupDigits(view, "") {
+		locale.GroupSeparator = ""
+	}
+	return locale
+}
+
+// langTag returns the BCP 47 language tag of the locale with the "fw" (first day of week)
+// Unicode extension, for example, "de-u-fw-mon"
+func (locale Locale) langTag() string {
+	lang := strings.ReplaceAll(locale.Language, "_", "-")
+	if lang == "" || strings.Contains(strings.ToLower(lang), "-u-") {
+		return lang
+	}
+	return lang + "-u-fw-" + strings.ToLower(locale.FirstDayOfWeek.String()[:3])
+}
+
+// htmlLangAttribute writes the "lang" attribute with the language tag of the session locale.
+// The browser uses it to present the values of the number, date and time input elements
+// and to select the first day of week of the calendar
+func htmlLangAttribute(session Session, buffer *strings.Builder) {
+	if lang := session.Locale().langTag(); lang != "" {
+		buffer.WriteString(` lang="`)
+		buffer.WriteString(lang)
+		buffer.WriteByte('"')
+	}
+}
+
+// updateLangAttribute updates the "lang" attribute of the input element after the change of the session language
+func updateLangAttribute(htmlID string, session Session) {
+	updateProperty(htmlID, "lang", session.Locale().langTag(), session)
+}
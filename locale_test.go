@@ -0,0 +1,123 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocale(t *testing.T) {
+	date := time.Date(2022, time.March, 7, 0, 0, 0, 0, time.UTC)
+	dateTime := time.Date(2022, time.March, 7, 14, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		lang     string
+		value    interface{}
+		expected string
+	}{
+		{"en", 1234567.25, "1,234,567.25"},
+		{"en", -1234, "-1,234"},
+		{"en", 123, "123"},
+		{"en", float32(0.5), "0.5"},
+		{"en", date, "03/07/2022"},
+		{"en", dateTime, "03/07/2022 2:05 PM"},
+		{"de", 1234567.25, "1.234.567,25"},
+		{"de-AT", 1234.5, "1.234,5"},
+		{"ru_RU", 1234567.25, "1\u00a0234\u00a0567,25"},
+		{"ru", dateTime, "07.03.2022 14:05"},
+		{"xx", 1000, "1,000"},
+		{"en", "text", "text"},
+	}
+
+	for _, test := range tests {
+		if text := GetLocale(test.lang).FormatValue(test.value); text != test.expected {
+			t.Errorf(`GetLocale("%s").FormatValue(%v) = "%s", expected "%s"`, test.lang, test.value, text, test.expected)
+		}
+	}
+
+	if text := GetLocale("en").FormatCurrency(-1234.5, ""); text != "-$1,234.50" {
+		t.Errorf(`FormatCurrency: "%s", expected "-$1,234.50"`, text)
+	}
+	if text := GetLocale("da").FormatCurrency(10, ""); text != "10,00\u00a0kr." {
+		t.Errorf(`FormatCurrency: %q, expected "10,00\u00a0kr."`, text)
+	}
+	if text := GetLocale("tr").FormatCurrency(10, ""); text != "₺10,00" {
+		t.Errorf(`FormatCurrency: %q, expected "₺10,00"`, text)
+	}
+	if text := GetLocale("fr").FormatCurrency(10, ""); text != "10,00\u00a0€" {
+		t.Errorf(`FormatCurrency: %q, expected "10,00\u00a0€"`, text)
+	}
+
+	if GetLocale("en").FirstDayOfWeek != time.Sunday || GetLocale("de").FirstDayOfWeek != time.Monday {
+		t.Error("Invalid first day of week")
+	}
+
+	loadStringResources(ParseDataText(`strings:eo {
+		locale-decimal-separator = ",",
+		locale-group-separator = ".",
+		locale-date-format = "2006-01-02",
+		locale-first-day-of-week = monday,
+	}`))
+	defer delete(stringResources, "eo")
+
+	locale := GetLocale("eo")
+	if text := locale.FormatValue(1234.5); text != "1.234,5" {
+		t.Errorf(`FormatValue(1234.5) = "%s", expected "1.234,5"`, text)
+	}
+	if text := locale.FormatDate(date); text != "2022-03-07" {
+		t.Errorf(`FormatDate = "%s", expected "2022-03-07"`, text)
+	}
+	if locale.FirstDayOfWeek != time.Monday {
+		t.Errorf("FirstDayOfWeek = %v, expected Monday", locale.FirstDayOfWeek)
+	}
+}
+
+func TestLocaleViews(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	session.language = "de_DE"
+
+	if lang := session.Locale().langTag(); lang != "de-DE-u-fw-mon" {
+		t.Errorf(`langTag() = "%s", expected "de-DE-u-fw-mon"`, lang)
+	}
+
+	textView := NewTextView(session, Params{Text: 2024})
+	if text := GetText(textView, ""); text != "2024" {
+		t.Errorf(`GetText = "%s", expected "2024"`, text)
+	}
+	textView.Set(GroupDigits, true)
+	if text := GetText(textView, ""); text != "2.024" || !IsGroupDigits(textView, "") {
+		t.Errorf(`GetText = "%s", expected "2.024"`, text)
+	}
+	textView.Set(Text, 1234.5)
+	textView.Set(GroupDigits, false)
+	if text := GetText(textView, ""); text != "1234,5" {
+		t.Errorf(`GetText = "%s", expected "1234,5"`, text)
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	table := NewTableView(session, Params{Content: [][]interface{}{{2024, 1234.5}}})
+	viewHTML(table, buffer)
+	if html := buffer.String(); !strings.Contains(html, ">2024<") || !strings.Contains(html, ">1234,5<") {
+		t.Errorf("Invalid numbers of TableView: %s", html)
+	}
+
+	buffer.Reset()
+	table.Set(GroupDigits, true)
+	viewHTML(table, buffer)
+	if html := buffer.String(); !strings.Contains(html, ">2.024<") || !strings.Contains(html, ">1.234,5<") {
+		t.Errorf("Invalid numbers of TableView with group-digits: %s", html)
+	}
+
+	buffer.Reset()
+	viewHTML(NewNumberPicker(session, Params{NumberPickerValue: 0.5}), buffer)
+	html := buffer.String()
+	for _, attr := range []string{`lang="de-DE-u-fw-mon"`, `aria-valuetext="0,5"`} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("%s is not found in %s", attr, html)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package rui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MediaRule describes the conditions of a theme style section, for example, "styles:portrait:width600-1200".
+// The zero value describes the main styles section
+type MediaRule struct {
+	// Orientation is the screen orientation: DefaultMedia (0), PortraitMedia (1), or LandscapeMedia (2)
+	Orientation int
+	// MinWidth is the minimal screen width in logical pixels (0 - no limit)
+	MinWidth int
+	// MaxWidth is the maximal screen width in logical pixels (0 - no limit)
+	MaxWidth int
+	// MinHeight is the minimal screen height in logical pixels (0 - no limit)
+	MinHeight int
+	// MaxHeight is the maximal screen height in logical pixels (0 - no limit)
+	MaxHeight int
+	// ReducedMotion - the user prefers to minimize the amount of animation ("prefers-reduced-motion" element)
+	ReducedMotion bool
+	// Hover - the primary input device can hover over elements ("hover" element)
+	Hover bool
+	// CoarsePointer - the primary pointing device has limited accuracy, for example, a finger ("pointer-coarse" element)
+	CoarsePointer bool
+	// MoreContrast - the user prefers more contrast ("prefers-contrast" element)
+	MoreContrast bool
+	// Print - the styles are used for printing instead of a screen ("print" element)
+	Print bool
+}
+
+// features returns the media features which are set by flags
+func (rule MediaRule) features() []struct {
+	flag          bool
+	element, text string
+} {
+	return []struct {
+		flag          bool
+		element, text string
+	}{
+		{rule.ReducedMotion, "prefers-reduced-motion", "(prefers-reduced-motion: reduce)"},
+		{rule.Hover, "hover", "(hover: hover)"},
+		{rule.CoarsePointer, "pointer-coarse", "(pointer: coarse)"},
+		{rule.MoreContrast, "prefers-contrast", "(prefers-contrast: more)"},
+	}
+}
+
+// cssText returns the media query of the rule, for example, "screen and (max-width: 600px)"
+func (rule MediaRule) cssText() string {
+	builder := allocStringBuilder()
+	defer freeStringBuilder(builder)
+
+	if rule.Print {
+		builder.WriteString("print")
+	} else {
+		builder.WriteString("screen")
+	}
+
+	switch rule.Orientation {
+	case PortraitMedia:
+		builder.WriteString(" and (orientation: portrait)")
+
+	case LandscapeMedia:
+		builder.WriteString(" and (orientation: landscape)")
+	}
+
+	for _, limit := range []struct {
+		name  string
+		value int
+	}{
+		{"min-width", rule.MinWidth},
+		{"max-width", rule.MaxWidth},
+		{"min-height", rule.MinHeight},
+		{"max-height", rule.MaxHeight},
+	} {
+		if limit.value > 0 {
+			builder.WriteString(" and (")
+			builder.WriteString(limit.name)
+			builder.WriteString(": ")
+			builder.WriteString(strconv.Itoa(limit.value))
+			builder.WriteString("px)")
+		}
+	}
+
+	for _, feature := range rule.features() {
+		if feature.flag {
+			builder.WriteString(" and ")
+			builder.WriteString(feature.text)
+		}
+	}
+
+	return builder.String()
+}
+
+// sectionName returns the name of the theme section of the rule, for example, "styles:portrait:width600-1200"
+func (rule MediaRule) sectionName() string {
+	builder := allocStringBuilder()
+	defer freeStringBuilder(builder)
+
+	builder.WriteString("styles")
+	switch rule.Orientation {
+	case PortraitMedia:
+		builder.WriteString(":portrait")
+
+	case LandscapeMedia:
+		builder.WriteString(":landscape")
+	}
+
+	writeRange := func(name string, min, max int) {
+		if min > 0 || max > 0 {
+			builder.WriteRune(':')
+			builder.WriteString(name)
+			if min > 0 {
+				builder.WriteString(strconv.Itoa(min))
+				builder.WriteRune('-')
+			}
+			if max > 0 {
+				builder.WriteString(strconv.Itoa(max))
+			}
+		}
+	}
+	writeRange("width", rule.MinWidth, rule.MaxWidth)
+	writeRange("height", rule.MinHeight, rule.MaxHeight)
+
+	for _, feature := range rule.features() {
+		if feature.flag {
+			builder.WriteRune(':')
+			builder.WriteString(feature.element)
+		}
+	}
+
+	if rule.Print {
+		builder.WriteString(":print")
+	}
+
+	return builder.String()
+}
+
+// parseMediaRange parses the range of the section element: "600" - maximum, "600-" - minimum, "600-1200" - range
+func parseMediaRange(text string) (int, int, bool) {
+	parse := func(text string) (int, bool) {
+		n, err := strconv.Atoi(text)
+		return n, err == nil && n > 0
+	}
+
+	if n := strings.IndexRune(text, '-'); n >= 0 {
+		min, ok := parse(text[:n])
+		if !ok {
+			return 0, 0, false
+		}
+		if n == len(text)-1 {
+			return min, 0, true
+		}
+		max, ok := parse(text[n+1:])
+		if !ok || max < min {
+			return 0, 0, false
+		}
+		return min, max, true
+	}
+
+	max, ok := parse(text)
+	return 0, max, ok
+}
+
+func parseMediaRule(text string) (mediaStyle, bool) {
+	rule := mediaStyle{
+		styles: map[string]ViewStyle{},
+	}
+
+	elements := strings.Split(text, ":")
+	for i := 1; i < len(elements); i++ {
+		switch element := elements[i]; element {
+		case "portrait", "landscape":
+			if rule.Orientation != DefaultMedia {
+				ErrorLog(`Duplicate orientation tag in the style section "` + text + `"`)
+				return rule, false
+			}
+			if element == "portrait" {
+				rule.Orientation = PortraitMedia
+			} else {
+				rule.Orientation = LandscapeMedia
+			}
+
+		case "print":
+			if rule.Print {
+				ErrorLog(`Duplicate "print" tag in the style section "` + text + `"`)
+				return rule, false
+			}
+			rule.Print = true
+
+		default:
+			found := false
+			for _, feature := range []struct {
+				element string
+				flag    *bool
+			}{
+				{"prefers-reduced-motion", &rule.ReducedMotion},
+				{"hover", &rule.Hover},
+				{"pointer-coarse", &rule.CoarsePointer},
+				{"prefers-contrast", &rule.MoreContrast},
+			} {
+				if element == feature.element {
+					if *feature.flag {
+						ErrorLogF(`Duplicate "%s" tag in the style section "%s"`, element, text)
+						return rule, false
+					}
+					*feature.flag = true
+					found = true
+					break
+				}
+			}
+			if found {
+				continue
+			}
+
+			for _, dimension := range []struct {
+				name     string
+				min, max *int
+			}{
+				{"width", &rule.MinWidth, &rule.MaxWidth},
+				{"height", &rule.MinHeight, &rule.MaxHeight},
+			} {
+				if strings.HasPrefix(element, dimension.name) {
+					if *dimension.min != 0 || *dimension.max != 0 {
+						ErrorLogF(`Duplicate "%s" tag in the style section "%s"`, dimension.name, text)
+						return rule, false
+					}
+					min, max, ok := parseMediaRange(element[len(dimension.name):])
+					if !ok {
+						ErrorLogF(`Invalid element "%s" of the style section name "%s"`, element, text)
+						return rule, false
+					}
+					*dimension.min = min
+					*dimension.max = max
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				ErrorLogF(`Unknown element "%s" in the style section name "%s"`, element, text)
+				return rule, false
+			}
+		}
+	}
+	return rule, true
+}
+
+// mediaRuleLess defines the cascade order of the media rules: a more specific rule is placed after
+// a less specific one. The rules are ordered by the orientation, then wider ranges of the width and
+// the height are placed before narrower ones, and then the rules with fewer media features are placed first
+func mediaRuleLess(rule1, rule2 MediaRule) bool {
+	if rule1.Print != rule2.Print {
+		return !rule1.Print
+	}
+
+	if rule1.Orientation != rule2.Orientation {
+		return rule1.Orientation < rule2.Orientation
+	}
+
+	span := func(min, max int) int {
+		if max == 0 {
+			return math.MaxInt32 - min
+		}
+		return max - min
+	}
+
+	if span1, span2 := span(rule1.MinWidth, rule1.MaxWidth), span(rule2.MinWidth, rule2.MaxWidth); span1 != span2 {
+		return span1 > span2
+	}
+	if rule1.MinWidth != rule2.MinWidth {
+		return rule1.MinWidth < rule2.MinWidth
+	}
+
+	if span1, span2 := span(rule1.MinHeight, rule1.MaxHeight), span(rule2.MinHeight, rule2.MaxHeight); span1 != span2 {
+		return span1 > span2
+	}
+	if rule1.MinHeight != rule2.MinHeight {
+		return rule1.MinHeight < rule2.MinHeight
+	}
+
+	count := func(rule MediaRule) int {
+		result := 0
+		for _, feature := range rule.features() {
+			if feature.flag {
+				result++
+			}
+		}
+		return result
+	}
+	return count(rule1) < count(rule2)
+}
@@ -0,0 +1,123 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMediaRule(t *testing.T) {
+	createTestLog(t, false)
+
+	tests := []struct {
+		section string
+		rule    MediaRule
+		css     string
+	}{
+		{"styles:portrait:width320", MediaRule{Orientation: PortraitMedia, MaxWidth: 320},
+			"screen and (orientation: portrait) and (max-width: 320px)"},
+		{"styles:width600-1200", MediaRule{MinWidth: 600, MaxWidth: 1200},
+			"screen and (min-width: 600px) and (max-width: 1200px)"},
+		{"styles:height480-", MediaRule{MinHeight: 480},
+			"screen and (min-height: 480px)"},
+		{"styles:prefers-reduced-motion:hover:pointer-coarse:prefers-contrast",
+			MediaRule{ReducedMotion: true, Hover: true, CoarsePointer: true, MoreContrast: true},
+			"screen and (prefers-reduced-motion: reduce) and (hover: hover) and (pointer: coarse) and (prefers-contrast: more)"},
+		{"styles:landscape:print", MediaRule{Orientation: LandscapeMedia, Print: true},
+			"print and (orientation: landscape)"},
+	}
+
+	for _, test := range tests {
+		media, ok := parseMediaRule(test.section)
+		if !ok {
+			t.Errorf(`parseMediaRule("%s") failed`, test.section)
+			continue
+		}
+		if media.MediaRule != test.rule {
+			t.Errorf(`parseMediaRule("%s") = %+v, expected %+v`, test.section, media.MediaRule, test.rule)
+		}
+		if css := media.cssText(); css != test.css {
+			t.Errorf(`"%s": cssText() = "%s", expected "%s"`, test.section, css, test.css)
+		}
+		if name := test.rule.sectionName(); name != test.section {
+			t.Errorf(`sectionName() = "%s", expected "%s"`, name, test.section)
+		}
+	}
+
+	createTestLog(t, true)
+	for _, section := range []string{"styles:width0", "styles:width1200-600", "styles:hover:hover", "styles:print:wide"} {
+		if _, ok := parseMediaRule(section); ok {
+			t.Errorf(`parseMediaRule("%s") must fail`, section)
+		}
+	}
+}
+
+func TestMediaStylesOrder(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		styles:width320 = [ s1 { width = 1px } ],
+		styles:width600- = [ s1 { width = 2px } ],
+		styles:width1200 = [ s1 { width = 3px } ],
+		styles:width320-800 = [ s1 { width = 4px } ],
+		styles:landscape = [ s1 { width = 5px } ],
+		styles:print = [ s1 { width = 6px } ],
+		styles:width1200:hover = [ s1 { width = 7px } ],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	theme.SetMediaRuleStyle("s2", MediaRule{MinWidth: 1000}, NewViewStyle(Params{Width: Px(8)}))
+	if style := theme.MediaRuleStyle("s2", MediaRule{MinWidth: 1000}); style == nil {
+		t.Error("MediaRuleStyle returns nil")
+	}
+	if style := theme.MediaStyle("s1", DefaultMedia, 320, 0); style == nil {
+		t.Error("MediaStyle returns nil")
+	}
+
+	sections := []string{}
+	for _, media := range theme.data().mediaStyles {
+		sections = append(sections, media.sectionName())
+	}
+
+	expected := "styles:width600-, styles:width1000-, styles:width1200, styles:width1200:hover, styles:width320-800, " +
+		"styles:width320, styles:landscape, styles:print"
+	if result := strings.Join(sections, ", "); result != expected {
+		t.Errorf("Invalid media styles order:\n%s\nexpected:\n%s", result, expected)
+	}
+}
+
+func TestMergedMediaStylesOrder(t *testing.T) {
+	createTestLog(t, false)
+
+	base, err := CreateThemeFromTextWithError(`theme {
+		name = testMediaBase,
+		styles:width600 = [ s1 { width = 1px } ],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		name = testMediaTheme,
+		extends = testMediaBase,
+		styles:width1200 = [ s1 { width = 2px } ],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addTheme(base)
+	defer delete(resources.themes, "testMediaBase")
+
+	sections := []string{}
+	for _, media := range mergedTheme(theme, false).data().mediaStyles {
+		sections = append(sections, media.sectionName())
+	}
+
+	// the narrower rule is output later, so it overrides the wider one
+	expected := "styles:width1200, styles:width600"
+	if result := strings.Join(sections, ", "); result != expected {
+		t.Errorf("Invalid merged media styles order: %s, expected: %s", result, expected)
+	}
+}
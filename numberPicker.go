@@ -155,6 +155,7 @@ func (picker *numberPickerData) set(tag string, value interface{}) bool {
 			if newValue := GetNumberPickerValue(picker, ""); oldValue != newValue {
 				if picker.created {
 					picker.session.runScript(fmt.Sprintf(`setInputValue('%s', '%f')`, picker.htmlID(), newValue))
+					picker.updateValueText()
 				}
 				for _, listener := range picker.numberChangedListeners {
 					listener(picker, newValue)
@@ -201,6 +202,7 @@ func (picker *numberPickerData) propertyChanged(tag string) {
 		case NumberPickerValue:
 			value := GetNumberPickerValue(picker, "")
 			picker.session.runScript(fmt.Sprintf(`setInputValue('%s', '%f')`, picker.htmlID(), value))
+			picker.updateValueText()
 			for _, listener := range picker.numberChangedListeners {
 				listener(picker, value)
 			}
@@ -226,6 +228,24 @@ func (picker *numberPickerData) htmlTag() string {
 	return "input"
 }
 
+func (picker *numberPickerData) languageChanged() {
+	if picker.created {
+		updateLangAttribute(picker.htmlID(), picker.session)
+		picker.updateValueText()
+	}
+}
+
+// valueText returns the value formatted according to the session locale. It is used as "aria-valuetext".
+// The value is formatted with the float32 precision as the value received from the client
+func (picker *numberPickerData) valueText() string {
+	text, _ := picker.session.Locale().FormatNumber(float32(GetNumberPickerValue(picker, "")))
+	return text
+}
+
+func (picker *numberPickerData) updateValueText() {
+	updateProperty(picker.htmlID(), "aria-valuetext", picker.valueText(), picker.session)
+}
+
 func (picker *numberPickerData) htmlProperties(self View, buffer *strings.Builder) {
 	picker.viewData.htmlProperties(self, buffer)
 
@@ -234,6 +254,7 @@ func (picker *numberPickerData) htmlProperties(self View, buffer *strings.Builde
 	} else {
 		buffer.WriteString(` type="number"`)
 	}
+	htmlLangAttribute(picker.session, buffer)
 
 	min, max := GetNumberPickerMinMax(picker, "")
 	if min != math.Inf(-1) {
@@ -259,6 +280,8 @@ func (picker *numberPickerData) htmlProperties(self View, buffer *strings.Builde
 
 	buffer.WriteString(` value="`)
 	buffer.WriteString(strconv.FormatFloat(GetNumberPickerValue(picker, ""), 'f', -1, 64))
+	buffer.WriteString(`" aria-valuetext="`)
+	buffer.WriteString(picker.valueText())
 	buffer.WriteByte('"')
 
 	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
@@ -279,6 +302,7 @@ func (picker *numberPickerData) handleCommand(self View, command string, data Da
 				oldValue := GetNumberPickerValue(picker, "")
 				picker.properties[NumberPickerValue] = value
 				if value != oldValue {
+					picker.updateValueText()
 					for _, listener := range picker.numberChangedListeners {
 						listener(picker, value)
 					}
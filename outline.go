@@ -104,11 +104,18 @@ type ViewOutline struct {
 }
 
 func (outline ViewOutline) cssValue(builder cssBuilder) {
+	if outline.Color.Alpha() > 0 {
+		outline.cssValueWithColor(builder, outline.Color.cssString())
+	}
+}
+
+// cssValueWithColor writes the outline with the CSS color value (for example, "var(--ruiHighlightColor)")
+func (outline ViewOutline) cssValueWithColor(builder cssBuilder, color string) {
 	values := enumProperties[BorderStyle].cssValues
-	if outline.Style > 0 && outline.Style < len(values) && outline.Color.Alpha() > 0 &&
+	if outline.Style > 0 && outline.Style < len(values) &&
 		outline.Width.Type != Auto && outline.Width.Type != SizeInFraction &&
 		outline.Width.Type != SizeInPercent && outline.Width.Value > 0 {
-		builder.addValues("outline", " ", outline.Width.cssString("0"), values[outline.Style], outline.Color.cssString())
+		builder.addValues("outline", " ", outline.Width.cssString("0"), values[outline.Style], color)
 	}
 }
 
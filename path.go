@@ -1,6 +1,7 @@
 package rui
 
 import (
+	"math"
 	"strconv"
 	"strings"
 )
@@ -63,11 +64,19 @@ type Path interface {
 	// If the shape has already been closed or has only one point, this function does nothing.
 	Close()
 
+	// SVGData returns the path as a SVG path data string (the value of the "d" attribute)
+	SVGData() string
+
 	scriptText() string
 }
 
 type pathData struct {
-	script strings.Builder
+	script   strings.Builder
+	svg      strings.Builder
+	hasPoint bool
+	x, y     float64
+	startX   float64
+	startY   float64
 }
 
 // NewPath creates a new empty Path
@@ -81,6 +90,95 @@ func NewPath() Path {
 func (path *pathData) Reset() {
 	path.script.Reset()
 	path.script.WriteString("\nctx.beginPath();")
+	path.svg.Reset()
+	path.hasPoint = false
+}
+
+func (path *pathData) svgCommand(command rune, args ...float64) {
+	if path.svg.Len() > 0 {
+		path.svg.WriteRune(' ')
+	}
+	path.svg.WriteRune(command)
+	for i, arg := range args {
+		if i > 0 {
+			path.svg.WriteRune(',')
+		}
+		path.svg.WriteString(strconv.FormatFloat(arg, 'g', -1, 64))
+	}
+}
+
+func (path *pathData) svgLineTo(x, y float64) {
+	if !path.hasPoint {
+		path.svgMoveTo(x, y)
+	} else if math.Abs(path.x-x) > 1e-9 || math.Abs(path.y-y) > 1e-9 {
+		path.svgCommand('L', x, y)
+		path.x, path.y = x, y
+	}
+}
+
+func (path *pathData) svgMoveTo(x, y float64) {
+	path.svgCommand('M', x, y)
+	path.x, path.y = x, y
+	path.startX, path.startY = x, y
+	path.hasPoint = true
+}
+
+// svgEllipseArc appends to the SVG data the arc of the ellipse with the center (x, y)
+// between the startAngle and endAngle (in radians).
+func (path *pathData) svgEllipseArc(x, y, radiusX, radiusY, rotation, startAngle, endAngle float64, clockwise bool) {
+	point := func(angle float64) (float64, float64) {
+		cos, sin := math.Cos(angle), math.Sin(angle)
+		rotCos, rotSin := math.Cos(rotation), math.Sin(rotation)
+		return x + radiusX*cos*rotCos - radiusY*sin*rotSin,
+			y + radiusX*cos*rotSin + radiusY*sin*rotCos
+	}
+
+	var sweep float64
+	if clockwise {
+		sweep = endAngle - startAngle
+	} else {
+		sweep = startAngle - endAngle
+	}
+	if sweep >= 2*math.Pi {
+		sweep = 2 * math.Pi
+	} else {
+		sweep = math.Mod(sweep, 2*math.Pi)
+		if sweep < 0 {
+			sweep += 2 * math.Pi
+		}
+	}
+
+	path.svgLineTo(point(startAngle))
+	if sweep == 0 {
+		return
+	}
+
+	sweepFlag := 0.0
+	direction := -1.0
+	if clockwise {
+		sweepFlag = 1
+		direction = 1
+	}
+	degrees := rotation * 180 / math.Pi
+
+	if sweep >= 2*math.Pi-1e-9 {
+		// a full ellipse can not be defined by a single SVG arc
+		middleX, middleY := point(startAngle + direction*math.Pi)
+		path.svgCommand('A', radiusX, radiusY, degrees, 0, sweepFlag, middleX, middleY)
+		sweep = math.Pi
+		startAngle += direction * math.Pi
+	}
+
+	largeArc := 0.0
+	if sweep > math.Pi {
+		largeArc = 1
+	}
+	path.x, path.y = point(startAngle + direction*sweep)
+	path.svgCommand('A', radiusX, radiusY, degrees, largeArc, sweepFlag, path.x, path.y)
+}
+
+func (path *pathData) SVGData() string {
+	return path.svg.String()
 }
 
 func (path *pathData) MoveTo(x, y float64) {
@@ -89,6 +187,7 @@ func (path *pathData) MoveTo(x, y float64) {
 	path.script.WriteRune(',')
 	path.script.WriteString(strconv.FormatFloat(y, 'g', -1, 64))
 	path.script.WriteString(");")
+	path.svgMoveTo(x, y)
 }
 
 func (path *pathData) LineTo(x, y float64) {
@@ -97,6 +196,12 @@ func (path *pathData) LineTo(x, y float64) {
 	path.script.WriteRune(',')
 	path.script.WriteString(strconv.FormatFloat(y, 'g', -1, 64))
 	path.script.WriteString(");")
+	if path.hasPoint {
+		path.svgCommand('L', x, y)
+		path.x, path.y = x, y
+	} else {
+		path.svgMoveTo(x, y)
+	}
 }
 
 func (path *pathData) ArcTo(x0, y0, x1, y1, radius float64) {
@@ -112,7 +217,39 @@ func (path *pathData) ArcTo(x0, y0, x1, y1, radius float64) {
 		path.script.WriteRune(',')
 		path.script.WriteString(strconv.FormatFloat(radius, 'g', -1, 64))
 		path.script.WriteString(");")
+		path.svgArcTo(x0, y0, x1, y1, radius)
+	}
+}
+
+func (path *pathData) svgArcTo(x0, y0, x1, y1, radius float64) {
+	if !path.hasPoint {
+		path.svgMoveTo(x0, y0)
+		return
+	}
+
+	// vectors from the first control point to the current point and to the second control point
+	ax, ay := path.x-x0, path.y-y0
+	bx, by := x1-x0, y1-y0
+	lenA := math.Hypot(ax, ay)
+	lenB := math.Hypot(bx, by)
+	cross := ax*by - ay*bx
+	if lenA == 0 || lenB == 0 || math.Abs(cross) < 1e-9 {
+		path.svgLineTo(x0, y0)
+		return
+	}
+
+	cos := (ax*bx + ay*by) / (lenA * lenB)
+	angle := math.Acos(math.Max(-1, math.Min(1, cos)))
+	distance := radius / math.Tan(angle/2)
+
+	path.svgLineTo(x0+ax*distance/lenA, y0+ay*distance/lenA)
+
+	sweepFlag := 0.0
+	if cross < 0 {
+		sweepFlag = 1
 	}
+	path.x, path.y = x0+bx*distance/lenB, y0+by*distance/lenB
+	path.svgCommand('A', radius, radius, 0, 0, sweepFlag, path.x, path.y)
 }
 
 func (path *pathData) Arc(x, y, radius, startAngle, endAngle float64, clockwise bool) {
@@ -132,6 +269,7 @@ func (path *pathData) Arc(x, y, radius, startAngle, endAngle float64, clockwise
 		} else {
 			path.script.WriteString(");")
 		}
+		path.svgEllipseArc(x, y, radius, radius, 0, startAngle, endAngle, clockwise)
 	}
 }
 
@@ -149,6 +287,11 @@ func (path *pathData) BezierCurveTo(cp0x, cp0y, cp1x, cp1y, x, y float64) {
 	path.script.WriteRune(',')
 	path.script.WriteString(strconv.FormatFloat(y, 'g', -1, 64))
 	path.script.WriteString(");")
+	if !path.hasPoint {
+		path.svgMoveTo(cp0x, cp0y)
+	}
+	path.svgCommand('C', cp0x, cp0y, cp1x, cp1y, x, y)
+	path.x, path.y = x, y
 }
 
 func (path *pathData) QuadraticCurveTo(cpx, cpy, x, y float64) {
@@ -161,6 +304,11 @@ func (path *pathData) QuadraticCurveTo(cpx, cpy, x, y float64) {
 	path.script.WriteRune(',')
 	path.script.WriteString(strconv.FormatFloat(y, 'g', -1, 64))
 	path.script.WriteString(");")
+	if !path.hasPoint {
+		path.svgMoveTo(cpx, cpy)
+	}
+	path.svgCommand('Q', cpx, cpy, x, y)
+	path.x, path.y = x, y
 }
 
 func (path *pathData) Ellipse(x, y, radiusX, radiusY, rotation, startAngle, endAngle float64, clockwise bool) {
@@ -184,11 +332,16 @@ func (path *pathData) Ellipse(x, y, radiusX, radiusY, rotation, startAngle, endA
 		} else {
 			path.script.WriteString(");")
 		}
+		path.svgEllipseArc(x, y, radiusX, radiusY, rotation, startAngle, endAngle, clockwise)
 	}
 }
 
 func (path *pathData) Close() {
-	path.script.WriteString("\nctx.close();")
+	path.script.WriteString("\nctx.closePath();")
+	if path.hasPoint {
+		path.svgCommand('Z')
+		path.x, path.y = path.startX, path.startY
+	}
 }
 
 func (path *pathData) scriptText() string {
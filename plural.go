@@ -0,0 +1,223 @@
+package rui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Constants of CLDR plural categories (see PluralCategory)
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralRule returns the CLDR plural category of the number. The arguments are the CLDR operands:
+// n - the absolute value of the number, i - the integer digits of n, v - the number of visible fraction digits
+type PluralRule func(n float64, i int64, v int) string
+
+var pluralRules = struct {
+	rules map[string]PluralRule
+	mutex sync.RWMutex
+}{
+	rules: map[string]PluralRule{},
+}
+
+func init() {
+	oneOther := func(n float64, i int64, v int) string {
+		if i == 1 && v == 0 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+
+	other := func(n float64, i int64, v int) string {
+		return PluralOther
+	}
+
+	zeroOne := func(n float64, i int64, v int) string {
+		if i == 0 || i == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+
+	eastSlavic := func(n float64, i int64, v int) string {
+		if v != 0 {
+			return PluralOther
+		}
+		switch i10, i100 := i%10, i%100; {
+		case i10 == 1 && i100 != 11:
+			return PluralOne
+
+		case i10 >= 2 && i10 <= 4 && (i100 < 12 || i100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+	}
+
+	polish := func(n float64, i int64, v int) string {
+		if v != 0 {
+			return PluralOther
+		}
+		if i == 1 {
+			return PluralOne
+		}
+		if i10, i100 := i%10, i%100; i10 >= 2 && i10 <= 4 && (i100 < 12 || i100 > 14) {
+			return PluralFew
+		}
+		return PluralMany
+	}
+
+	czech := func(n float64, i int64, v int) string {
+		switch {
+		case v != 0:
+			return PluralMany
+
+		case i == 1:
+			return PluralOne
+
+		case i >= 2 && i <= 4:
+			return PluralFew
+		}
+		return PluralOther
+	}
+
+	arabic := func(n float64, i int64, v int) string {
+		if v != 0 {
+			return PluralOther
+		}
+		switch i100 := i % 100; {
+		case i == 0:
+			return PluralZero
+
+		case i == 1:
+			return PluralOne
+
+		case i == 2:
+			return PluralTwo
+
+		case i100 >= 3 && i100 <= 10:
+			return PluralFew
+
+		case i100 >= 11 && i100 <= 99:
+			return PluralMany
+		}
+		return PluralOther
+	}
+
+	hebrew := func(n float64, i int64, v int) string {
+		if v == 0 {
+			switch i {
+			case 1:
+				return PluralOne
+
+			case 2:
+				return PluralTwo
+			}
+		}
+		return PluralOther
+	}
+
+	for _, lang := range []string{"en", "de", "nl", "sv", "da", "nb", "nn", "no", "fi", "et", "it", "el", "hu",
+		"tr", "bg", "ca", "gl", "eu", "af", "sq", "az", "ka", "kk", "ky", "mn", "ur", "sw", "es"} {
+		pluralRules.rules[lang] = oneOther
+	}
+
+	for _, lang := range []string{"ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km"} {
+		pluralRules.rules[lang] = other
+	}
+
+	for _, lang := range []string{"fr", "pt", "hy", "kab"} {
+		pluralRules.rules[lang] = zeroOne
+	}
+
+	for _, lang := range []string{"ru", "uk", "be"} {
+		pluralRules.rules[lang] = eastSlavic
+	}
+
+	pluralRules.rules["pl"] = polish
+	pluralRules.rules["cs"] = czech
+	pluralRules.rules["sk"] = czech
+	pluralRules.rules["ar"] = arabic
+	pluralRules.rules["he"] = hebrew
+}
+
+// RegisterPluralRule sets the plural rule of the language. The language is the ISO 639-1 code, for example, "en"
+func RegisterPluralRule(lang string, rule PluralRule) {
+	if rule != nil {
+		pluralRules.mutex.Lock()
+		pluralRules.rules[strings.ToLower(lang)] = rule
+		pluralRules.mutex.Unlock()
+	}
+}
+
+// pluralRule returns the rule of the language. The region subtag is ignored: "ru-RU" -> "ru"
+func pluralRule(lang string) PluralRule {
+	lang = strings.ToLower(lang)
+
+	pluralRules.mutex.RLock()
+	defer pluralRules.mutex.RUnlock()
+
+	if rule, ok := pluralRules.rules[lang]; ok {
+		return rule
+	}
+	if n := strings.IndexAny(lang, "-_"); n > 0 {
+		if rule, ok := pluralRules.rules[lang[:n]]; ok {
+			return rule
+		}
+	}
+	return pluralRules.rules["en"]
+}
+
+// pluralOperands returns the CLDR operands n, i, v of the number. The number can be of any int or float type, or a string
+func pluralOperands(count interface{}) (float64, int64, int, bool) {
+	var text string
+	switch value := count.(type) {
+	case string:
+		text = strings.Trim(value, " \t")
+
+	case float32:
+		text = strconv.FormatFloat(float64(value), 'f', -1, 32)
+
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+
+	default:
+		if n, ok := isInt(count); ok {
+			if n < 0 {
+				n = -n
+			}
+			return float64(n), int64(n), 0, true
+		}
+		text = fmt.Sprint(count)
+	}
+
+	text = strings.TrimPrefix(text, "-")
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, 0, 0, false
+	}
+
+	v := 0
+	if dot := strings.IndexRune(text, '.'); dot >= 0 {
+		v = len(text) - dot - 1
+	}
+	return n, int64(n), v, true
+}
+
+// PluralCategory returns the CLDR plural category ("zero", "one", "two", "few", "many", or "other")
+// of the number for the language. The number can be of any int or float type, or a string
+func PluralCategory(lang string, count interface{}) string {
+	n, i, v, ok := pluralOperands(count)
+	if !ok {
+		return PluralOther
+	}
+	return pluralRule(lang)(n, i, v)
+}
@@ -0,0 +1,131 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang     string
+		count    interface{}
+		category string
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en", 2, PluralOther},
+		{"en", "1.0", PluralOther},
+		{"en-US", 1, PluralOne},
+		{"ru", 1, PluralOne},
+		{"ru", 21, PluralOne},
+		{"ru", 11, PluralMany},
+		{"ru", 2, PluralFew},
+		{"ru", 24, PluralFew},
+		{"ru", 14, PluralMany},
+		{"ru", 5, PluralMany},
+		{"ru", 0, PluralMany},
+		{"ru", 1.5, PluralOther},
+		{"ru_RU", int64(101), PluralOne},
+		{"pl", 1, PluralOne},
+		{"pl", 22, PluralFew},
+		{"pl", 21, PluralMany},
+		{"cs", 3, PluralFew},
+		{"cs", 5, PluralOther},
+		{"fr", 0, PluralOne},
+		{"fr", 1.5, PluralOne},
+		{"fr", 2, PluralOther},
+		{"ja", 1, PluralOther},
+		{"ar", 0, PluralZero},
+		{"ar", 2, PluralTwo},
+		{"ar", 105, PluralFew},
+		{"ar", 111, PluralMany},
+		{"ar", 100, PluralOther},
+		{"en", "abc", PluralOther},
+	}
+
+	for _, test := range tests {
+		if category := PluralCategory(test.lang, test.count); category != test.category {
+			t.Errorf(`PluralCategory("%s", %v) = "%s", expected "%s"`, test.lang, test.count, category, test.category)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	defer func() {
+		delete(stringResources, "ru")
+		delete(pluralResources, "ru")
+	}()
+
+	loadStringResources(ParseDataText(`strings:ru {
+		hello = "Привет, @{name}!",
+		files = _{
+			one = "@{count} файл",
+			few = "@{count} файла",
+			many = "@{count} файлов",
+			other = "@{count} файла",
+		},
+	}`))
+
+	tests := []struct {
+		tag      string
+		args     Params
+		expected string
+	}{
+		{"hello", Params{"name": "Анна"}, "Привет, Анна!"},
+		{"hello", nil, "Привет, @{name}!"},
+		{"files", Params{"count": 1}, "1 файл"},
+		{"files", Params{"count": 3}, "3 файла"},
+		{"files", Params{"count": 25}, "25 файлов"},
+		{"files", Params{"count": 1.5}, "1.5 файла"},
+		{"unknown @{x}", Params{"x": 10}, "unknown 10"},
+	}
+
+	for _, test := range tests {
+		if text := Translate(test.tag, "ru", test.args); text != test.expected {
+			t.Errorf(`Translate("%s", "ru", %v) = "%s", expected "%s"`, test.tag, test.args, text, test.expected)
+		}
+	}
+
+	if text, ok := GetString("files", "ru"); !ok || text != "@{count} файла" {
+		t.Errorf(`GetString("files", "ru") = "%s"`, text)
+	}
+
+	if args, ok := valueToTextArgs("count = 5, name = Ann"); !ok || args["count"] != "5" || args["name"] != "Ann" {
+		t.Errorf(`valueToTextArgs error: %v`, args)
+	}
+}
+
+func TestAddStringsFromText(t *testing.T) {
+	defer func() {
+		delete(stringResources, "ia")
+		delete(pluralResources, "ia")
+	}()
+
+	if err := AddStringsFromText(`strings:ia { hello = "Salute" }`); err != nil {
+		t.Error(err)
+	}
+	if text, _ := GetString("hello", "ia"); text != "Salute" {
+		t.Errorf(`GetString = "%s"`, text)
+	}
+
+	err := AddStringsFromText(`strings:ia { hello = "Bon die" `)
+	if _, ok := err.(*DataParseError); !ok {
+		t.Errorf("AddStringsFromText must return *DataParseError: %v", err)
+	}
+	if text, _ := GetString("hello", "ia"); text != "Salute" {
+		t.Errorf(`GetString = "%s" after the syntax error`, text)
+	}
+
+	for _, text := range []string{
+		`theme { hello = "Salute" }`,
+		`strings:ia { files = _{ one = "@{count} file", several = "@{count} files" } }`,
+	} {
+		if err := AddStringsFromText(text); err == nil {
+			t.Errorf("AddStringsFromText(`%s`) must return an error", text)
+		}
+	}
+}
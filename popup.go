@@ -1,6 +1,9 @@
 package rui
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	// Title is the Popup string property
@@ -30,6 +33,8 @@ type Popup interface {
 	Dismiss()
 	html(buffer *strings.Builder)
 	viewByHTMLID(id string) View
+	// rootView returns the root view of the popup which contains the title, the content, and the buttons
+	rootView() View
 }
 
 type popupData struct {
@@ -118,6 +123,9 @@ func (popup *popupData) init(view View, params Params) {
 		CellVerticalAlign:   StretchAlign,
 		CellHorizontalAlign: StretchAlign,
 		ClickEvent:          func(View) {},
+		Role:                "dialog",
+		AriaModal:           true,
+		TabIndex:            -1,
 	})
 
 	for tag, value := range params {
@@ -165,6 +173,10 @@ func (popup *popupData) init(view View, params Params) {
 		})
 		if title != nil {
 			titleView.Append(title)
+			if popupView.Get(AriaLabel) == nil && popupView.Get(AriaLabelledBy) == nil {
+				// the html id is used because the title view may have no id
+				popupView.Set(AriaLabelledBy, title.htmlID())
+			}
 		}
 		if closeButton {
 			titleView.Append(NewGridLayout(session, Params{
@@ -175,6 +187,8 @@ func (popup *popupData) init(view View, params Params) {
 				CellVerticalAlign:   CenterAlign,
 				TextSize:            Px(20),
 				Content:             "✕",
+				Role:                "button",
+				AriaLabel:           "Close",
 				ClickEvent: func(View) {
 					popup.Dismiss()
 				},
@@ -272,6 +286,10 @@ func (popup *popupData) html(buffer *strings.Builder) {
 	viewHTML(popup.layerView, buffer)
 }
 
+func (popup *popupData) rootView() View {
+	return popup.layerView
+}
+
 func (popup *popupData) viewByHTMLID(id string) View {
 	return viewByHTMLID(id, popup.layerView)
 }
@@ -317,9 +335,10 @@ func (manager *popupManager) showPopup(popup Popup) {
 		manager.popups = append(manager.popups, popup)
 	}
 
-	session.runScript(`if (document.activeElement != document.body) document.activeElement.blur();`)
 	manager.updatePopupLayerInnerHTML(session)
 	updateCSSProperty("ruiPopupLayer", "visibility", "visible", session)
+	// the focus is moved into the popup, the previously focused element is restored after the popup is dismissed
+	session.runScript(`popupShown();`)
 }
 
 func (manager *popupManager) dismissPopup(popup Popup) {
@@ -334,6 +353,10 @@ func (manager *popupManager) dismissPopup(popup Popup) {
 	}
 
 	session := popup.Session()
+	if focused := session.FocusedView(); focused != nil && popup.viewByHTMLID(focused.htmlID()) != nil {
+		session.viewFocusChanged(focused, false)
+	}
+
 	if manager.popups[count-1] == popup {
 		if count == 1 {
 			manager.popups = []Popup{}
@@ -343,6 +366,7 @@ func (manager *popupManager) dismissPopup(popup Popup) {
 			manager.popups = manager.popups[:count-1]
 			manager.updatePopupLayerInnerHTML(session)
 		}
+		session.runScript(fmt.Sprintf(`popupDismissed(%d);`, count-1))
 		return
 	}
 
@@ -354,6 +378,7 @@ func (manager *popupManager) dismissPopup(popup Popup) {
 				manager.popups = append(manager.popups[:n], manager.popups[n+1:]...)
 			}
 			manager.updatePopupLayerInnerHTML(session)
+			session.runScript(fmt.Sprintf(`popupDismissed(%d);`, n))
 			return
 		}
 	}
@@ -0,0 +1,762 @@
+package rui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Constants of the property value types (see PropertyInfo)
+const (
+	// TextPropertyType - the property value is any text
+	TextPropertyType = iota
+	// BoolPropertyType - the property value is true/false, yes/no, on/off, 1/0
+	BoolPropertyType
+	// IntPropertyType - the property value is an integer
+	IntPropertyType
+	// FloatPropertyType - the property value is a floating point number in the range [Min, Max]
+	FloatPropertyType
+	// SizePropertyType - the property value is SizeUnit
+	SizePropertyType
+	// AnglePropertyType - the property value is AngleUnit
+	AnglePropertyType
+	// ColorPropertyType - the property value is Color
+	ColorPropertyType
+	// EnumPropertyType - the property value is one of Values (or its index)
+	EnumPropertyType
+	// BoundsPropertyType - the property value is Bounds: one or four sizes, or an object
+	BoundsPropertyType
+	// ObjectPropertyType - the property value is an object or a text in the property specific format
+	ObjectPropertyType
+	// ViewPropertyType - the property value is a view, an array of views, or a text
+	ViewPropertyType
+	// EventPropertyType - the property value is an event listener, it can be set only by code
+	EventPropertyType
+	// AnyPropertyType - the property value can be of any type
+	AnyPropertyType
+)
+
+// PropertyInfo describes a property of views
+type PropertyInfo struct {
+	// Name - the property tag
+	Name string
+	// Aliases - other tags of the property (they are replaced by Name when the property is set)
+	Aliases []string
+	// Type - the value type: TextPropertyType, BoolPropertyType, ..., AnyPropertyType
+	Type int
+	// Values - the valid values of EnumPropertyType property
+	Values []string
+	// Min, Max - the valid range of FloatPropertyType property
+	Min, Max float64
+	// Views - the tags of views which accept the property. If it is empty then all views accept the property
+	Views []string
+}
+
+// ViewValidationError describes an invalid property of a view description (see ValidateViewObject)
+type ViewValidationError struct {
+	// Path - the path of the view in the description, for example, "ListLayout.content[1]"
+	Path string
+	// View - the tag of the view
+	View string
+	// Property - the property tag. Empty if the view itself is invalid
+	Property string
+	// Message - the error description
+	Message string
+}
+
+func (err *ViewValidationError) Error() string {
+	if err.Property == "" {
+		return fmt.Sprintf("%s: %s", err.Path, err.Message)
+	}
+	return fmt.Sprintf(`%s: "%s" property: %s`, err.Path, err.Property, err.Message)
+}
+
+type propertyRegistry struct {
+	properties map[string][]*PropertyInfo
+	once       sync.Once
+	mutex      sync.RWMutex
+}
+
+var propertyInfoRegistry propertyRegistry
+
+var containerViews = []string{"ListLayout", "GridLayout", "ColumnLayout", "StackLayout", "TabsLayout", "AbsoluteLayout",
+	"Resizable", "DetailsView", "Button", "Checkbox"}
+
+var chartViews = []string{"LineChart", "BarChart", "PieChart", "ScatterChart"}
+
+var mediaViews = []string{"AudioPlayer", "VideoPlayer"}
+
+// commonPropertyTypes defines the types of the properties accepted by all views which are not
+// listed in the tables of propertySet.go
+var commonPropertyTypes = map[string]int{
+	ID:                      TextPropertyType,
+	Style:                   TextPropertyType,
+	StyleDisabled:           TextPropertyType,
+	Role:                    TextPropertyType,
+	AriaLabel:               TextPropertyType,
+	AriaLabelledBy:          TextPropertyType,
+	AriaDescribedBy:         TextPropertyType,
+	UserData:                AnyPropertyType,
+	TextArgs:                ObjectPropertyType,
+	FontName:                TextPropertyType,
+	Row:                     TextPropertyType,
+	Column:                  TextPropertyType,
+	Title:                   TextPropertyType,
+	Icon:                    TextPropertyType,
+	VerticalAlign:           EnumPropertyType,
+	HorizontalAlign:         EnumPropertyType,
+	Margin:                  BoundsPropertyType,
+	Padding:                 BoundsPropertyType,
+	Background:              ObjectPropertyType,
+	Border:                  ObjectPropertyType,
+	BorderLeft:              ObjectPropertyType,
+	BorderRight:             ObjectPropertyType,
+	BorderTop:               ObjectPropertyType,
+	BorderBottom:            ObjectPropertyType,
+	BorderStyle:             ObjectPropertyType,
+	BorderWidth:             ObjectPropertyType,
+	BorderColor:             ObjectPropertyType,
+	Outline:                 ObjectPropertyType,
+	Radius:                  ObjectPropertyType,
+	Shadow:                  ObjectPropertyType,
+	TextShadow:              ObjectPropertyType,
+	Filter:                  ObjectPropertyType,
+	BackdropFilter:          ObjectPropertyType,
+	Clip:                    ObjectPropertyType,
+	ShapeOutside:            ObjectPropertyType,
+	Transition:              ObjectPropertyType,
+	AnimationTag:            ObjectPropertyType,
+	FocusEvent:              EventPropertyType,
+	LostFocusEvent:          EventPropertyType,
+	KeyDownEvent:            EventPropertyType,
+	KeyUpEvent:              EventPropertyType,
+	ClickEvent:              EventPropertyType,
+	DoubleClickEvent:        EventPropertyType,
+	MouseDown:               EventPropertyType,
+	MouseUp:                 EventPropertyType,
+	MouseMove:               EventPropertyType,
+	MouseOut:                EventPropertyType,
+	MouseOver:               EventPropertyType,
+	ContextMenuEvent:        EventPropertyType,
+	PointerDown:             EventPropertyType,
+	PointerUp:               EventPropertyType,
+	PointerMove:             EventPropertyType,
+	PointerCancel:           EventPropertyType,
+	PointerOut:              EventPropertyType,
+	PointerOver:             EventPropertyType,
+	TouchStart:              EventPropertyType,
+	TouchEnd:                EventPropertyType,
+	TouchMove:               EventPropertyType,
+	TouchCancel:             EventPropertyType,
+	ResizeEvent:             EventPropertyType,
+	ScrollEvent:             EventPropertyType,
+	TransitionRunEvent:      EventPropertyType,
+	TransitionStartEvent:    EventPropertyType,
+	TransitionEndEvent:      EventPropertyType,
+	TransitionCancelEvent:   EventPropertyType,
+	AnimationStartEvent:     EventPropertyType,
+	AnimationEndEvent:       EventPropertyType,
+	AnimationCancelEvent:    EventPropertyType,
+	AnimationIterationEvent: EventPropertyType,
+}
+
+// viewPropertySpec describes a view specific property. The type of properties listed
+// in the tables of propertySet.go is taken from these tables
+type viewPropertySpec struct {
+	name    string
+	kind    int
+	aliases []string
+}
+
+// viewSpecificProperties defines the properties which are accepted only by the listed views
+var viewSpecificProperties = []struct {
+	views      []string
+	properties []viewPropertySpec
+}{
+	{containerViews, []viewPropertySpec{{name: Content, kind: ViewPropertyType}}},
+	{[]string{"ListLayout", "Button"}, []viewPropertySpec{
+		{name: Orientation},
+		{name: ListWrap, aliases: []string{"wrap"}},
+	}},
+	{[]string{"GridLayout"}, []viewPropertySpec{
+		{name: CellWidth},
+		{name: CellHeight},
+		{name: GridRowGap, aliases: []string{"row-gap"}},
+		{name: GridColumnGap, aliases: []string{ColumnGap}},
+		{name: CellVerticalAlign, aliases: []string{VerticalAlign}},
+		{name: CellHorizontalAlign, aliases: []string{HorizontalAlign}},
+	}},
+	{[]string{"GridLayout"}, []viewPropertySpec{
+		{name: GridAutoFlow},
+		{name: Gap, kind: SizePropertyType},
+	}},
+	{[]string{"ColumnLayout"}, []viewPropertySpec{
+		{name: ColumnCount},
+		{name: ColumnWidth},
+		{name: ColumnGap, aliases: []string{Gap}},
+		{name: ColumnSeparator, kind: ObjectPropertyType},
+		{name: ColumnSeparatorStyle},
+		{name: ColumnSeparatorWidth, kind: SizePropertyType},
+		{name: ColumnSeparatorColor, kind: ColorPropertyType},
+	}},
+	{[]string{"StackLayout", "TabsLayout", "DropDownList", "ListView", "TableView"}, []viewPropertySpec{
+		{name: Current, kind: TextPropertyType},
+	}},
+	{[]string{"TabsLayout"}, []viewPropertySpec{
+		{name: Tabs},
+		{name: TabBarStyle, kind: TextPropertyType},
+		{name: TabStyle, kind: TextPropertyType},
+		{name: CurrentTabStyle, kind: TextPropertyType},
+		{name: TabCloseButton},
+		{name: CurrentTabChangedEvent, kind: EventPropertyType},
+		{name: TabCloseEvent, kind: EventPropertyType},
+	}},
+	{[]string{"Resizable"}, []viewPropertySpec{
+		{name: Side},
+		{name: ResizeBorderWidth, kind: SizePropertyType},
+	}},
+	{[]string{"DetailsView"}, []viewPropertySpec{
+		{name: Summary, kind: ViewPropertyType},
+		{name: Expanded},
+	}},
+	{[]string{"TextView", "EditView"}, []viewPropertySpec{
+		{name: Text, kind: TextPropertyType},
+	}},
+	{[]string{"TextView"}, []viewPropertySpec{
+		{name: NotTranslate},
+	}},
+	{[]string{"TextView", "TableView"}, []viewPropertySpec{
+		{name: GroupDigits},
+	}},
+	{[]string{"Checkbox", "ListView"}, []viewPropertySpec{
+		{name: Checked, kind: TextPropertyType},
+		{name: CheckboxHorizontalAlign},
+		{name: CheckboxVerticalAlign},
+	}},
+	{[]string{"Checkbox"}, []viewPropertySpec{
+		{name: CheckboxChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"DropDownList", "ListView"}, []viewPropertySpec{
+		{name: Items, kind: ObjectPropertyType},
+	}},
+	{[]string{"DropDownList"}, []viewPropertySpec{
+		{name: DisabledItems, kind: TextPropertyType},
+		{name: DropDownEvent, kind: EventPropertyType},
+	}},
+	{[]string{"ProgressBar"}, []viewPropertySpec{
+		{name: ProgressBarMax, kind: FloatPropertyType, aliases: []string{Max, "progress-bar-max", "progressbar-max"}},
+		{name: ProgressBarValue, kind: FloatPropertyType, aliases: []string{Value, "progress-bar-value", "progressbar-value"}},
+	}},
+	{[]string{"NumberPicker"}, []viewPropertySpec{
+		{name: NumberPickerType, aliases: []string{Type}},
+		{name: NumberPickerMin, kind: FloatPropertyType, aliases: []string{Min}},
+		{name: NumberPickerMax, kind: FloatPropertyType, aliases: []string{Max}},
+		{name: NumberPickerStep, kind: FloatPropertyType, aliases: []string{Step}},
+		{name: NumberPickerValue, kind: FloatPropertyType, aliases: []string{Value}},
+		{name: NumberChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"ColorPicker"}, []viewPropertySpec{
+		{name: ColorPickerValue, kind: ColorPropertyType, aliases: []string{Value, ColorTag}},
+		{name: ColorChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"DatePicker"}, []viewPropertySpec{
+		{name: DatePickerMin, kind: TextPropertyType, aliases: []string{Min}},
+		{name: DatePickerMax, kind: TextPropertyType, aliases: []string{Max}},
+		{name: DatePickerStep, kind: IntPropertyType, aliases: []string{Step}},
+		{name: DatePickerValue, kind: TextPropertyType, aliases: []string{Value}},
+		{name: DateChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"TimePicker"}, []viewPropertySpec{
+		{name: TimePickerMin, kind: TextPropertyType, aliases: []string{Min}},
+		{name: TimePickerMax, kind: TextPropertyType, aliases: []string{Max}},
+		{name: TimePickerStep, kind: IntPropertyType, aliases: []string{Step}},
+		{name: TimePickerValue, kind: TextPropertyType, aliases: []string{Value}},
+		{name: TimeChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"FilePicker"}, []viewPropertySpec{
+		{name: Accept, kind: TextPropertyType},
+		{name: Multiple},
+		{name: FileSelectedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"EditView"}, []viewPropertySpec{
+		{name: Hint, kind: TextPropertyType},
+		{name: MaxLength, kind: IntPropertyType, aliases: []string{"maxlength", "maxlen"}},
+		{name: ReadOnly},
+		{name: Spellcheck},
+		{name: EditViewType, aliases: []string{Type, "edit-type"}},
+		{name: EditViewPattern, kind: TextPropertyType, aliases: []string{Pattern, "edit-pattern"}},
+		{name: EditWrap, aliases: []string{"wrap"}},
+		{name: EditTextChangedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"ListView"}, []viewPropertySpec{
+		{name: Orientation},
+		{name: ListWrap, aliases: []string{"wrap"}},
+		{name: ItemWidth},
+		{name: ItemHeight},
+		{name: ItemVerticalAlign, aliases: []string{VerticalAlign}},
+		{name: ItemHorizontalAlign, aliases: []string{HorizontalAlign}},
+		{name: ItemCheckbox},
+		{name: ListItemStyle, kind: TextPropertyType},
+		{name: CurrentStyle, kind: TextPropertyType},
+		{name: CurrentInactiveStyle, kind: TextPropertyType},
+		{name: ListItemClickedEvent, kind: EventPropertyType},
+		{name: ListItemSelectedEvent, kind: EventPropertyType},
+		{name: ListItemCheckedEvent, kind: EventPropertyType},
+	}},
+	{[]string{"CanvasView"}, []viewPropertySpec{
+		{name: DrawFunction, kind: EventPropertyType, aliases: []string{"draw-func"}},
+	}},
+	{[]string{"ImageView"}, []viewPropertySpec{
+		{name: Source, kind: TextPropertyType, aliases: []string{"source"}},
+		{name: AltText, kind: TextPropertyType, aliases: []string{altTag}},
+		{name: Fit},
+		{name: ImageVerticalAlign, aliases: []string{VerticalAlign}},
+		{name: ImageHorizontalAlign, aliases: []string{HorizontalAlign}},
+	}},
+	{[]string{"TableView"}, []viewPropertySpec{
+		{name: Content, kind: ObjectPropertyType},
+		{name: HeadHeight, kind: IntPropertyType},
+		{name: HeadStyle, kind: ObjectPropertyType},
+		{name: FootHeight, kind: IntPropertyType},
+		{name: FootStyle, kind: ObjectPropertyType},
+		{name: RowStyle, kind: ObjectPropertyType},
+		{name: ColumnStyle, kind: ObjectPropertyType},
+		{name: CellStyle, kind: ObjectPropertyType},
+		{name: CellPadding, kind: BoundsPropertyType},
+		{name: CellPaddingLeft, kind: SizePropertyType, aliases: []string{"left-cell-padding"}},
+		{name: CellPaddingRight, kind: SizePropertyType, aliases: []string{"right-cell-padding"}},
+		{name: CellPaddingTop, kind: SizePropertyType, aliases: []string{"top-cell-padding"}},
+		{name: CellPaddingBottom, kind: SizePropertyType, aliases: []string{"bottom-cell-padding"}},
+		{name: CellBorder, kind: ObjectPropertyType},
+		{name: CellBorderLeft, kind: ObjectPropertyType},
+		{name: CellBorderRight, kind: ObjectPropertyType},
+		{name: CellBorderTop, kind: ObjectPropertyType},
+		{name: CellBorderBottom, kind: ObjectPropertyType},
+		{name: CellBorderStyle, kind: ObjectPropertyType},
+		{name: CellBorderLeftStyle},
+		{name: CellBorderRightStyle},
+		{name: CellBorderTopStyle},
+		{name: CellBorderBottomStyle},
+		{name: CellBorderWidth, kind: ObjectPropertyType},
+		{name: CellBorderLeftWidth, kind: SizePropertyType},
+		{name: CellBorderRightWidth, kind: SizePropertyType},
+		{name: CellBorderTopWidth, kind: SizePropertyType},
+		{name: CellBorderBottomWidth, kind: SizePropertyType},
+		{name: CellBorderColor, kind: ObjectPropertyType},
+		{name: CellBorderLeftColor, kind: ColorPropertyType},
+		{name: CellBorderRightColor, kind: ColorPropertyType},
+		{name: CellBorderTopColor, kind: ColorPropertyType},
+		{name: CellBorderBottomColor, kind: ColorPropertyType},
+		{name: SelectionMode},
+		{name: AllowSelection, kind: EventPropertyType},
+		{name: TableVerticalAlign},
+		{name: Gap},
+		{name: TableCellClickedEvent, kind: EventPropertyType},
+		{name: TableCellSelectedEvent, kind: EventPropertyType},
+		{name: TableRowClickedEvent, kind: EventPropertyType},
+		{name: TableRowSelectedEvent, kind: EventPropertyType},
+	}},
+	{mediaViews, []viewPropertySpec{
+		{name: Source, kind: ObjectPropertyType},
+		{name: Controls},
+		{name: Loop},
+		{name: Muted},
+		{name: Preload},
+		{name: AbortEvent, kind: EventPropertyType},
+		{name: CanPlayEvent, kind: EventPropertyType},
+		{name: CanPlayThroughEvent, kind: EventPropertyType},
+		{name: CompleteEvent, kind: EventPropertyType},
+		{name: DurationChangedEvent, kind: EventPropertyType},
+		{name: EmptiedEvent, kind: EventPropertyType},
+		{name: EndedEvent, kind: EventPropertyType},
+		{name: LoadedDataEvent, kind: EventPropertyType},
+		{name: LoadedMetadataEvent, kind: EventPropertyType},
+		{name: LoadStartEvent, kind: EventPropertyType},
+		{name: PauseEvent, kind: EventPropertyType},
+		{name: PlayEvent, kind: EventPropertyType},
+		{name: PlayingEvent, kind: EventPropertyType},
+		{name: ProgressEvent, kind: EventPropertyType},
+		{name: RateChangedEvent, kind: EventPropertyType},
+		{name: SeekedEvent, kind: EventPropertyType},
+		{name: SeekingEvent, kind: EventPropertyType},
+		{name: StalledEvent, kind: EventPropertyType},
+		{name: SuspendEvent, kind: EventPropertyType},
+		{name: TimeUpdateEvent, kind: EventPropertyType},
+		{name: VolumeChangedEvent, kind: EventPropertyType},
+		{name: WaitingEvent, kind: EventPropertyType},
+		{name: PlayerErrorEvent, kind: EventPropertyType},
+	}},
+	{[]string{"VideoPlayer"}, []viewPropertySpec{
+		{name: VideoWidth, kind: FloatPropertyType},
+		{name: VideoHeight, kind: FloatPropertyType},
+		{name: Poster, kind: TextPropertyType},
+	}},
+	{chartViews, []viewPropertySpec{
+		{name: Content, kind: ObjectPropertyType},
+		{name: Categories, kind: TextPropertyType, aliases: []string{"labels"}},
+		{name: SeriesTitles, kind: TextPropertyType, aliases: []string{"titles"}},
+		{name: ShowLegend, kind: BoolPropertyType},
+		{name: ShowAxes, kind: BoolPropertyType},
+		{name: ShowGrid, kind: BoolPropertyType},
+		{name: ShowTooltips, kind: BoolPropertyType},
+	}},
+}
+
+// tablePropertyInfo returns the description of the property listed in the tables of propertySet.go
+func tablePropertyInfo(tag string) (PropertyInfo, bool) {
+	info := PropertyInfo{Name: tag}
+	if _, ok := sizeProperties[tag]; ok {
+		info.Type = SizePropertyType
+	} else if enum, ok := enumProperties[tag]; ok {
+		info.Type = EnumPropertyType
+		info.Values = enum.values
+	} else if limits, ok := floatProperties[tag]; ok {
+		info.Type = FloatPropertyType
+		info.Min = limits.min
+		info.Max = limits.max
+	} else if isPropertyInList(tag, colorProperties) {
+		info.Type = ColorPropertyType
+	} else if isPropertyInList(tag, angleProperties) {
+		info.Type = AnglePropertyType
+	} else if isPropertyInList(tag, boolProperties) {
+		info.Type = BoolPropertyType
+	} else if isPropertyInList(tag, intProperties) {
+		info.Type = IntPropertyType
+	} else {
+		return info, false
+	}
+	return info, true
+}
+
+func (registry *propertyRegistry) init() {
+	registry.once.Do(func() {
+		registry.mutex.Lock()
+		defer registry.mutex.Unlock()
+
+		registry.properties = map[string][]*PropertyInfo{}
+		specific := map[string]bool{}
+
+		for tag, kind := range commonPropertyTypes {
+			if info, ok := tablePropertyInfo(tag); ok && info.Type == kind {
+				registry.add(info)
+			} else {
+				registry.add(PropertyInfo{Name: tag, Type: kind})
+			}
+		}
+
+		for _, group := range viewSpecificProperties {
+			for _, spec := range group.properties {
+				specific[spec.name] = true
+				info, ok := tablePropertyInfo(spec.name)
+				if !ok {
+					info.Type = spec.kind
+				}
+				info.Aliases = spec.aliases
+				info.Views = group.views
+				registry.add(info)
+			}
+		}
+
+		tags := []string{}
+		for tag := range sizeProperties {
+			tags = append(tags, tag)
+		}
+		for tag := range enumProperties {
+			tags = append(tags, tag)
+		}
+		for tag := range floatProperties {
+			tags = append(tags, tag)
+		}
+		tags = append(tags, colorProperties...)
+		tags = append(tags, angleProperties...)
+		tags = append(tags, boolProperties...)
+		tags = append(tags, intProperties...)
+
+		for _, tag := range tags {
+			if !specific[tag] && registry.properties[tag] == nil {
+				info, _ := tablePropertyInfo(tag)
+				registry.add(info)
+			}
+		}
+	})
+}
+
+// add adds the description. The description with the same name and the same view list is replaced
+func (registry *propertyRegistry) add(info PropertyInfo) {
+	list := registry.properties[info.Name]
+	for i, old := range list {
+		if strings.Join(old.Views, ",") == strings.Join(info.Views, ",") {
+			list[i] = &info
+			return
+		}
+	}
+	registry.properties[info.Name] = append(list, &info)
+}
+
+// RegisterPropertyInfo adds the property description to the registry (or replaces the description
+// of the property with the same name for the same views). It is used to describe properties of custom views
+func RegisterPropertyInfo(info PropertyInfo) {
+	propertyInfoRegistry.init()
+	info.Name = strings.ToLower(info.Name)
+
+	propertyInfoRegistry.mutex.Lock()
+	defer propertyInfoRegistry.mutex.Unlock()
+	propertyInfoRegistry.add(info)
+}
+
+func (info *PropertyInfo) acceptedBy(viewTag string) bool {
+	return len(info.Views) == 0 || viewTag == "" || isPropertyInList(viewTag, info.Views)
+}
+
+// GetPropertyInfo returns the description of the property of the view with the given tag.
+// The property tag can be an alias. If viewTag is empty then the first description with the given name or alias is returned
+func GetPropertyInfo(viewTag, tag string) (PropertyInfo, bool) {
+	propertyInfoRegistry.init()
+	tag = strings.ToLower(tag)
+
+	propertyInfoRegistry.mutex.RLock()
+	defer propertyInfoRegistry.mutex.RUnlock()
+
+	// the view specific descriptions and aliases have priority over the common ones
+	for _, info := range propertyInfoRegistry.properties[tag] {
+		if len(info.Views) > 0 && info.acceptedBy(viewTag) {
+			return *info, true
+		}
+	}
+
+	if viewTag != "" {
+		for _, list := range propertyInfoRegistry.properties {
+			for _, info := range list {
+				if len(info.Views) > 0 && info.acceptedBy(viewTag) && isPropertyInList(tag, info.Aliases) {
+					return *info, true
+				}
+			}
+		}
+	}
+
+	for _, info := range propertyInfoRegistry.properties[tag] {
+		if len(info.Views) == 0 {
+			return *info, true
+		}
+	}
+
+	for _, list := range propertyInfoRegistry.properties {
+		for _, info := range list {
+			if info.acceptedBy(viewTag) && isPropertyInList(tag, info.Aliases) {
+				return *info, true
+			}
+		}
+	}
+	return PropertyInfo{}, false
+}
+
+// AllPropertyInfo returns the descriptions of all registered properties sorted by name
+func AllPropertyInfo() []PropertyInfo {
+	propertyInfoRegistry.init()
+
+	propertyInfoRegistry.mutex.RLock()
+	defer propertyInfoRegistry.mutex.RUnlock()
+
+	result := []PropertyInfo{}
+	for _, list := range propertyInfoRegistry.properties {
+		for _, info := range list {
+			result = append(result, *info)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return strings.Join(result[i].Views, ",") < strings.Join(result[j].Views, ",")
+	})
+	return result
+}
+
+// ViewPropertyInfo returns the descriptions of all properties accepted by the view with the given tag
+func ViewPropertyInfo(viewTag string) []PropertyInfo {
+	result := []PropertyInfo{}
+	for _, info := range AllPropertyInfo() {
+		if info.acceptedBy(viewTag) {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// ValidateViewObject checks the view description and returns the list of all unknown and ill-typed properties.
+// Nested views (the "content" property, etc.) are checked too. The properties of custom views
+// (see RegisterViewCreator) are checked only if they are described by RegisterPropertyInfo
+func ValidateViewObject(object DataObject) []error {
+	errors := []error{}
+	validateViewObject(object, object.Tag(), &errors)
+	return errors
+}
+
+// ValidateViewText parses the view description and checks it (see ValidateViewObject)
+func ValidateViewText(text string) []error {
+	object, err := ParseDataTextWithError(text)
+	if err != nil {
+		return []error{err}
+	}
+	return ValidateViewObject(object)
+}
+
+func validateViewObject(object DataObject, path string, errors *[]error) {
+	viewTag := object.Tag()
+	if isViewTemplate(object) || viewTag == repeatTag {
+		return
+	}
+
+	if _, ok := viewCreators[viewTag]; !ok {
+		*errors = append(*errors, &ViewValidationError{Path: path, View: viewTag, Message: fmt.Sprintf(`unknown view type "%s"`, viewTag)})
+		return
+	}
+	builtin := isBuiltinViewTag(viewTag)
+
+	addError := func(tag, format string, args ...interface{}) {
+		*errors = append(*errors, &ViewValidationError{Path: path, View: viewTag, Property: tag, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i := 0; i < object.PropertyCount(); i++ {
+		node := object.Property(i)
+		tag := strings.ToLower(node.Tag())
+
+		info, ok := GetPropertyInfo(viewTag, tag)
+		if !ok {
+			if builtin {
+				if views := propertyViews(tag); len(views) > 0 {
+					addError(node.Tag(), "the property is not supported by %s (it is supported by %s)", viewTag, strings.Join(views, ", "))
+				} else {
+					addError(node.Tag(), "unknown property")
+				}
+			}
+			continue
+		}
+
+		switch node.Type() {
+		case TextNode:
+			if message := validatePropertyText(info, node.Text()); message != "" {
+				addError(node.Tag(), message)
+			}
+
+		case ObjectNode:
+			switch info.Type {
+			case ViewPropertyType:
+				validateViewObject(node.Object(), path+"."+node.Tag(), errors)
+
+			case ObjectPropertyType, BoundsPropertyType, AnyPropertyType:
+
+			default:
+				addError(node.Tag(), "an object is not compatible with the property type")
+			}
+
+		case ArrayNode:
+			switch info.Type {
+			case ViewPropertyType:
+				for k, value := range node.ArrayElements() {
+					if value.IsObject() {
+						validateViewObject(value.Object(), path+"."+node.Tag()+"["+strconv.Itoa(k)+"]", errors)
+					}
+				}
+
+			case ObjectPropertyType, TextPropertyType, AnyPropertyType:
+
+			default:
+				addError(node.Tag(), "an array is not compatible with the property type")
+			}
+		}
+	}
+}
+
+// propertyViews returns the tags of all views which accept the property with the name or the alias
+func propertyViews(tag string) []string {
+	result := []string{}
+	for _, info := range AllPropertyInfo() {
+		if info.Name == tag || isPropertyInList(tag, info.Aliases) {
+			for _, view := range info.Views {
+				if !isPropertyInList(view, result) {
+					result = append(result, view)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// isBuiltinViewTag returns true if the properties of the view type are described by the registry
+func isBuiltinViewTag(viewTag string) bool {
+	switch viewTag {
+	case "View", "ListLayout", "GridLayout", "ColumnLayout", "StackLayout", "TabsLayout", "AbsoluteLayout",
+		"Resizable", "DetailsView", "TextView", "Button", "Checkbox", "DropDownList", "ProgressBar",
+		"NumberPicker", "ColorPicker", "DatePicker", "TimePicker", "FilePicker", "EditView", "ListView",
+		"CanvasView", "ImageView", "TableView", "AudioPlayer", "VideoPlayer",
+		"LineChart", "BarChart", "PieChart", "ScatterChart":
+		return true
+	}
+	return false
+}
+
+// validatePropertyText returns the error message if the text is not a valid value of the property
+func validatePropertyText(info PropertyInfo, text string) string {
+	if isConstantName(text) {
+		return ""
+	}
+
+	value := strings.Trim(text, " \t\n\r")
+	switch info.Type {
+	case BoolPropertyType:
+		switch strings.ToLower(value) {
+		case "true", "yes", "on", "1", "false", "no", "off", "0":
+			return ""
+		}
+		return fmt.Sprintf(`invalid bool value "%s"`, text)
+
+	case IntPropertyType:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Sprintf(`invalid integer value "%s"`, text)
+		}
+
+	case FloatPropertyType:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Sprintf(`invalid number "%s"`, text)
+		}
+		if info.Min < info.Max && (f < info.Min || f > info.Max) {
+			return fmt.Sprintf(`the value %s is out of range [%g, %g]`, text, info.Min, info.Max)
+		}
+
+	case SizePropertyType:
+		if _, err := stringToSizeUnit(value); err != nil {
+			return fmt.Sprintf(`invalid size "%s"`, text)
+		}
+
+	case AnglePropertyType:
+		if _, err := stringToAngleUnit(value); err != nil {
+			return fmt.Sprintf(`invalid angle "%s"`, text)
+		}
+
+	case ColorPropertyType:
+		if _, err := stringToColor(value); err != nil {
+			return fmt.Sprintf(`invalid color "%s"`, text)
+		}
+
+	case EnumPropertyType:
+		if info.Name == Orientation {
+			// see valueToEnum
+			switch value {
+			case "vertical", "horizontal":
+				return ""
+			}
+		}
+		if _, ok := enumStringToInt(value, info.Values, false); !ok {
+			return fmt.Sprintf(`invalid value "%s". Valid values: %s`, text, strings.Join(info.Values, ", "))
+		}
+
+	case EventPropertyType:
+		return "the event listener can be set only by code"
+	}
+	return ""
+}
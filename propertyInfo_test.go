@@ -0,0 +1,161 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestGetPropertyInfo(t *testing.T) {
+	tests := []struct {
+		view, tag, name string
+		kind            int
+	}{
+		{"TextView", "text-color", TextColor, ColorPropertyType},
+		{"TextView", "width", Width, SizePropertyType},
+		{"View", "margin", Margin, BoundsPropertyType},
+		{"ListLayout", "orientation", Orientation, EnumPropertyType},
+		{"ListLayout", "wrap", ListWrap, EnumPropertyType},
+		{"GridLayout", "vertical-align", CellVerticalAlign, EnumPropertyType},
+		{"ImageView", "source", Source, TextPropertyType},
+		{"ProgressBar", "max", ProgressBarMax, FloatPropertyType},
+		{"NumberPicker", "max", NumberPickerMax, FloatPropertyType},
+		{"EditView", "maxlength", MaxLength, IntPropertyType},
+		{"Button", "click-event", ClickEvent, EventPropertyType},
+	}
+
+	for _, test := range tests {
+		info, ok := GetPropertyInfo(test.view, test.tag)
+		if !ok {
+			t.Errorf(`GetPropertyInfo("%s", "%s") not found`, test.view, test.tag)
+		} else if info.Name != test.name || info.Type != test.kind {
+			t.Errorf(`GetPropertyInfo("%s", "%s") = "%s", %d. Expected "%s", %d`, test.view, test.tag, info.Name, info.Type, test.name, test.kind)
+		}
+	}
+
+	if _, ok := GetPropertyInfo("TextView", "orientation"); ok {
+		t.Error(`"orientation" must not be accepted by TextView`)
+	}
+}
+
+func TestPropertyInfoAliases(t *testing.T) {
+	createTestLog(t, false)
+	session := new(sessionData)
+
+	values := map[int]string{
+		SizePropertyType:   "10px",
+		BoundsPropertyType: "10px",
+		ColorPropertyType:  "#FF0000FF",
+		AnglePropertyType:  "10deg",
+		BoolPropertyType:   "true",
+		IntPropertyType:    "1",
+		FloatPropertyType:  "1",
+		TextPropertyType:   "text",
+	}
+	propertyValues := map[string]string{
+		DatePickerMin:   "2024-01-01",
+		DatePickerMax:   "2024-01-01",
+		DatePickerValue: "2024-01-01",
+		TimePickerMin:   "12:00",
+		TimePickerMax:   "12:00",
+		TimePickerValue: "12:00",
+	}
+
+	// the view with the property set by the alias must be equal to the view with the property set by the name
+	for _, info := range AllPropertyInfo() {
+		if len(info.Aliases) == 0 {
+			continue
+		}
+
+		value, ok := propertyValues[info.Name]
+		if !ok {
+			if info.Type == EnumPropertyType {
+				value, ok = info.Values[len(info.Values)-1], true
+			} else if value, ok = values[info.Type]; !ok {
+				continue
+			}
+		}
+
+		for _, viewTag := range info.Views {
+			expected := CreateViewFromObject(session, NewDataObject(viewTag))
+			if expected == nil {
+				t.Errorf(`Unable to create "%s"`, viewTag)
+				continue
+			}
+			empty := expected.String()
+			if !expected.Set(info.Name, value) || expected.String() == empty {
+				t.Errorf(`%s: Set("%s", "%s") failed`, viewTag, info.Name, value)
+				continue
+			}
+
+			for _, alias := range info.Aliases {
+				view := CreateViewFromObject(session, NewDataObject(viewTag))
+				if !view.Set(alias, value) {
+					t.Errorf(`%s: Set("%s", "%s") failed`, viewTag, alias, value)
+				} else if view.String() != expected.String() {
+					t.Errorf(`%s: "%s" is not an alias of "%s"`, viewTag, alias, info.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestValidateViewText(t *testing.T) {
+
+	errorLog := errorLogFunc
+	t.Cleanup(func() {
+		SetErrorLog(errorLog)
+	})
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	valid := `ListLayout {
+		id = list, width = 100%, orientation = vertical, padding = 8px, margin = _{ left = 4px },
+		text-color = @textColor, background-color = #FF0000FF,
+		content = [
+			TextView { text = "Hello", text-size = 12pt, italic = true, vertical-align = center },
+			EditView { id = edit, type = multiline, readonly = true, wrap = true, hint = "Name" },
+			GridLayout { gap = 4px, content = ImageView { src = "image.png", fit = contain } },
+			Button { content = "OK", opacity = 0.5 },
+		],
+	}`
+
+	if errors := ValidateViewText(valid); len(errors) != 0 {
+		for _, err := range errors {
+			t.Error(err)
+		}
+	}
+
+	invalid := `ListLayout {
+		colr = red,
+		width = wide,
+		orientation = diagonal,
+		opacity = 2,
+		click-event = handler,
+		content = [
+			TextView { text = "Hello", italic = maybe },
+			TextView { checked = true },
+			UnknownView { },
+		],
+	}`
+
+	expected := []string{
+		`ListLayout: "colr" property: unknown property`,
+		`ListLayout: "width" property: invalid size "wide"`,
+		`ListLayout: "orientation" property: invalid value "diagonal". Valid values: up-down, start-to-end, bottom-up, end-to-start`,
+		`ListLayout: "opacity" property: the value 2 is out of range [0, 1]`,
+		`ListLayout: "click-event" property: the event listener can be set only by code`,
+		`ListLayout.content[0]: "italic" property: invalid bool value "maybe"`,
+		`ListLayout.content[1]: "checked" property: the property is not supported by TextView (it is supported by Checkbox, ListView)`,
+		`ListLayout.content[2]: unknown view type "UnknownView"`,
+	}
+
+	errors := ValidateViewText(invalid)
+	if len(errors) != len(expected) {
+		t.Errorf("ValidateViewText returns %d errors, expected %d", len(errors), len(expected))
+	}
+	for i, err := range errors {
+		if i < len(expected) && err.Error() != expected[i] {
+			t.Errorf("error %d: %s\nexpected: %s", i, err.Error(), expected[i])
+		}
+	}
+}
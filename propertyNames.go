@@ -601,6 +601,13 @@ const (
 	// This is an inherited property, i.e. if it is not defined, then the value of the parent view is used.
 	NotTranslate = "not-translate"
 
+	// TextArgs is the constant for the "text-args" property tag.
+	// The "text-args" property sets the arguments of the translated text (the "text" property of TextView,
+	// the "summary" of DetailsView, the "title" of a tab, etc.). "@{name}" in the string resource is replaced
+	// by the value of the "name" argument, the "count" argument selects the plural form (see Translate).
+	// The value can be Params, map[string]interface{}, DataObject, or a text like "count = 5, name = Ann"
+	TextArgs = "text-args"
+
 	// Filter is the constant for the "filter" property tag.
 	// The "filter" property applies graphical effects to a View,
 	// such as such as blurring, color shifting, changing brightness/contrast, etc.
@@ -618,6 +625,11 @@ const (
 	// Points is the constant for the "points" property tag.
 	Points = "points"
 
+	// PathData is the constant for the "path-data" property tag.
+	// The string "path-data" property of the path clip shape sets the outline of the shape
+	// in the SVG path data format (the value of the "d" attribute of the SVG "path" element).
+	PathData = "path-data"
+
 	// ShapeOutside is the constant for the "shape-outside" property tag.
 	// The "shape-outside" property defines a shape (which may be non-rectangular) around which adjacent
 	// inline content should wrap. By default, inline content wraps around its margin box;
@@ -634,6 +646,13 @@ const (
 	// The "user-data" property can contain any user data
 	UserData = "user-data"
 
+	// GroupDigits is the constant for the "group-digits" property tag.
+	// The "group-digits" bool property of TableView and TextView defines whether the group separator
+	// of the session locale is inserted into the numbers ("1,234,567"). The default value is false,
+	// so integer values such as years and identifiers are written as is ("2024").
+	// The decimal separator of the locale is used in any case
+	GroupDigits = "group-digits"
+
 	// Resize is the constant for the "resize" property tag.
 	// The "resize" int property sets whether an element is resizable, and if so, in which directions.
 	// Valid values are "none" (0), "both" (1), horizontal (2), and "vertical" (3)
@@ -62,6 +62,12 @@ var boolProperties = []string{
 	Multiple,
 	TabCloseButton,
 	Repeating,
+	AriaAtomic,
+	AriaBusy,
+	AriaExpanded,
+	AriaHidden,
+	AriaModal,
+	GroupDigits,
 }
 
 var intProperties = []string{
@@ -71,6 +77,7 @@ var intProperties = []string{
 	RowSpan,
 	ColumnSpan,
 	ColumnCount,
+	TabIndex,
 }
 
 var floatProperties = map[string]struct{ min, max float64 }{
@@ -173,6 +180,16 @@ var enumProperties = map[string]struct {
 		"",
 		[]string{"div", "article", "section", "aside", "header", "main", "footer", "nav", "figure", "figcaption", "button", "p", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "code"},
 	},
+	AriaLive: {
+		[]string{"off", "polite", "assertive"},
+		"",
+		[]string{"off", "polite", "assertive"},
+	},
+	FocusGroup: {
+		[]string{"none", "horizontal", "vertical", "both"},
+		"",
+		[]string{"none", "horizontal", "vertical", "both"},
+	},
 	Visibility: {
 		[]string{"visible", "invisible", "gone"},
 		"",
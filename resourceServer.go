@@ -0,0 +1,423 @@
+package rui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// fingerprintParam is the URL query parameter that contains the content hash of a resource
+	fingerprintParam = "v"
+	// maxGzipCacheSize is the maximal size of a file which is compressed on the fly and cached in memory
+	maxGzipCacheSize       = 4 << 20
+	immutableCacheControl  = "public, max-age=31536000, immutable"
+	revalidateCacheControl = "no-cache"
+)
+
+// the sources of the resource files in the order of priority
+const (
+	embedResource = iota
+	pathResource
+	exeResource
+)
+
+// resourceFile describes an embedded or a disk file served over HTTP
+type resourceFile struct {
+	fs      *embed.FS
+	path    string
+	source  int
+	modTime time.Time
+	size    int64
+	hash    string
+	gzip    []byte
+}
+
+// resourceIndex maps the request file names to the resource files. The embedded files are
+// indexed by AddEmbedResources, the files of the resource directory by SetResourcePath, and
+// the files of the "resources" directory next to the executable on the first lookup
+type resourceIndex struct {
+	files   map[string]*resourceFile
+	exeOnce sync.Once
+	mutex   sync.Mutex
+}
+
+// add adds the file to the index. The file replaces the indexed one only if its source has a higher priority
+func (index *resourceIndex) add(name string, file *resourceFile) {
+	if old, ok := index.files[name]; !ok || file.source < old.source {
+		index.files[name] = file
+	}
+}
+
+// addEmbedFS adds all files of the embedded FS to the index. A file "a/b/c/name" can be requested as
+// "a/b/c/name", "b/c/name", or "c/name". Names which are already indexed are not replaced by the embedded files
+func (index *resourceIndex) addEmbedFS(embedFS *embed.FS) {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	fs.WalkDir(embedFS, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+
+		file := &resourceFile{fs: embedFS, path: path, source: embedResource}
+		if info, err := entry.Info(); err == nil {
+			file.modTime = info.ModTime()
+			file.size = info.Size()
+		}
+
+		name := path
+		for i := 0; i < 3; i++ {
+			index.add(name, file)
+			n := strings.IndexRune(name, '/')
+			if n < 0 {
+				break
+			}
+			name = name[n+1:]
+		}
+		return nil
+	})
+}
+
+// setDiskDir replaces the indexed files of the source by the files of the resource directory.
+// A file "dir/a/b" can be requested as "a/b" and a file "dir/images/a/b" also as "a/b"
+func (index *resourceIndex) setDiskDir(dir string, source int) {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	for name, file := range index.files {
+		if file.source == source {
+			delete(index.files, name)
+		}
+	}
+
+	if dir == "" {
+		return
+	}
+
+	for _, root := range []string{dir, filepath.Join(dir, imageDir)} {
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if path != root && strings.HasPrefix(info.Name(), ".") {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.Mode()&os.ModeSymlink != 0 {
+				if info, err = os.Stat(path); err != nil {
+					return nil
+				}
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			if rel, err := filepath.Rel(root, path); err == nil {
+				index.add(filepath.ToSlash(rel), &resourceFile{
+					path:    path,
+					source:  source,
+					modTime: info.ModTime(),
+					size:    info.Size(),
+				})
+			}
+			return nil
+		})
+	}
+}
+
+// resourceName returns the cleaned name of the requested file. The names which contain ".." elements
+// (which could go outside of the resource directories) or backslashes are rejected
+func resourceName(filename string) (string, bool) {
+	if strings.ContainsAny(filename, "\\\x00") {
+		return "", false
+	}
+	for _, part := range strings.Split(filename, "/") {
+		if part == ".." {
+			return "", false
+		}
+	}
+	name := path.Clean("/" + filename)[1:]
+	return name, name != ""
+}
+
+// find returns the file with the request name or nil if it is not found. Only the indexed files are returned
+func (index *resourceIndex) find(filename string) *resourceFile {
+	name, ok := resourceName(filename)
+	if !ok {
+		return nil
+	}
+
+	index.exeOnce.Do(func() {
+		if exe, err := os.Executable(); err == nil {
+			index.setDiskDir(filepath.Join(filepath.Dir(exe), "resources"), exeResource)
+		}
+	})
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	if image, ok := resources.images[name]; ok && image.fs != nil {
+		if file, ok := index.files[image.path]; ok && file.fs == image.fs {
+			return file
+		}
+	}
+
+	if file, ok := index.files[name]; ok {
+		if file.fs != nil {
+			return file
+		}
+
+		// the disk file can be changed, so its state is checked on each request
+		if info, err := os.Stat(file.path); err == nil && !info.IsDir() {
+			if !info.ModTime().Equal(file.modTime) || info.Size() != file.size {
+				file.modTime = info.ModTime()
+				file.size = info.Size()
+				file.hash = ""
+				file.gzip = nil
+			}
+			return file
+		}
+	}
+	return nil
+}
+
+func (file *resourceFile) open() (io.ReadSeekCloser, error) {
+	if file.fs != nil {
+		f, err := file.fs.Open(file.path)
+		if err != nil {
+			return nil, err
+		}
+		return f.(io.ReadSeekCloser), nil
+	}
+	return os.Open(file.path)
+}
+
+// contentHash returns the hash of the file content. The hash is calculated once and cached
+func (file *resourceFile) contentHash() string {
+	resources.files.mutex.Lock()
+	hash := file.hash
+	resources.files.mutex.Unlock()
+
+	if hash != "" {
+		return hash
+	}
+
+	f, err := file.open()
+	if err != nil {
+		ErrorLog(err.Error())
+		return ""
+	}
+	defer f.Close()
+
+	sum := sha256.New()
+	if _, err := io.Copy(sum, f); err != nil {
+		ErrorLog(err.Error())
+		return ""
+	}
+	hash = hex.EncodeToString(sum.Sum(nil)[:10])
+
+	resources.files.mutex.Lock()
+	file.hash = hash
+	resources.files.mutex.Unlock()
+	return hash
+}
+
+// gzipData returns the compressed file content. The result is cached
+func (file *resourceFile) gzipData() []byte {
+	resources.files.mutex.Lock()
+	data := file.gzip
+	resources.files.mutex.Unlock()
+
+	if data != nil {
+		return data
+	}
+
+	f, err := file.open()
+	if err != nil {
+		ErrorLog(err.Error())
+		return nil
+	}
+	defer f.Close()
+
+	buffer := new(bytes.Buffer)
+	writer := gzip.NewWriter(buffer)
+	if _, err := io.Copy(writer, f); err != nil {
+		ErrorLog(err.Error())
+		return nil
+	}
+	if err := writer.Close(); err != nil {
+		ErrorLog(err.Error())
+		return nil
+	}
+	data = buffer.Bytes()
+
+	resources.files.mutex.Lock()
+	file.gzip = data
+	resources.files.mutex.Unlock()
+	return data
+}
+
+// acceptEncoding returns true if the client accepts the content encoding
+func acceptEncoding(r *http.Request, encoding string) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, item := range strings.Split(header, ",") {
+			params := strings.Split(item, ";")
+			name := strings.ToLower(strings.Trim(params[0], " \t"))
+			if name != encoding && name != "*" {
+				continue
+			}
+
+			for _, param := range params[1:] {
+				param = strings.Trim(param, " \t")
+				if strings.HasPrefix(param, "q=") {
+					if q, err := strconv.ParseFloat(param[2:], 64); err == nil && q <= 0 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// isCompressible returns true if the content of the file with the name is worth to compress
+func isCompressible(filename string) bool {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		return false
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+	for _, name := range []string{"javascript", "json", "xml", "svg", "wasm", "font/ttf", "font/otf"} {
+		if strings.Contains(contentType, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func setContentType(w http.ResponseWriter, filename string) {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+}
+
+func serveResourceFile(filename string, w http.ResponseWriter, r *http.Request) bool {
+	file := resources.files.find(filename)
+	if file == nil {
+		return false
+	}
+
+	hash := file.contentHash()
+	header := w.Header()
+	if hash != "" && r.URL.Query().Get(fingerprintParam) == hash {
+		header.Set("Cache-Control", immutableCacheControl)
+	} else {
+		header.Set("Cache-Control", revalidateCacheControl)
+	}
+
+	compressible := isCompressible(filename)
+	if compressible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	// precompressed files have priority
+	for _, encoding := range []struct{ name, ext string }{{"br", ".br"}, {"gzip", ".gz"}} {
+		if !acceptEncoding(r, encoding.name) {
+			continue
+		}
+		if compressed := resources.files.find(filename + encoding.ext); compressed != nil {
+			f, err := compressed.open()
+			if err != nil {
+				ErrorLog(err.Error())
+				continue
+			}
+			defer f.Close()
+
+			if !compressible {
+				header.Add("Vary", "Accept-Encoding")
+			}
+			setContentType(w, filename)
+			header.Set("Content-Encoding", encoding.name)
+			if hash != "" {
+				header.Set("ETag", `"`+hash+"-"+encoding.name+`"`)
+			}
+			http.ServeContent(w, r, filename, file.modTime, f)
+			return true
+		}
+	}
+
+	if compressible && file.size <= maxGzipCacheSize && acceptEncoding(r, "gzip") {
+		if data := file.gzipData(); data != nil {
+			setContentType(w, filename)
+			header.Set("Content-Encoding", "gzip")
+			if hash != "" {
+				header.Set("ETag", `"`+hash+`-gzip"`)
+			}
+			http.ServeContent(w, r, filename, file.modTime, bytes.NewReader(data))
+			return true
+		}
+	}
+
+	f, err := file.open()
+	if err != nil {
+		ErrorLog(err.Error())
+		return false
+	}
+	defer f.Close()
+
+	if hash != "" {
+		header.Set("ETag", `"`+hash+`"`)
+	}
+	http.ServeContent(w, r, filename, file.modTime, f)
+	return true
+}
+
+// serveData sends the data with the ETag and the gzip compression
+func serveData(name string, data []byte, gzipData []byte, hash string, w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Set("Cache-Control", revalidateCacheControl)
+	header.Set("Vary", "Accept-Encoding")
+	setContentType(w, name)
+
+	if gzipData != nil && acceptEncoding(r, "gzip") {
+		header.Set("Content-Encoding", "gzip")
+		header.Set("ETag", `"`+hash+`-gzip"`)
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(gzipData))
+	} else {
+		header.Set("ETag", `"`+hash+`"`)
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+	}
+}
+
+// ResourceURL returns the URL of the resource file with the content hash (fingerprint), for example,
+// "image.png?v=3f2a9c1b0d4e5f607182". The browser caches the files requested by such URLs without revalidation.
+// If the file is not found then the file name is returned
+func ResourceURL(filename string) string {
+	if file := resources.files.find(filename); file != nil {
+		if hash := file.contentHash(); hash != "" {
+			return filename + "?" + fingerprintParam + "=" + hash
+		}
+	} else {
+		ErrorLogF(`The resource file "%s" not found`, filename)
+	}
+	return filename
+}
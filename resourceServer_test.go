@@ -0,0 +1,136 @@
+package rui
+
+import (
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//go:embed app_styles.css
+var testResourceFS embed.FS
+
+func TestServeResourceFile(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	AddEmbedResources(&testResourceFS)
+
+	request := func(url string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		if !serveResourceFile(strings.TrimPrefix(req.URL.Path, "/"), w, req) {
+			t.Fatalf(`serveResourceFile("%s") returns false`, url)
+		}
+		return w
+	}
+
+	w := request("/app_styles.css", nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Body.String() != appStyles {
+		t.Errorf("invalid response: %d, ETag: %s", w.Code, etag)
+	}
+	if cache := w.Header().Get("Cache-Control"); cache != revalidateCacheControl {
+		t.Errorf(`Cache-Control = "%s"`, cache)
+	}
+
+	if w = request("/app_styles.css", map[string]string{"If-None-Match": etag}); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match response code: %d", w.Code)
+	}
+
+	w = request("/app_styles.css", map[string]string{"Accept-Encoding": "deflate, gzip;q=0.8"})
+	if w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("ETag") == etag ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "text/css") {
+		t.Errorf("invalid gzip response headers: %v", w.Header())
+	}
+
+	if w = request("/app_styles.css", map[string]string{"Accept-Encoding": "gzip;q=0"}); w.Header().Get("Content-Encoding") != "" {
+		t.Error(`"gzip;q=0" must disable the compression`)
+	}
+
+	url := ResourceURL("app_styles.css")
+	if url != "app_styles.css?v="+strings.Trim(etag, `"`) {
+		t.Errorf(`ResourceURL("app_styles.css") = "%s"`, url)
+	}
+
+	if w = request("/"+url, nil); w.Header().Get("Cache-Control") != immutableCacheControl {
+		t.Errorf(`Cache-Control of the fingerprinted URL = "%s"`, w.Header().Get("Cache-Control"))
+	}
+}
+
+func TestServeResourceDiskFile(t *testing.T) {
+	createTestLog(t, false)
+
+	dir := t.TempDir()
+	for _, sub := range []string{imageDir, imageDir + "/sub", themeDir, stringsDir, viewDir, fontsDir} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, text := range map[string]string{
+		"page.txt":            "page",
+		"images/logo.png":     "logo",
+		".hidden":             "hidden",
+		"../outside.txt":      "outside",
+		"images/sub/icon.png": "icon",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	SetResourcePath(dir)
+	t.Cleanup(func() {
+		resources.path = ""
+		resources.files.setDiskDir("", pathResource)
+		delete(resources.images, "logo.png")
+		delete(resources.images, "sub/icon.png")
+	})
+
+	// the file created after SetResourcePath is not indexed
+	if err := os.WriteFile(filepath.Join(dir, "late.txt"), []byte("late"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	request := func(filename string) (string, bool) {
+		w := httptest.NewRecorder()
+		if !serveResourceFile(filename, w, httptest.NewRequest("GET", "/", nil)) {
+			return "", false
+		}
+		return w.Body.String(), true
+	}
+
+	for filename, text := range map[string]string{
+		"page.txt":             "page",
+		"logo.png":             "logo",
+		"images/logo.png":      "logo",
+		"sub/icon.png":         "icon",
+		"images//sub/icon.png": "icon",
+	} {
+		if body, ok := request(filename); !ok || body != text {
+			t.Errorf(`"%s" response: %v, "%s"`, filename, ok, body)
+		}
+	}
+
+	for _, filename := range []string{
+		"../outside.txt",
+		"images/../../outside.txt",
+		"images/../page.txt",
+		"..\\outside.txt",
+		".hidden",
+		"late.txt",
+		"",
+	} {
+		if _, ok := request(filename); ok {
+			t.Errorf(`"%s" must not be served`, filename)
+		}
+	}
+}
@@ -2,14 +2,13 @@ package rui
 
 import (
 	"embed"
-	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
@@ -35,6 +34,9 @@ type resourceManager struct {
 	themes       map[string]Theme
 	images       map[string]imagePath
 	imageSrcSets map[string][]scaledImage
+	addedThemes  []Theme
+	addedStrings []DataObject
+	files        resourceIndex
 	path         string
 }
 
@@ -43,10 +45,12 @@ var resources = resourceManager{
 	themes:       map[string]Theme{},
 	images:       map[string]imagePath{},
 	imageSrcSets: map[string][]scaledImage{},
+	files:        resourceIndex{files: map[string]*resourceFile{}},
 }
 
 func AddEmbedResources(fs *embed.FS) {
 	resources.embedFS = append(resources.embedFS, fs)
+	resources.files.addEmbedFS(fs)
 	rootDirs := embedRootDirs(fs)
 	for _, dir := range rootDirs {
 		switch dir {
@@ -54,12 +58,18 @@ func AddEmbedResources(fs *embed.FS) {
 			scanEmbedImagesDir(fs, dir, "")
 
 		case themeDir:
-			scanEmbedThemesDir(fs, dir)
+			updateThemes(func(themes *themeList) { scanEmbedThemesDir(fs, dir, themes) })
 
 		case stringsDir:
-			scanEmbedStringsDir(fs, dir)
+			updateStrings(func(list *stringList) { scanEmbedStringsDir(fs, dir, list) })
 
-		case viewDir, rawDir:
+		case fontsDir:
+			scanEmbedFontsDir(fs, dir)
+
+		case viewDir:
+			scanEmbedViewsDir(fs, dir, "")
+
+		case rawDir:
 			// do nothing
 
 		default:
@@ -71,12 +81,20 @@ func AddEmbedResources(fs *embed.FS) {
 							scanEmbedImagesDir(fs, dir+"/"+imageDir, "")
 
 						case themeDir:
-							scanEmbedThemesDir(fs, dir+"/"+themeDir)
+							path := dir + "/" + themeDir
+							updateThemes(func(themes *themeList) { scanEmbedThemesDir(fs, path, themes) })
 
 						case stringsDir:
-							scanEmbedStringsDir(fs, dir+"/"+stringsDir)
+							path := dir + "/" + stringsDir
+							updateStrings(func(list *stringList) { scanEmbedStringsDir(fs, path, list) })
+
+						case fontsDir:
+							scanEmbedFontsDir(fs, dir+"/"+fontsDir)
 
-						case viewDir, rawDir:
+						case viewDir:
+							scanEmbedViewsDir(fs, dir+"/"+viewDir, "")
+
+						case rawDir:
 							// do nothing
 						}
 					}
@@ -98,16 +116,16 @@ func embedRootDirs(fs *embed.FS) []string {
 	return result
 }
 
-func scanEmbedThemesDir(fs *embed.FS, dir string) {
+func scanEmbedThemesDir(fs *embed.FS, dir string, themes *themeList) {
 	if files, err := fs.ReadDir(dir); err == nil {
 		for _, file := range files {
 			name := file.Name()
 			path := dir + "/" + name
 			if file.IsDir() {
-				scanEmbedThemesDir(fs, path)
-			} else if strings.ToLower(filepath.Ext(name)) == ".rui" {
+				scanEmbedThemesDir(fs, path, themes)
+			} else if isDataFile(name) {
 				if data, err := fs.ReadFile(path); err == nil {
-					registerThemeText(string(data))
+					registerThemeData(parseDataFile(path, data), themes)
 				}
 			}
 		}
@@ -188,17 +206,17 @@ func scanImagesDirectory(path, filePrefix string) {
 	}
 }
 
-func scanThemesDir(path string) {
+func scanThemesDir(path string, themes *themeList) {
 	if files, err := ioutil.ReadDir(path); err == nil {
 		for _, file := range files {
 			filename := file.Name()
 			if filename[0] != '.' {
 				newPath := path + `/` + filename
 				if file.IsDir() {
-					scanThemesDir(newPath)
-				} else if strings.ToLower(filepath.Ext(newPath)) == ".rui" {
+					scanThemesDir(newPath, themes)
+				} else if isDataFile(newPath) {
 					if data, err := ioutil.ReadFile(newPath); err == nil {
-						registerThemeText(string(data))
+						registerThemeData(parseDataFile(newPath, data), themes)
 					} else {
 						ErrorLog(err.Error())
 					}
@@ -218,101 +236,26 @@ func SetResourcePath(path string) {
 		resources.path += "/"
 	}
 
+	resources.files.setDiskDir(resources.path, pathResource)
 	scanImagesDirectory(resources.path+imageDir, "")
-	scanThemesDir(resources.path + themeDir)
-	scanStringsDir(resources.path + stringsDir)
-}
-
-func registerThemeText(text string) bool {
-	theme, ok := CreateThemeFromText(text)
-	if !ok {
-		return false
-	}
-
-	name := theme.Name()
-	if name == "" {
-		defaultTheme.Append(theme)
-	} else if t, ok := resources.themes[name]; ok {
-		t.Append(theme)
-	} else {
-		resources.themes[name] = theme
-	}
-
-	return true
+	updateThemes(func(themes *themeList) { scanThemesDir(resources.path+themeDir, themes) })
+	updateStrings(func(list *stringList) { scanStringsDir(resources.path+stringsDir, list) })
+	scanFontsDir(resources.path + fontsDir)
+	scanViewsDir(resources.path+viewDir, "")
 }
 
-func serveResourceFile(filename string, w http.ResponseWriter, r *http.Request) bool {
-	serveEmbed := func(fs *embed.FS, path string) bool {
-		if file, err := fs.Open(path); err == nil {
-			if stat, err := file.Stat(); err == nil {
-				http.ServeContent(w, r, filename, stat.ModTime(), file.(io.ReadSeeker))
-				return true
-			}
-		}
+func registerThemeData(data DataObject, themes *themeList) bool {
+	if data == nil {
 		return false
 	}
 
-	if image, ok := resources.images[filename]; ok {
-		if image.fs != nil {
-			if serveEmbed(image.fs, image.path) {
-				return true
-			}
-		} else {
-			if _, err := os.Stat(image.path); err == nil {
-				http.ServeFile(w, r, image.path)
-				return true
-			}
-		}
-	}
-
-	for _, fs := range resources.embedFS {
-		if serveEmbed(fs, filename) {
-			return true
-		}
-		for _, dir := range embedRootDirs(fs) {
-			if serveEmbed(fs, dir+"/"+filename) {
-				return true
-			}
-			if subdirs, err := fs.ReadDir(dir); err == nil {
-				for _, subdir := range subdirs {
-					if subdir.IsDir() {
-						if serveEmbed(fs, dir+"/"+subdir.Name()+"/"+filename) {
-							return true
-						}
-					}
-				}
-			}
-		}
-	}
-
-	serve := func(path, filename string) bool {
-		filepath := path + filename
-		if _, err := os.Stat(filepath); err == nil {
-			http.ServeFile(w, r, filepath)
-			return true
-		}
-
-		filepath = path + imageDir + "/" + filename
-		if _, err := os.Stat(filepath); err == nil {
-			http.ServeFile(w, r, filepath)
-			return true
-		}
-
+	theme, ok := CreateThemeFromObject(data)
+	if !ok {
 		return false
 	}
 
-	if resources.path != "" && serve(resources.path, filename) {
-		return true
-	}
-
-	if exe, err := os.Executable(); err == nil {
-		path := filepath.Dir(exe) + "/resources/"
-		if serve(path, filename) {
-			return true
-		}
-	}
-
-	return false
+	themes.add(theme)
+	return true
 }
 
 func ReadRawResource(filename string) []byte {
@@ -414,15 +357,82 @@ func AllImageResources() []string {
 	return result
 }
 
+// AddTheme adds the theme to the list of themes. If the theme has no name
+// then it is appended to the default theme
 func AddTheme(theme Theme) {
 	if theme != nil {
-		name := theme.Name()
-		if name == "" {
-			defaultTheme.Append(theme)
-		} else if t, ok := resources.themes[name]; ok {
-			t.Append(theme)
-		} else {
-			resources.themes[name] = theme
-		}
+		updateThemes(func(themes *themeList) {
+			resources.addedThemes = append(resources.addedThemes, theme)
+			themes.add(theme)
+		})
+	}
+}
+
+func addTheme(theme Theme) {
+	updateThemes(func(themes *themeList) { themes.add(theme) })
+}
+
+// themesMutex guards defaultTheme and resources.themes which are read by the session goroutines.
+// The published themes are never changed: updateThemes builds the new set of themes and then replaces the old one
+var themesMutex sync.RWMutex
+
+// themesUpdateMutex serializes the updates of the themes
+var themesUpdateMutex sync.Mutex
+
+// themeList is the set of themes which is built by updateThemes
+type themeList struct {
+	base   Theme
+	themes map[string]Theme
+}
+
+// updateThemes calls the function for the copy of the registered themes and then publishes the result
+func updateThemes(update func(themes *themeList)) {
+	themesUpdateMutex.Lock()
+	defer themesUpdateMutex.Unlock()
+
+	themesMutex.RLock()
+	list := &themeList{base: NewTheme(""), themes: make(map[string]Theme, len(resources.themes))}
+	list.base.Append(defaultTheme)
+	for name, theme := range resources.themes {
+		list.themes[name] = theme
+	}
+	themesMutex.RUnlock()
+
+	update(list)
+
+	themesMutex.Lock()
+	defaultTheme = list.base
+	resources.themes = list.themes
+	themesMutex.Unlock()
+}
+
+// add appends the theme to the list. The theme object itself and the already published themes are not changed
+func (list *themeList) add(theme Theme) {
+	name := theme.Name()
+	if name == "" {
+		list.base.Append(theme)
+		return
+	}
+
+	result := NewTheme(name)
+	if t, ok := list.themes[name]; ok {
+		result.Append(t)
 	}
+	result.Append(theme)
+	list.themes[name] = result
+}
+
+// getDefaultTheme returns the default theme. The result must not be changed
+func getDefaultTheme() Theme {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+	return defaultTheme
+}
+
+// getTheme returns the registered theme with the given name. The result must not be changed
+func getTheme(name string) (Theme, bool) {
+	themesMutex.RLock()
+	defer themesMutex.RUnlock()
+	theme, ok := resources.themes[name]
+	return theme, ok
 }
@@ -20,6 +20,9 @@ type Session interface {
 
 	// DarkTheme returns "true" if the dark theme is used
 	DarkTheme() bool
+	// SetDarkTheme switches the session to the dark (true) or light (false) theme. The theme CSS and
+	// the styles of views are updated, then SessionDarkThemeListener is notified
+	SetDarkTheme(dark bool)
 	// Mobile returns "true" if current session is displayed on a touch screen device
 	TouchScreen() bool
 	// PixelRatio returns the ratio of the resolution in physical pixels to the resolution
@@ -41,10 +44,16 @@ type Session interface {
 	RemoteAddr() string
 	// Language returns the current session language
 	Language() string
-	// SetLanguage set the current session language
+	// SetLanguage set the current session language. The texts of string resources and the values
+	// formatted according to the locale are updated, then SessionLanguageListener is notified
 	SetLanguage(lang string)
 	// GetString returns the text for the current language
 	GetString(tag string) (string, bool)
+	// Translate returns the text of the string resource for the current language with substituted
+	// arguments and the plural form selected by the "count" argument (see rui.Translate)
+	Translate(tag string, args Params) string
+	// Locale returns the number and date formatting rules of the current language
+	Locale() Locale
 
 	// Content returns the SessionContent of session
 	Content() SessionContent
@@ -69,6 +78,14 @@ type Session interface {
 	DownloadFile(path string)
 	//DownloadFileData downloads (saves) on the client side a file with a specified name and specified content.
 	DownloadFileData(filename string, data []byte)
+	// FocusedView returns the view which has the input focus or nil if no view is focused.
+	FocusedView() View
+	// FocusNextView moves the input focus to the next view of the keyboard navigation order (like the Tab key).
+	// If a Popup is shown then the focus is moved only inside the top Popup.
+	FocusNextView()
+	// FocusPrevView moves the input focus to the previous view of the keyboard navigation order (like Shift+Tab).
+	// If a Popup is shown then the focus is moved only inside the top Popup.
+	FocusPrevView()
 
 	registerAnimation(props []AnimatedProperty) string
 
@@ -90,6 +107,13 @@ type Session interface {
 	handleRootSize(data DataObject)
 	handleResize(data DataObject)
 	handleViewEvent(command string, data DataObject)
+	hotReload(views []string)
+	handleHotReload(data DataObject)
+	handleDarkThemeChanged(data DataObject)
+	themeVariable(value interface{}, colorValue bool) (string, bool)
+	getCurrentTheme() Theme
+	getCustomTheme() Theme
+	previewTheme(theme Theme)
 	close()
 
 	onStart()
@@ -104,6 +128,8 @@ type Session interface {
 
 	popupManager() *popupManager
 	imageManager() *imageManager
+	textMetrics() *textMetrics
+	viewFocusChanged(view View, focused bool)
 }
 
 type sessionData struct {
@@ -118,6 +144,7 @@ type sessionData struct {
 	userAgent        string
 	language         string
 	languages        []string
+	title            string
 	checkboxOff      string
 	checkboxOn       string
 	radiobuttonOff   string
@@ -127,13 +154,17 @@ type sessionData struct {
 	viewCounter      int
 	content          SessionContent
 	rootView         View
+	focusedView      View
 	ignoreUpdates    bool
 	popups           *popupManager
 	images           *imageManager
+	metrics          *textMetrics
 	brige            WebBrige
 	events           chan DataObject
 	animationCounter int
 	animationCSS     string
+	themeVariables   bool
+	themeCSS         themeCSSState
 }
 
 func newSession(app Application, id int, customTheme string, params DataObject) Session {
@@ -150,6 +181,10 @@ func newSession(app Application, id int, customTheme string, params DataObject)
 	session.animationCounter = 0
 	session.animationCSS = ""
 
+	if app, ok := app.(*application); ok {
+		session.themeVariables = app.params.ThemeVariables
+	}
+
 	if customTheme != "" {
 		if theme, ok := CreateThemeFromText(customTheme); ok {
 			session.customTheme = theme
@@ -246,6 +281,7 @@ func (session *sessionData) Content() SessionContent {
 func (session *sessionData) setContent(content SessionContent, self Session) bool {
 	if content != nil {
 		session.content = content
+		session.focusedView = nil
 		session.rootView = content.CreateRootView(self)
 		if session.rootView != nil {
 			session.rootView.setParentID("ruiRootView")
@@ -260,7 +296,17 @@ func (session *sessionData) RootView() View {
 }
 
 func (session *sessionData) writeInitScript(writer *strings.Builder) {
-	if css := session.getCurrentTheme().cssText(session); css != "" {
+	session.writeThemeClassesScript(writer)
+	if session.themeVariables {
+		if css := fontResources.cssText(); css != "" {
+			css = strings.ReplaceAll(css, "\n", `\n`)
+			css = strings.ReplaceAll(css, "\t", `\t`)
+			writer.WriteString(`document.querySelector('style').textContent += "`)
+			writer.WriteString(css)
+			writer.WriteString("\";\n")
+		}
+		session.writeThemeStylesScript(writer)
+	} else if css := fontResources.cssText() + session.getCurrentTheme().cssText(session); css != "" {
 		css = strings.ReplaceAll(css, "\n", `\n`)
 		css = strings.ReplaceAll(css, "\t", `\t`)
 		writer.WriteString(`document.querySelector('style').textContent += "`)
@@ -279,12 +325,7 @@ func (session *sessionData) reload() {
 	buffer := allocStringBuilder()
 	defer freeStringBuilder(buffer)
 
-	css := appStyles + session.getCurrentTheme().cssText(session) + session.animationCSS
-	css = strings.ReplaceAll(css, "\n", `\n`)
-	css = strings.ReplaceAll(css, "\t", `\t`)
-	buffer.WriteString(`document.querySelector('style').textContent = "`)
-	buffer.WriteString(css)
-	buffer.WriteString("\";\n")
+	session.writeStylesScript(buffer)
 
 	if session.rootView != nil {
 		buffer.WriteString(`document.getElementById('ruiRootView').innerHTML = '`)
@@ -295,6 +336,24 @@ func (session *sessionData) reload() {
 	session.runScript(buffer.String())
 }
 
+// writeStylesScript writes the script which replaces all styles of the page
+func (session *sessionData) writeStylesScript(buffer *strings.Builder) {
+	css := appStyles + fontResources.cssText()
+	if !session.themeVariables {
+		css += session.getCurrentTheme().cssText(session)
+	}
+	css += session.animationCSS
+	css = strings.ReplaceAll(css, "\n", `\n`)
+	css = strings.ReplaceAll(css, "\t", `\t`)
+	buffer.WriteString(`document.querySelector('style').textContent = "`)
+	buffer.WriteString(css)
+	buffer.WriteString("\";\n")
+
+	if session.themeVariables {
+		session.writeThemeStylesScript(buffer)
+	}
+}
+
 func (session *sessionData) ignoreViewUpdates() bool {
 	return session.brige == nil || session.ignoreUpdates
 }
@@ -333,6 +392,13 @@ func (session *sessionData) imageManager() *imageManager {
 	return session.images
 }
 
+func (session *sessionData) textMetrics() *textMetrics {
+	if session.metrics == nil {
+		session.metrics = new(textMetrics)
+	}
+	return session.metrics
+}
+
 func (session *sessionData) runScript(script string) {
 	if session.brige != nil {
 		session.brige.WriteMessage(script)
@@ -431,6 +497,7 @@ func (session *sessionData) handleViewEvent(command string, data DataObject) {
 }
 
 func (session *sessionData) SetTitle(title string) {
+	session.title = title
 	title, _ = session.GetString(title)
 	session.runScript(`document.title = "` + title + `";`)
 }
@@ -118,45 +118,21 @@ func (session *sessionData) getCurrentTheme() Theme {
 	}
 
 	if session.customTheme != nil {
-		session.currentTheme = NewTheme("")
-		session.currentTheme.Append(defaultTheme)
-		session.currentTheme.Append(session.customTheme)
+		session.currentTheme = mergedTheme(session.customTheme, true)
 		return session.currentTheme
 	}
 
-	return defaultTheme
+	return getDefaultTheme()
 }
 
 // Color return the color with "tag" name or 0 if it is not exists
 func (session *sessionData) Color(tag string) (Color, bool) {
-	tags := []string{tag}
-	theme := session.getCurrentTheme()
-	for {
-		result := theme.color(tag, session.darkTheme)
-		if result == "" {
-			ErrorLogF(`"%v" color not found`, tag)
-			return 0, false
-		}
-
-		if len(result) == 0 || result[0] != '@' {
-			color, err := stringToColor(result)
-			if err != nil {
-				ErrorLogF(`invalid value "%v" of "%v" color constant (%s)`, result, tag, err.Error())
-				return 0, false
-			}
-			return color, true
-		}
-
-		tag = result[1:]
-		for _, t := range tags {
-			if t == tag {
-				ErrorLogF(`"%v" color is cyclic`, tag)
-				return 0, false
-			}
-		}
-
-		tags = append(tags, tag)
+	color, err := themeColor(session.getCurrentTheme(), tag, session.darkTheme)
+	if err != nil {
+		ErrorLog(err.Error())
+		return 0, false
 	}
+	return color, true
 }
 
 func (session *sessionData) ImageConstant(tag string) (string, bool) {
@@ -190,14 +166,19 @@ func (session *sessionData) SetCustomTheme(name string) bool {
 		if session.customTheme == nil {
 			return true
 		}
-	} else if theme, ok := resources.themes[name]; ok {
+	} else if theme, ok := getTheme(name); ok {
 		session.customTheme = theme
 		session.currentTheme = nil
 	} else {
 		return false
 	}
 
-	session.reload()
+	if session.themeVariables {
+		// the styles of views refer to the theme variables, so only the page styles are updated
+		session.updateThemeStyles()
+	} else {
+		session.reload()
+	}
 	return true
 }
 
@@ -324,16 +305,6 @@ func (session *sessionData) SetLanguage(lang string) {
 	lang = strings.Trim(lang, " \t\n\r")
 	if lang != session.language {
 		session.language = lang
-
-		if session.rootView != nil {
-			buffer := allocStringBuilder()
-			defer freeStringBuilder(buffer)
-
-			buffer.WriteString(`document.getElementById('ruiRootView').innerHTML = '`)
-			viewHTML(session.rootView, buffer)
-			buffer.WriteString("';\nscanElementsSize();")
-
-			session.runScript(buffer.String())
-		}
+		session.onLanguageChanged()
 	}
 }
@@ -1,6 +1,8 @@
 package rui
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -22,6 +24,92 @@ func createTestLog(t *testing.T, ignore bool) {
 	})
 }
 
+// testBrige is WebBrige which stores the scripts and answers the text measurement requests.
+// The width of each character is 10 pixels, the font size is 20 pixels
+type testBrige struct {
+	scripts []string
+	getters []string
+}
+
+func (brige *testBrige) ReadMessage() (string, bool) {
+	return "", false
+}
+
+func (brige *testBrige) WriteMessage(text string) bool {
+	brige.scripts = append(brige.scripts, text)
+	return true
+}
+
+func (brige *testBrige) RunGetterScript(script string) DataObject {
+	brige.getters = append(brige.getters, script)
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString("answer{widths=[")
+	for i, text := range testScriptTexts(script) {
+		if i > 0 {
+			buffer.WriteRune(',')
+		}
+		buffer.WriteString(strconv.Itoa(10 * len([]rune(text))))
+	}
+	buffer.WriteString("], fontSize=20}")
+	return ParseDataText(buffer.String())
+}
+
+func (brige *testBrige) AnswerReceived(answer DataObject) {
+}
+
+func (brige *testBrige) Close() {
+}
+
+func (brige *testBrige) remoteAddr() string {
+	return "localhost"
+}
+
+// testScriptTexts returns the texts of the "const texts = [...]" array of the text measurement script
+func testScriptTexts(script string) []string {
+	index := strings.Index(script, "const texts = [")
+	if index < 0 {
+		return nil
+	}
+
+	texts := []string{}
+	runes := []rune(script[index+15:])
+	text := []rune{}
+	quoted := false
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; {
+		case !quoted && ch == ']':
+			return texts
+
+		case ch == '\'':
+			if quoted {
+				texts = append(texts, string(text))
+				text = []rune{}
+			}
+			quoted = !quoted
+
+		case quoted && ch == '\\' && i+1 < len(runes):
+			i++
+			switch runes[i] {
+			case 'n':
+				text = append(text, '\n')
+			case 't':
+				text = append(text, '\t')
+			case 'r':
+				text = append(text, '\r')
+			default:
+				text = append(text, runes[i])
+			}
+
+		case quoted:
+			text = append(text, ch)
+		}
+	}
+	return texts
+}
+
 /*
 func createTestSession(t *testing.T) *sessionData {
 	session := new(sessionData)
@@ -2,23 +2,80 @@ package rui
 
 import (
 	"embed"
+	"fmt"
 	"io/ioutil"
-	"path/filepath"
 	"strings"
+	"sync"
 )
 
+// pluralArg is the name of the Translate argument which selects the plural form
+const pluralArg = "count"
+
 var stringResources = map[string]map[string]string{}
 
-func scanEmbedStringsDir(fs *embed.FS, dir string) {
+// pluralResources contains plural forms of strings: language -> tag -> plural category -> text
+var pluralResources = map[string]map[string]map[string]string{}
+
+// stringsMutex guards stringResources and pluralResources which are read by the session goroutines.
+// The published tables are never changed: updateStrings builds the new tables and then replaces the old ones
+var stringsMutex sync.RWMutex
+
+// stringsUpdateMutex serializes the updates of the string resources
+var stringsUpdateMutex sync.Mutex
+
+// stringList is the set of string resources which is built by updateStrings
+type stringList struct {
+	strings map[string]map[string]string
+	plurals map[string]map[string]map[string]string
+}
+
+// updateStrings calls the function for the copy of the string resources and then publishes the result
+func updateStrings(update func(list *stringList)) {
+	stringsUpdateMutex.Lock()
+	defer stringsUpdateMutex.Unlock()
+
+	stringsMutex.RLock()
+	list := &stringList{
+		strings: make(map[string]map[string]string, len(stringResources)),
+		plurals: make(map[string]map[string]map[string]string, len(pluralResources)),
+	}
+	for lang, table := range stringResources {
+		list.strings[lang] = table
+	}
+	for lang, plurals := range pluralResources {
+		list.plurals[lang] = plurals
+	}
+	stringsMutex.RUnlock()
+
+	update(list)
+
+	stringsMutex.Lock()
+	stringResources = list.strings
+	pluralResources = list.plurals
+	stringsMutex.Unlock()
+}
+
+// languageStrings returns the string resources and the plural forms of the language.
+// The result must not be changed
+func languageStrings(lang string) (map[string]string, map[string]map[string]string, bool) {
+	stringsMutex.RLock()
+	defer stringsMutex.RUnlock()
+	table, ok := stringResources[lang]
+	return table, pluralResources[lang], ok
+}
+
+func scanEmbedStringsDir(fs *embed.FS, dir string, list *stringList) {
 	if files, err := fs.ReadDir(dir); err == nil {
 		for _, file := range files {
 			name := file.Name()
 			path := dir + "/" + name
 			if file.IsDir() {
-				scanEmbedStringsDir(fs, path)
-			} else if strings.ToLower(filepath.Ext(name)) == ".rui" {
+				scanEmbedStringsDir(fs, path, list)
+			} else if isDataFile(name) {
 				if data, err := fs.ReadFile(path); err == nil {
-					loadStringResources(string(data))
+					if err := list.load(parseDataFile(path, data)); err != nil {
+						ErrorLogF(`"%s": %s`, path, err.Error())
+					}
 				} else {
 					ErrorLog(err.Error())
 				}
@@ -27,17 +84,19 @@ func scanEmbedStringsDir(fs *embed.FS, dir string) {
 	}
 }
 
-func scanStringsDir(path string) {
+func scanStringsDir(path string, list *stringList) {
 	if files, err := ioutil.ReadDir(path); err == nil {
 		for _, file := range files {
 			filename := file.Name()
 			if filename[0] != '.' {
 				newPath := path + `/` + filename
 				if file.IsDir() {
-					scanStringsDir(newPath)
-				} else if strings.ToLower(filepath.Ext(newPath)) == ".rui" {
+					scanStringsDir(newPath, list)
+				} else if isDataFile(newPath) {
 					if data, err := ioutil.ReadFile(newPath); err == nil {
-						loadStringResources(string(data))
+						if err := list.load(parseDataFile(newPath, data)); err != nil {
+							ErrorLogF(`"%s": %s`, newPath, err.Error())
+						}
 					} else {
 						ErrorLog(err.Error())
 					}
@@ -49,25 +108,82 @@ func scanStringsDir(path string) {
 	}
 }
 
-func loadStringResources(text string) {
-	data := ParseDataText(text)
+// AddStringsFromText adds the string resources from the text in the "strings { ... }"
+// or "strings:<language> { ... }" format. In case of a syntax error nothing is added and *DataParseError
+// is returned. Invalid plural forms are returned as an error too, but the valid strings of the text are added
+func AddStringsFromText(text string) error {
+	data, err := ParseDataTextWithError(text)
+	if err != nil {
+		return err
+	}
+	return loadStringResources(data)
+}
+
+func loadStringResources(data DataObject) error {
+	var err error
+	updateStrings(func(list *stringList) {
+		// the added strings are kept to be loaded again by the hot reload
+		resources.addedStrings = append(resources.addedStrings, data)
+		err = list.load(data)
+	})
+	return err
+}
+
+// load adds the string resources of the data to the list. The published tables are not changed.
+// The first found error is returned
+func (list *stringList) load(data DataObject) error {
 	if data == nil {
-		return
+		return nil
+	}
+
+	var loadErr error
+	fail := func(err error) {
+		if loadErr == nil {
+			loadErr = err
+		}
 	}
 
 	parseStrings := func(obj DataObject, lang string) {
-		table, ok := stringResources[lang]
-		if !ok {
-			table = map[string]string{}
+		table := map[string]string{}
+		for tag, text := range list.strings[lang] {
+			table[tag] = text
+		}
+
+		plurals := map[string]map[string]string{}
+		for tag, forms := range list.plurals[lang] {
+			plurals[tag] = forms
 		}
 
 		for i := 0; i < obj.PropertyCount(); i++ {
-			if prop := obj.Property(i); prop != nil && prop.Type() == TextNode {
-				table[prop.Tag()] = prop.Text()
+			if prop := obj.Property(i); prop != nil {
+				switch prop.Type() {
+				case TextNode:
+					table[prop.Tag()] = prop.Text()
+
+				case ObjectNode:
+					forms := map[string]string{}
+					formsObject := prop.Object()
+					for k := 0; k < formsObject.PropertyCount(); k++ {
+						if form := formsObject.Property(k); form.Type() == TextNode {
+							switch category := form.Tag(); category {
+							case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+								forms[category] = form.Text()
+
+							default:
+								fail(fmt.Errorf(`invalid plural category "%s" of the "%s" string`, category, prop.Tag()))
+							}
+						}
+					}
+					if _, ok := forms[PluralOther]; !ok {
+						fail(fmt.Errorf(`the "other" plural form of the "%s" string is not defined`, prop.Tag()))
+					}
+					plurals[prop.Tag()] = forms
+				}
 			}
 		}
 
-		stringResources[lang] = table
+		list.strings[lang] = table
+		list.plurals[lang] = plurals
 	}
 
 	tag := data.Tag()
@@ -78,17 +194,42 @@ func loadStringResources(text string) {
 			}
 		}
 
-	} else if strings.HasPrefix(tag, "strings:") {
-		if lang := tag[8:]; lang != "" {
-			parseStrings(data, lang)
+	} else if strings.HasPrefix(tag, "strings:") && tag != "strings:" {
+		parseStrings(data, tag[8:])
+
+	} else {
+		fail(fmt.Errorf(`invalid strings tag "%s", the "strings" or "strings:<language>" tag is expected`, tag))
+	}
+	return loadErr
+}
+
+// lookupString returns the text of the string resource. If the resource has plural forms then the form
+// for the count is returned. If count is nil then the "other" form is returned
+func lookupString(tag, lang string, count interface{}) (string, bool) {
+	table, plurals, _ := languageStrings(lang)
+	if text, ok := table[tag]; ok {
+		return text, true
+	}
+
+	if plurals != nil {
+		if forms, ok := plurals[tag]; ok {
+			if count != nil {
+				if text, ok := forms[PluralCategory(lang, count)]; ok {
+					return text, true
+				}
+			}
+			if text, ok := forms[PluralOther]; ok {
+				return text, true
+			}
 		}
 	}
+	return tag, false
 }
 
 // GetString returns the text for the language which is defined by "lang" parameter
 func GetString(tag, lang string) (string, bool) {
-	if table, ok := stringResources[lang]; ok {
-		if text, ok := table[tag]; ok {
+	if _, _, ok := languageStrings(lang); ok {
+		if text, ok := lookupString(tag, lang, nil); ok {
 			return text, true
 		}
 		DebugLogF(`There is no "%s" string resource`, tag)
@@ -97,10 +238,122 @@ func GetString(tag, lang string) (string, bool) {
 	return tag, false
 }
 
+// Translate returns the text of the string resource for the language with substituted arguments.
+// "@{name}" in the text is replaced by the value of the "name" argument. If the string resource has
+// plural forms then the form is selected by the "count" argument according to CLDR plural rules of the language.
+// If there is no such string resource then the arguments are substituted into the tag
+func Translate(tag, lang string, args Params) string {
+	text, _ := lookupString(tag, lang, args[pluralArg])
+	return interpolateString(text, args)
+}
+
+func (session *sessionData) Translate(tag string, args Params) string {
+	count := args[pluralArg]
+	text := tag
+	for _, lang := range session.stringLanguages() {
+		if str, ok := lookupString(tag, lang, count); ok {
+			text = str
+			break
+		}
+	}
+	return interpolateString(text, args)
+}
+
+// stringLanguages returns the languages in which the string resources are searched
+func (session *sessionData) stringLanguages() []string {
+	result := []string{}
+	if session.language != "" {
+		result = append(result, session.language)
+	}
+	for _, lang := range session.languages {
+		if lang != session.language {
+			result = append(result, lang)
+		}
+	}
+	return result
+}
+
+// interpolateString replaces "@{name}" in the text by the values of arguments
+func interpolateString(text string, args Params) string {
+	if len(args) == 0 || !strings.Contains(text, "@{") {
+		return text
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	for {
+		start := strings.Index(text, "@{")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexRune(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		buffer.WriteString(text[:start])
+		if value, ok := args[text[start+2:end]]; ok {
+			buffer.WriteString(fmt.Sprint(value))
+		} else {
+			buffer.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+
+	buffer.WriteString(text)
+	return buffer.String()
+}
+
+// valueToTextArgs converts the value of the "text-args" property to Params
+func valueToTextArgs(value interface{}) (Params, bool) {
+	args := Params{}
+	switch value := value.(type) {
+	case Params:
+		for key, val := range value {
+			args[key] = val
+		}
+
+	case map[string]interface{}:
+		for key, val := range value {
+			args[key] = val
+		}
+
+	case DataObject:
+		for i := 0; i < value.PropertyCount(); i++ {
+			if node := value.Property(i); node.Type() == TextNode {
+				args[node.Tag()] = node.Text()
+			}
+		}
+
+	case string:
+		obj := ParseDataText("_{" + value + "}")
+		if obj == nil {
+			return nil, false
+		}
+		return valueToTextArgs(obj)
+
+	default:
+		return nil, false
+	}
+	return args, true
+}
+
+// viewText returns the translation of the text property of the view. The arguments are taken from the "text-args" property
+func viewText(view View, text string) string {
+	session := view.Session()
+	if args, ok := view.getRaw(TextArgs).(Params); ok && len(args) > 0 {
+		return session.Translate(text, args)
+	}
+	text, _ = session.GetString(text)
+	return text
+}
+
 func (session *sessionData) GetString(tag string) (string, bool) {
 	getString := func(tag, lang string) (string, bool) {
-		if table, ok := stringResources[lang]; ok {
-			if text, ok := table[tag]; ok {
+		if _, _, ok := languageStrings(lang); ok {
+			if text, ok := lookupString(tag, lang, nil); ok {
 				return text, true
 			}
 			DebugLogF(`There is no "%s" string in "%s" resources`, tag, lang)
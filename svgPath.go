@@ -0,0 +1,355 @@
+package rui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type svgPathParser struct {
+	data []rune
+	pos  int
+}
+
+// NewPathFromSVG creates a new Path from the SVG path data (the value of the "d" attribute of the SVG "path" element).
+// All commands of the SVG path grammar (M, L, H, V, C, S, Q, T, A, Z) are supported
+// both in the absolute (upper case) and the relative (lower case) forms.
+func NewPathFromSVG(d string) (Path, error) {
+	path := NewPath().(*pathData)
+	parser := &svgPathParser{data: []rune(d)}
+	if err := parser.parse(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (parser *svgPathParser) skipSpaces() {
+	for parser.pos < len(parser.data) {
+		switch parser.data[parser.pos] {
+		case ' ', '\t', '\n', '\r', '\f':
+			parser.pos++
+
+		default:
+			return
+		}
+	}
+}
+
+func (parser *svgPathParser) skipSeparator() {
+	parser.skipSpaces()
+	if parser.pos < len(parser.data) && parser.data[parser.pos] == ',' {
+		parser.pos++
+		parser.skipSpaces()
+	}
+}
+
+func (parser *svgPathParser) isNumberStart() bool {
+	parser.skipSeparator()
+	if parser.pos >= len(parser.data) {
+		return false
+	}
+	switch ch := parser.data[parser.pos]; ch {
+	case '+', '-', '.':
+		return true
+
+	default:
+		return ch >= '0' && ch <= '9'
+	}
+}
+
+func (parser *svgPathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("SVG path data, position %d: %s", parser.pos, fmt.Sprintf(format, args...))
+}
+
+func (parser *svgPathParser) number() (float64, error) {
+	parser.skipSeparator()
+	start := parser.pos
+	count := len(parser.data)
+
+	isDigit := func() bool {
+		return parser.pos < count && parser.data[parser.pos] >= '0' && parser.data[parser.pos] <= '9'
+	}
+	skipDigits := func() bool {
+		digitStart := parser.pos
+		for isDigit() {
+			parser.pos++
+		}
+		return parser.pos > digitStart
+	}
+
+	if parser.pos < count && (parser.data[parser.pos] == '+' || parser.data[parser.pos] == '-') {
+		parser.pos++
+	}
+
+	intPart := skipDigits()
+	fracPart := false
+	if parser.pos < count && parser.data[parser.pos] == '.' {
+		parser.pos++
+		fracPart = skipDigits()
+	}
+	if !intPart && !fracPart {
+		parser.pos = start
+		return 0, parser.errorf("number expected")
+	}
+
+	if parser.pos < count && (parser.data[parser.pos] == 'e' || parser.data[parser.pos] == 'E') {
+		expStart := parser.pos
+		parser.pos++
+		if parser.pos < count && (parser.data[parser.pos] == '+' || parser.data[parser.pos] == '-') {
+			parser.pos++
+		}
+		if !skipDigits() {
+			parser.pos = expStart
+		}
+	}
+
+	value, err := strconv.ParseFloat(string(parser.data[start:parser.pos]), 64)
+	if err != nil {
+		parser.pos = start
+		return 0, parser.errorf("invalid number: %s", err.Error())
+	}
+	return value, nil
+}
+
+func (parser *svgPathParser) flag() (bool, error) {
+	parser.skipSeparator()
+	if parser.pos < len(parser.data) {
+		switch parser.data[parser.pos] {
+		case '0':
+			parser.pos++
+			return false, nil
+
+		case '1':
+			parser.pos++
+			return true, nil
+		}
+	}
+	return false, parser.errorf("flag (0 or 1) expected")
+}
+
+func (parser *svgPathParser) numbers(count int) ([]float64, error) {
+	result := make([]float64, count)
+	for i := range result {
+		value, err := parser.number()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+func (parser *svgPathParser) parse(path *pathData) error {
+	var x, y float64
+	// the last control point of the previous cubic (C, S) or quadratic (Q, T) command
+	var cubicX, cubicY, quadX, quadY float64
+	var lastCommand rune
+
+	for {
+		parser.skipSpaces()
+		if parser.pos >= len(parser.data) {
+			return nil
+		}
+
+		command := parser.data[parser.pos]
+		parser.pos++
+		if lastCommand == 0 && command != 'M' && command != 'm' {
+			parser.pos--
+			return parser.errorf("the path data must begin with the moveto command")
+		}
+
+		relative := command >= 'a' && command <= 'z'
+		offset := func(dx, dy float64) (float64, float64) {
+			if relative {
+				return x + dx, y + dy
+			}
+			return dx, dy
+		}
+
+		if command == 'Z' || command == 'z' {
+			path.Close()
+			x, y = path.startX, path.startY
+			lastCommand = command
+			continue
+		}
+
+		first := true
+		for first || parser.isNumberStart() {
+			switch command {
+			case 'M', 'm':
+				args, err := parser.numbers(2)
+				if err != nil {
+					return err
+				}
+				x, y = offset(args[0], args[1])
+				if first {
+					path.MoveTo(x, y)
+				} else {
+					// subsequent pairs of the moveto command are the implicit lineto commands
+					path.LineTo(x, y)
+				}
+
+			case 'L', 'l':
+				args, err := parser.numbers(2)
+				if err != nil {
+					return err
+				}
+				x, y = offset(args[0], args[1])
+				path.LineTo(x, y)
+
+			case 'H', 'h':
+				value, err := parser.number()
+				if err != nil {
+					return err
+				}
+				if relative {
+					x += value
+				} else {
+					x = value
+				}
+				path.LineTo(x, y)
+
+			case 'V', 'v':
+				value, err := parser.number()
+				if err != nil {
+					return err
+				}
+				if relative {
+					y += value
+				} else {
+					y = value
+				}
+				path.LineTo(x, y)
+
+			case 'C', 'c':
+				args, err := parser.numbers(6)
+				if err != nil {
+					return err
+				}
+				cp0x, cp0y := offset(args[0], args[1])
+				cubicX, cubicY = offset(args[2], args[3])
+				x, y = offset(args[4], args[5])
+				path.BezierCurveTo(cp0x, cp0y, cubicX, cubicY, x, y)
+
+			case 'S', 's':
+				args, err := parser.numbers(4)
+				if err != nil {
+					return err
+				}
+				cp0x, cp0y := x, y
+				switch lastCommand {
+				case 'C', 'c', 'S', 's':
+					cp0x, cp0y = 2*x-cubicX, 2*y-cubicY
+				}
+				cubicX, cubicY = offset(args[0], args[1])
+				x, y = offset(args[2], args[3])
+				path.BezierCurveTo(cp0x, cp0y, cubicX, cubicY, x, y)
+
+			case 'Q', 'q':
+				args, err := parser.numbers(4)
+				if err != nil {
+					return err
+				}
+				quadX, quadY = offset(args[0], args[1])
+				x, y = offset(args[2], args[3])
+				path.QuadraticCurveTo(quadX, quadY, x, y)
+
+			case 'T', 't':
+				args, err := parser.numbers(2)
+				if err != nil {
+					return err
+				}
+				switch lastCommand {
+				case 'Q', 'q', 'T', 't':
+					quadX, quadY = 2*x-quadX, 2*y-quadY
+
+				default:
+					quadX, quadY = x, y
+				}
+				x, y = offset(args[0], args[1])
+				path.QuadraticCurveTo(quadX, quadY, x, y)
+
+			case 'A', 'a':
+				args, err := parser.numbers(3)
+				if err != nil {
+					return err
+				}
+				largeArc, err := parser.flag()
+				if err != nil {
+					return err
+				}
+				sweep, err := parser.flag()
+				if err != nil {
+					return err
+				}
+				end, err := parser.numbers(2)
+				if err != nil {
+					return err
+				}
+				x0, y0 := x, y
+				x, y = offset(end[0], end[1])
+				svgArcToPath(path, x0, y0, args[0], args[1], args[2], largeArc, sweep, x, y)
+
+			default:
+				parser.pos--
+				return parser.errorf(`unknown command "%c"`, command)
+			}
+
+			lastCommand = command
+			first = false
+		}
+	}
+}
+
+// svgArcToPath converts the SVG elliptical arc from the endpoint parameterization
+// to the center parameterization and adds it to the path
+func svgArcToPath(path *pathData, x0, y0, radiusX, radiusY, rotation float64, largeArc, sweep bool, x, y float64) {
+	if x0 == x && y0 == y {
+		return
+	}
+
+	radiusX = math.Abs(radiusX)
+	radiusY = math.Abs(radiusY)
+	if radiusX == 0 || radiusY == 0 {
+		path.LineTo(x, y)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+
+	dx, dy := (x0-x)/2, (y0-y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// scale up the radii if they are too small
+	if lambda := (x1*x1)/(radiusX*radiusX) + (y1*y1)/(radiusY*radiusY); lambda > 1 {
+		scale := math.Sqrt(lambda)
+		radiusX *= scale
+		radiusY *= scale
+	}
+
+	rx2, ry2 := radiusX*radiusX, radiusY*radiusY
+	numerator := rx2*ry2 - rx2*y1*y1 - ry2*x1*x1
+	denominator := rx2*y1*y1 + ry2*x1*x1
+	coef := 0.0
+	if numerator > 0 && denominator > 0 {
+		coef = math.Sqrt(numerator / denominator)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+
+	cx1 := coef * radiusX * y1 / radiusY
+	cy1 := -coef * radiusY * x1 / radiusX
+
+	cx := cosPhi*cx1 - sinPhi*cy1 + (x0+x)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (y0+y)/2
+
+	startAngle := math.Atan2((y1-cy1)/radiusY, (x1-cx1)/radiusX)
+	endAngle := math.Atan2((-y1-cy1)/radiusY, (-x1-cx1)/radiusX)
+
+	// canvas arcs are drawn between angles modulo 2π, so the direction flag is enough
+	path.Ellipse(cx, cy, radiusX, radiusY, phi, startAngle, endAngle, sweep)
+	path.x, path.y = x, y
+}
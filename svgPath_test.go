@@ -0,0 +1,81 @@
+package rui
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNewPathFromSVG(t *testing.T) {
+
+	type testPair struct {
+		data, svg string
+	}
+
+	tests := []testPair{
+		{data: "", svg: ""},
+		{data: "M10 20 L30 40 Z", svg: "M10,20 L30,40 Z"},
+		{data: "m10,20 l5-5 h10 v-10 z", svg: "M10,20 L15,15 L25,15 L25,5 Z"},
+		{data: "M0,0 10,10 20,0", svg: "M0,0 L10,10 L20,0"},
+		{data: "M1.5.5L-1e1-2", svg: "M1.5,0.5 L-10,-2"},
+		{data: "M0 0 C 0 10 10 10 10 0 S 20 -10 20 0", svg: "M0,0 C0,10,10,10,10,0 C10,-10,20,-10,20,0"},
+		{data: "M0 0 Q 5 10 10 0 T 20 0", svg: "M0,0 Q5,10,10,0 Q15,-10,20,0"},
+		{data: "M0 0 q5 10 10 0 t10 0", svg: "M0,0 Q5,10,10,0 Q15,-10,20,0"},
+		{data: "M0 0 A 10 10 0 0 1 20 0", svg: "M0,0 A10,10,0,0,1,20,0"},
+		{data: "M0 0 a10 10 0 1020 0", svg: "M0,0 A10,10,0,0,0,20,0"},
+	}
+
+	for _, test := range tests {
+		path, err := NewPathFromSVG(test.data)
+		if err != nil {
+			t.Errorf(`NewPathFromSVG("%s") error: %s`, test.data, err.Error())
+			continue
+		}
+
+		if svg := roundSVGData(path.SVGData()); svg != test.svg {
+			t.Errorf(`NewPathFromSVG("%s").SVGData() = "%s". Need: "%s"`, test.data, svg, test.svg)
+		}
+	}
+
+	for _, data := range []string{"L10 10", "M10", "M0 0 X 10", "M0 0 A 10 10 0 2 0 10 10"} {
+		if _, err := NewPathFromSVG(data); err == nil {
+			t.Errorf(`NewPathFromSVG("%s") must return an error`, data)
+		}
+	}
+}
+
+func TestPathSVGData(t *testing.T) {
+	path := NewPath()
+	path.MoveTo(10, 10)
+	path.LineTo(20, 10)
+	path.Arc(20, 20, 10, -1.5707963267948966, 0, true)
+	path.Close()
+
+	if svg := roundSVGData(path.SVGData()); svg != "M10,10 L20,10 A10,10,0,0,1,30,20 Z" {
+		t.Errorf(`SVGData() = "%s"`, svg)
+	}
+
+	path.Reset()
+	path.Arc(0, 0, 10, 0, 6.283185307179586, true)
+	if svg := roundSVGData(path.SVGData()); svg != "M10,0 A10,10,0,0,1,-10,0 A10,10,0,0,1,10,0" {
+		t.Errorf(`SVGData() of a circle = "%s"`, svg)
+	}
+}
+
+// roundSVGData removes the rounding errors from the numbers of the SVG path data
+func roundSVGData(data string) string {
+	path := []rune(data)
+	result := make([]rune, 0, len(path))
+	parser := &svgPathParser{data: path}
+	for parser.pos < len(path) {
+		if ch := path[parser.pos]; (ch >= '0' && ch <= '9') || ch == '-' || ch == '.' {
+			value, _ := parser.number()
+			value = math.Round(value*1000)/1000 + 0
+			result = append(result, []rune(strconv.FormatFloat(value, 'g', -1, 64))...)
+		} else {
+			result = append(result, ch)
+			parser.pos++
+		}
+	}
+	return string(result)
+}
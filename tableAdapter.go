@@ -13,11 +13,13 @@ type TableAdapter interface {
 	// * rune
 	// * float32, float64
 	// * integer values: int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64
+	// * time.Time
 	// * bool
 	// * rui.Color
 	// * rui.View
 	// * fmt.Stringer
 	// * rui.VerticalTableJoin, rui.HorizontalTableJoin
+	// Numbers and time.Time values are formatted according to the session locale (see Session.Locale)
 	Cell(row, column int) interface{}
 }
 
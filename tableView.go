@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -290,7 +291,7 @@ func (table *tableViewData) normalizeTag(tag string) string {
 }
 
 func (table *tableViewData) Focusable() bool {
-	return GetTableSelectionMode(table, "") != NoneSelection
+	return GetTableSelectionMode(table, "") != NoneSelection || table.viewData.Focusable()
 }
 
 func (table *tableViewData) Get(tag string) interface{} {
@@ -308,7 +309,7 @@ func (table *tableViewData) remove(tag string) {
 		table.propertyChanged(tag)
 
 	case SelectionMode, TableVerticalAlign, Gap, CellBorder, CellPadding, RowStyle,
-		ColumnStyle, CellStyle, HeadHeight, HeadStyle, FootHeight, FootStyle, AllowSelection:
+		ColumnStyle, CellStyle, HeadHeight, HeadStyle, FootHeight, FootStyle, AllowSelection, GroupDigits:
 		if _, ok := table.properties[tag]; ok {
 			delete(table.properties, tag)
 			table.propertyChanged(tag)
@@ -494,7 +495,7 @@ func (table *tableViewData) set(tag string, value interface{}) bool {
 			return false
 		}
 
-	case SelectionMode, TableVerticalAlign, CellBorder, CellBorderStyle, CellBorderColor, CellBorderWidth,
+	case SelectionMode, TableVerticalAlign, GroupDigits, CellBorder, CellBorderStyle, CellBorderColor, CellBorderWidth,
 		CellBorderLeft, CellBorderLeftStyle, CellBorderLeftColor, CellBorderLeftWidth,
 		CellBorderRight, CellBorderRightStyle, CellBorderRightColor, CellBorderRightWidth,
 		CellBorderTop, CellBorderTopStyle, CellBorderTopColor, CellBorderTopWidth,
@@ -579,7 +580,7 @@ func (table *tableViewData) propertyChanged(tag string) {
 			CellBorder, HeadHeight, HeadStyle, FootHeight, FootStyle,
 			CellPaddingTop, CellPaddingRight, CellPaddingBottom, CellPaddingLeft,
 			TableCellClickedEvent, TableCellSelectedEvent, TableRowClickedEvent,
-			TableRowSelectedEvent, AllowSelection, Current:
+			TableRowSelectedEvent, AllowSelection, Current, GroupDigits:
 			table.ReloadTableData()
 
 		case Gap:
@@ -600,7 +601,6 @@ func (table *tableViewData) propertyChanged(tag string) {
 
 			switch GetTableSelectionMode(table, "") {
 			case CellSelection:
-				updateProperty(htmlID, "tabindex", "0", session)
 				updateProperty(htmlID, "onfocus", "tableViewFocusEvent(this, event)", session)
 				updateProperty(htmlID, "onblur", "tableViewBlurEvent(this, event)", session)
 				updateProperty(htmlID, "data-selection", "cell", session)
@@ -609,13 +609,14 @@ func (table *tableViewData) propertyChanged(tag string) {
 
 				if table.current.Row >= 0 && table.current.Column >= 0 {
 					updateProperty(htmlID, "data-current", table.cellID(table.current.Row, table.current.Column), session)
+					updateProperty(htmlID, "aria-activedescendant", table.cellID(table.current.Row, table.current.Column), session)
 				} else {
 					removeProperty(htmlID, "data-current", session)
+					removeProperty(htmlID, "aria-activedescendant", session)
 				}
 				updateProperty(htmlID, "onkeydown", "tableViewCellKeyDownEvent(this, event)", session)
 
 			case RowSelection:
-				updateProperty(htmlID, "tabindex", "0", session)
 				updateProperty(htmlID, "onfocus", "tableViewFocusEvent(this, event)", session)
 				updateProperty(htmlID, "onblur", "tableViewBlurEvent(this, event)", session)
 				updateProperty(htmlID, "data-selection", "cell", session)
@@ -624,16 +625,20 @@ func (table *tableViewData) propertyChanged(tag string) {
 
 				if table.current.Row >= 0 {
 					updateProperty(htmlID, "data-current", table.rowID(table.current.Row), session)
+					updateProperty(htmlID, "aria-activedescendant", table.rowID(table.current.Row), session)
 				} else {
 					removeProperty(htmlID, "data-current", session)
+					removeProperty(htmlID, "aria-activedescendant", session)
 				}
 				updateProperty(htmlID, "onkeydown", "tableViewRowKeyDownEvent(this, event)", session)
 
 			default: // NoneSelection
-				for _, prop := range []string{"tabindex", "data-current", "onfocus", "onblur", "onkeydown", "data-selection"} {
+				for _, prop := range []string{"data-current", "onkeydown", "data-selection", "aria-activedescendant"} {
 					removeProperty(htmlID, prop, session)
 				}
 			}
+			updateFocusProperty(table, TabIndex)
+			updateAriaProperty(table, Role)
 			updateInnerHTML(htmlID, session)
 		}
 	}
@@ -790,6 +795,13 @@ func (table *tableViewData) htmlTag() string {
 	return "table"
 }
 
+func (table *tableViewData) ariaRole() string {
+	if GetTableSelectionMode(table, "") != NoneSelection {
+		return viewRole(table, "grid")
+	}
+	return viewRole(table, "")
+}
+
 func (table *tableViewData) rowID(index int) string {
 	return fmt.Sprintf("%s-%d", table.htmlID(), index)
 }
@@ -821,6 +833,8 @@ func (table *tableViewData) htmlProperties(self View, buffer *strings.Builder) {
 			if table.current.Row >= 0 {
 				buffer.WriteString(` data-current="`)
 				buffer.WriteString(table.rowID(table.current.Row))
+				buffer.WriteString(`" aria-activedescendant="`)
+				buffer.WriteString(table.rowID(table.current.Row))
 				buffer.WriteRune('"')
 			}
 
@@ -829,6 +843,8 @@ func (table *tableViewData) htmlProperties(self View, buffer *strings.Builder) {
 			if table.current.Row >= 0 && table.current.Column >= 0 {
 				buffer.WriteString(` data-current="`)
 				buffer.WriteString(table.cellID(table.current.Row, table.current.Column))
+				buffer.WriteString(`" aria-activedescendant="`)
+				buffer.WriteString(table.cellID(table.current.Row, table.current.Column))
 				buffer.WriteRune('"')
 			}
 		}
@@ -914,6 +930,7 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 
 	tableCSS := func(startRow, endRow int, cellTag string, cellBorder BorderProperty, cellPadding BoundsProperty) {
 		var namedColors []NamedColor = nil
+		locale := viewLocale(table)
 
 		for row := startRow; row < endRow; row++ {
 			cssBuilder.buffer.Reset()
@@ -942,6 +959,11 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 					buffer.WriteRune('"')
 				}
 
+				if row == table.current.Row {
+					buffer.WriteString(` aria-selected="true"`)
+				} else {
+					buffer.WriteString(` aria-selected="false"`)
+				}
 				buffer.WriteString(` onclick="tableRowClickEvent(this, event)"`)
 
 				if allowRowSelection != nil && !allowRowSelection.AllowRowSelection(row) {
@@ -1033,6 +1055,11 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 					buffer.WriteRune('"')
 
 					if selectionMode == CellSelection {
+						if row == table.current.Row && column == table.current.Column {
+							buffer.WriteString(` aria-selected="true"`)
+						} else {
+							buffer.WriteString(` aria-selected="false"`)
+						}
 						buffer.WriteString(` onclick="tableCellClickEvent(this, event)"`)
 						if allowCellSelection != nil && !allowCellSelection.AllowCellSelection(row, column) {
 							buffer.WriteString(` data-disabled="1"`)
@@ -1100,17 +1127,17 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 							}
 						}
 
+					case time.Time:
+						buffer.WriteString(textToJS(locale.FormatValue(value)))
+
 					case fmt.Stringer:
 						buffer.WriteString(textToJS(value.String()))
 
 					case rune:
 						buffer.WriteString(textToJS(string(value)))
 
-					case float32:
-						buffer.WriteString(fmt.Sprintf("%g", float64(value)))
-
-					case float64:
-						buffer.WriteString(fmt.Sprintf("%g", value))
+					case float32, float64:
+						buffer.WriteString(textToJS(locale.FormatValue(value)))
 
 					case bool:
 						if value {
@@ -1120,8 +1147,8 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 						}
 
 					default:
-						if n, ok := isInt(value); ok {
-							buffer.WriteString(fmt.Sprintf("%d", n))
+						if text, ok := locale.FormatNumber(value); ok {
+							buffer.WriteString(textToJS(text))
 						} else {
 							buffer.WriteString("<Unsupported value>")
 						}
@@ -1390,6 +1417,18 @@ func (table *tableViewData) ReloadTableData() {
 	updateInnerHTML(table.htmlID(), table.Session())
 }
 
+func (table *tableViewData) languageChanged() {
+	if table.created {
+		table.ReloadTableData()
+	}
+}
+
+func (table *tableViewData) themeChanged() {
+	if table.created {
+		updateInnerHTML(table.htmlID(), table.Session())
+	}
+}
+
 func (table *tableViewData) onItemResize(self View, index string, x, y, width, height float64) {
 	if n := strings.IndexRune(index, '-'); n > 0 {
 		if row, err := strconv.Atoi(index[:n]); err == nil {
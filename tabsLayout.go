@@ -488,6 +488,12 @@ func (tabsLayout *tabsLayoutData) valueToCloseListeners(value interface{}) []fun
 	return nil
 }
 
+func (tabsLayout *tabsLayoutData) languageChanged() {
+	if tabsLayout.created {
+		updateInnerHTML(tabsLayout.htmlID(), tabsLayout.session)
+	}
+}
+
 func (tabsLayout *tabsLayoutData) tabsLocation() int {
 	tabs, _ := enumProperty(tabsLayout, Tabs, tabsLayout.session, 0)
 	return tabs
@@ -577,7 +583,7 @@ func (tabsLayout *tabsLayoutData) ListItem(index int, session Session) View {
 		title = "No title"
 	}
 	if !GetNotTranslate(tabsLayout, "") {
-		title, _ = tabsLayout.Session().GetString(title)
+		title = viewText(page, title)
 	}
 
 	views = append(views, NewTextView(session, Params{
@@ -749,7 +755,13 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 
 		buffer.WriteString(`<div class="`)
 		buffer.WriteString(tabsLayout.tabBarStyle())
-		buffer.WriteString(`" style="display: flex;`)
+		switch location {
+		case LeftTabs, LeftListTabs, RightTabs, RightListTabs:
+			buffer.WriteString(`" role="tablist" aria-orientation="vertical" style="display: flex;`)
+
+		default:
+			buffer.WriteString(`" role="tablist" style="display: flex;`)
+		}
 
 		switch location {
 		case LeftTabs, LeftListTabs, TopTabs:
@@ -806,7 +818,7 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 			icon, _ := imageProperty(view, Icon, tabsLayout.session)
 			title, _ := stringProperty(view, Title, tabsLayout.session)
 			if !notTranslate {
-				title, _ = tabsLayout.Session().GetString(title)
+				title = viewText(view, title)
 			}
 
 			buffer.WriteString(`<div id="`)
@@ -819,6 +831,12 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 			} else {
 				buffer.WriteString(inactiveStyle)
 			}
+			buffer.WriteString(`" role="tab" aria-selected="`)
+			buffer.WriteString(strconv.FormatBool(n == current))
+			buffer.WriteString(`" aria-controls="`)
+			buffer.WriteString(tabsLayoutID)
+			buffer.WriteString(`-page`)
+			buffer.WriteString(strconv.Itoa(n))
 			buffer.WriteString(`" tabindex="0" onclick="tabClickEvent(this, \'`)
 			buffer.WriteString(tabsLayoutID)
 			buffer.WriteString(`\', `)
@@ -861,7 +879,10 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 				close = closeButton
 			}
 			if close {
-				buffer.WriteString(`<div class="ruiTabCloseButton" tabindex="0" onclick="tabCloseClickEvent(this, \'`)
+				closeLabel, _ := tabsLayout.session.GetString("Close")
+				buffer.WriteString(`<div class="ruiTabCloseButton" role="button" aria-label="`)
+				buffer.WriteString(ariaText(closeLabel))
+				buffer.WriteString(`" tabindex="0" onclick="tabCloseClickEvent(this, \'`)
 				buffer.WriteString(tabsLayoutID)
 				buffer.WriteString(`\', `)
 				buffer.WriteString(strconv.Itoa(n))
@@ -896,6 +917,13 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 		buffer.WriteString(tabsLayoutID)
 		buffer.WriteString(`-page`)
 		buffer.WriteString(strconv.Itoa(n))
+		buffer.WriteString(`" role="tabpanel`)
+		if location != HiddenTabs {
+			buffer.WriteString(`" aria-labelledby="`)
+			buffer.WriteString(tabsLayoutID)
+			buffer.WriteByte('-')
+			buffer.WriteString(strconv.Itoa(n))
+		}
 
 		switch location {
 		case LeftTabs, LeftListTabs:
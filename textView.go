@@ -3,6 +3,7 @@ package rui
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // TextView - text View
@@ -48,7 +49,7 @@ func (textView *textViewData) remove(tag string) {
 	textView.viewData.remove(tag)
 	if textView.created {
 		switch tag {
-		case Text:
+		case Text, TextArgs, GroupDigits:
 			updateInnerHTML(textView.htmlID(), textView.session)
 
 		case TextOverflow:
@@ -68,15 +69,13 @@ func (textView *textViewData) set(tag string, value interface{}) bool {
 		case string:
 			textView.properties[Text] = value
 
+		case time.Time, float32, float64:
+			// numbers and dates are stored as is and formatted according to the session locale
+			textView.properties[Text] = value
+
 		case fmt.Stringer:
 			textView.properties[Text] = value.String()
 
-		case float32:
-			textView.properties[Text] = fmt.Sprintf("%g", float64(value))
-
-		case float64:
-			textView.properties[Text] = fmt.Sprintf("%g", value)
-
 		case []rune:
 			textView.properties[Text] = string(value)
 
@@ -88,8 +87,8 @@ func (textView *textViewData) set(tag string, value interface{}) bool {
 			}
 
 		default:
-			if n, ok := isInt(value); ok {
-				textView.properties[Text] = fmt.Sprintf("%d", n)
+			if _, ok := isInt(value); ok {
+				textView.properties[Text] = value
 			} else {
 				notCompatibleType(tag, value)
 				return false
@@ -107,7 +106,7 @@ func (textView *textViewData) set(tag string, value interface{}) bool {
 			textView.textOverflowUpdated()
 		}
 
-	case NotTranslate:
+	case NotTranslate, TextArgs, GroupDigits:
 		if !textView.viewData.set(tag, value) {
 			return false
 		}
@@ -135,6 +134,14 @@ func (textView *textViewData) textOverflowUpdated() {
 	updateCSSProperty(textView.htmlID(), TextOverflow, "", session)
 }
 
+func (textView *textViewData) languageChanged() {
+	if textView.created {
+		if _, ok := textView.getRaw(Text).(string); !ok || !GetNotTranslate(textView, "") {
+			updateInnerHTML(textView.htmlID(), textView.session)
+		}
+	}
+}
+
 func textToJS(text string) string {
 	for _, ch := range []struct{ old, new string }{
 		{old: "\\", new: `\\`},
@@ -156,9 +163,11 @@ func (textView *textViewData) htmlSubviews(self View, buffer *strings.Builder) {
 	if value := textView.getRaw(Text); value != nil {
 		if text, ok := value.(string); ok {
 			if !GetNotTranslate(textView, "") {
-				text, _ = textView.session.GetString(text)
+				text = viewText(textView, text)
 			}
 			buffer.WriteString(textToJS(text))
+		} else {
+			buffer.WriteString(textToJS(viewLocale(textView).FormatValue(value)))
 		}
 	}
 }
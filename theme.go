@@ -1,9 +1,9 @@
 package rui
 
 import (
+	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -14,14 +14,13 @@ const (
 )
 
 type mediaStyle struct {
-	orientation int
-	maxWidth    int
-	maxHeight   int
-	styles      map[string]ViewStyle
+	MediaRule
+	styles map[string]ViewStyle
 }
 
 type theme struct {
 	name           string
+	extends        string
 	constants      map[string]string
 	touchConstants map[string]string
 	colors         map[string]string
@@ -35,6 +34,11 @@ type theme struct {
 type Theme interface {
 	fmt.Stringer
 	Name() string
+	// Extends returns the name of the base theme ("" or "default" - the default theme)
+	Extends() string
+	// SetExtends sets the name of the base theme. The constants, colors, images, and styles
+	// which are not defined in the theme are taken from the base theme
+	SetExtends(name string)
 	Constant(tag string) (string, string)
 	SetConstant(tag string, value, touchUIValue string)
 	// ConstantTags returns the list of all available constants
@@ -51,10 +55,15 @@ type Theme interface {
 	SetStyle(tag string, style ViewStyle)
 	MediaStyle(tag string, orientation, maxWidth, maxHeight int) ViewStyle
 	SetMediaStyle(tag string, orientation, maxWidth, maxHeight int, style ViewStyle)
+	// MediaRuleStyle returns the style of the section defined by the media rule
+	MediaRuleStyle(tag string, rule MediaRule) ViewStyle
+	// SetMediaRuleStyle sets the style of the section defined by the media rule. If the style is nil then it is removed
+	SetMediaRuleStyle(tag string, rule MediaRule, style ViewStyle)
 	StyleTags() []string
 	MediaStyles(tag string) []struct {
 		Selectors                        string
 		Orientation, MaxWidth, MaxHeight int
+		Rule                             MediaRule
 	}
 	Append(anotherTheme Theme)
 
@@ -66,98 +75,6 @@ type Theme interface {
 	data() *theme
 }
 
-func (rule mediaStyle) cssText() string {
-	builder := allocStringBuilder()
-	defer freeStringBuilder(builder)
-
-	switch rule.orientation {
-	case PortraitMedia:
-		builder.WriteString(" and (orientation: portrait)")
-
-	case LandscapeMedia:
-		builder.WriteString(" and (orientation: landscape)")
-	}
-
-	if rule.maxWidth > 0 {
-		builder.WriteString(" and (max-width: ")
-		builder.WriteString(strconv.Itoa(rule.maxWidth))
-		builder.WriteString("px)")
-	}
-
-	if rule.maxHeight > 0 {
-		builder.WriteString(" and (max-height: ")
-		builder.WriteString(strconv.Itoa(rule.maxHeight))
-		builder.WriteString("px)")
-	}
-
-	return builder.String()
-}
-
-func parseMediaRule(text string) (mediaStyle, bool) {
-	rule := mediaStyle{
-		orientation: DefaultMedia,
-		maxWidth:    0,
-		maxHeight:   0,
-		styles:      map[string]ViewStyle{},
-	}
-
-	elements := strings.Split(text, ":")
-	for i := 1; i < len(elements); i++ {
-		switch element := elements[i]; element {
-		case "portrait":
-			if rule.orientation != DefaultMedia {
-				ErrorLog(`Duplicate orientation tag in the style section "` + text + `"`)
-				return rule, false
-			}
-			rule.orientation = PortraitMedia
-
-		case "landscape":
-			if rule.orientation != DefaultMedia {
-				ErrorLog(`Duplicate orientation tag in the style section "` + text + `"`)
-				return rule, false
-			}
-			rule.orientation = LandscapeMedia
-
-		default:
-			elementSize := func(name string) (int, bool) {
-				if strings.HasPrefix(element, name) {
-					size, err := strconv.Atoi(element[len(name):])
-					if err == nil && size > 0 {
-						return size, true
-					}
-					ErrorLogF(`Invalid style section name "%s": %s`, text, err.Error())
-					return 0, false
-				}
-				return 0, true
-			}
-
-			if size, ok := elementSize("width"); !ok || size > 0 {
-				if !ok {
-					return rule, false
-				}
-				if rule.maxWidth != 0 {
-					ErrorLog(`Duplicate "width" tag in the style section "` + text + `"`)
-					return rule, false
-				}
-				rule.maxWidth = size
-			} else if size, ok := elementSize("height"); !ok || size > 0 {
-				if !ok {
-					return rule, false
-				}
-				if rule.maxHeight != 0 {
-					ErrorLog(`Duplicate "height" tag in the style section "` + text + `"`)
-					return rule, false
-				}
-				rule.maxHeight = size
-			} else {
-				ErrorLogF(`Unknown elemnet "%s" in the style section name "%s"`, element, text)
-				return rule, false
-			}
-		}
-	}
-	return rule, true
-}
-
 var defaultTheme = NewTheme("")
 
 func NewTheme(name string) Theme {
@@ -168,9 +85,28 @@ func NewTheme(name string) Theme {
 }
 
 func CreateThemeFromText(text string) (Theme, bool) {
+	result, err := CreateThemeFromTextWithError(text)
+	if err != nil {
+		ErrorLog(err.Error())
+		return result, false
+	}
+	return result, true
+}
+
+// CreateThemeFromTextWithError creates the theme from the text. If the text is invalid then
+// the error is returned (*DataParseError in case of a syntax error)
+func CreateThemeFromTextWithError(text string) (Theme, error) {
+	result := new(theme)
+	result.init()
+	err := result.addText(text)
+	return result, err
+}
+
+// CreateThemeFromObject creates the theme from DataObject (for example, the result of ParseDataJSON)
+func CreateThemeFromObject(data DataObject) (Theme, bool) {
 	result := new(theme)
 	result.init()
-	ok := result.addText(text)
+	ok := result.addData(data)
 	return result, ok
 }
 
@@ -189,6 +125,14 @@ func (theme *theme) Name() string {
 	return theme.name
 }
 
+func (theme *theme) Extends() string {
+	return theme.extends
+}
+
+func (theme *theme) SetExtends(name string) {
+	theme.extends = name
+}
+
 func (theme *theme) Constant(tag string) (string, string) {
 	return theme.constants[tag], theme.touchConstants[tag]
 }
@@ -265,50 +209,55 @@ func (theme *theme) SetStyle(tag string, style ViewStyle) {
 }
 
 func (theme *theme) MediaStyle(tag string, orientation, maxWidth, maxHeight int) ViewStyle {
+	return theme.MediaRuleStyle(tag, MediaRule{Orientation: orientation, MaxWidth: maxWidth, MaxHeight: maxHeight})
+}
+
+func (theme *theme) SetMediaStyle(tag string, orientation, maxWidth, maxHeight int, style ViewStyle) {
+	theme.SetMediaRuleStyle(tag, MediaRule{Orientation: orientation, MaxWidth: maxWidth, MaxHeight: maxHeight}, style)
+}
+
+func (theme *theme) MediaRuleStyle(tag string, rule MediaRule) ViewStyle {
 	for _, styles := range theme.mediaStyles {
-		if styles.orientation == orientation && styles.maxWidth == maxWidth && styles.maxHeight == maxHeight {
+		if styles.MediaRule == rule {
 			if style, ok := styles.styles[tag]; ok {
 				return style
 			}
 		}
 	}
-	if orientation == 0 && maxWidth <= 0 && maxHeight <= 0 {
+	if rule == (MediaRule{}) {
 		return theme.style(tag)
 	}
 
 	return nil
 }
 
-func (theme *theme) SetMediaStyle(tag string, orientation, maxWidth, maxHeight int, style ViewStyle) {
-	if maxWidth < 0 {
-		maxWidth = 0
-	}
-	if maxHeight < 0 {
-		maxHeight = 0
+func (theme *theme) SetMediaRuleStyle(tag string, rule MediaRule, style ViewStyle) {
+	for _, limit := range []*int{&rule.MinWidth, &rule.MaxWidth, &rule.MinHeight, &rule.MaxHeight} {
+		if *limit < 0 {
+			*limit = 0
+		}
 	}
 
-	if orientation == DefaultMedia && maxWidth == 0 && maxHeight == 0 {
+	if rule == (MediaRule{}) {
 		theme.SetStyle(tag, style)
 		return
 	}
 
 	for i, styles := range theme.mediaStyles {
-		if styles.orientation == orientation && styles.maxWidth == maxWidth && styles.maxHeight == maxHeight {
+		if styles.MediaRule == rule {
 			if style != nil {
 				theme.mediaStyles[i].styles[tag] = style
 			} else {
 				delete(theme.mediaStyles[i].styles, tag)
 			}
-			break
+			return
 		}
 	}
 
 	if style != nil {
 		theme.mediaStyles = append(theme.mediaStyles, mediaStyle{
-			orientation: orientation,
-			maxWidth:    maxWidth,
-			maxHeight:   maxHeight,
-			styles:      map[string]ViewStyle{tag: style},
+			MediaRule: rule,
+			styles:    map[string]ViewStyle{tag: style},
 		})
 		theme.sortMediaStyles()
 	}
@@ -408,51 +357,43 @@ func (theme *theme) StyleTags() []string {
 func (theme *theme) MediaStyles(tag string) []struct {
 	Selectors                        string
 	Orientation, MaxWidth, MaxHeight int
+	Rule                             MediaRule
 } {
 	result := []struct {
 		Selectors                        string
 		Orientation, MaxWidth, MaxHeight int
+		Rule                             MediaRule
 	}{}
 
+	appendStyle := func(selectors string, rule MediaRule) {
+		result = append(result, struct {
+			Selectors                        string
+			Orientation, MaxWidth, MaxHeight int
+			Rule                             MediaRule
+		}{
+			Selectors:   selectors,
+			Orientation: rule.Orientation,
+			MaxWidth:    rule.MaxWidth,
+			MaxHeight:   rule.MaxHeight,
+			Rule:        rule,
+		})
+	}
+
 	prefix := tag + ":"
 	prefixLen := len(prefix)
 	for themeTag := range theme.styles {
 		if strings.HasPrefix(themeTag, prefix) {
-			result = append(result, struct {
-				Selectors                        string
-				Orientation, MaxWidth, MaxHeight int
-			}{
-				Selectors:   themeTag[prefixLen:],
-				Orientation: DefaultMedia,
-				MaxWidth:    0,
-				MaxHeight:   0,
-			})
+			appendStyle(themeTag[prefixLen:], MediaRule{})
 		}
 	}
 
 	for _, media := range theme.mediaStyles {
 		if _, ok := media.styles[tag]; ok {
-			result = append(result, struct {
-				Selectors                        string
-				Orientation, MaxWidth, MaxHeight int
-			}{
-				Selectors:   "",
-				Orientation: media.orientation,
-				MaxWidth:    media.maxWidth,
-				MaxHeight:   media.maxHeight,
-			})
+			appendStyle("", media.MediaRule)
 		}
 		for themeTag := range media.styles {
 			if strings.HasPrefix(themeTag, prefix) {
-				result = append(result, struct {
-					Selectors                        string
-					Orientation, MaxWidth, MaxHeight int
-				}{
-					Selectors:   themeTag[prefixLen:],
-					Orientation: media.orientation,
-					MaxWidth:    media.maxWidth,
-					MaxHeight:   media.maxHeight,
-				})
+				appendStyle(themeTag[prefixLen:], media.MediaRule)
 			}
 		}
 	}
@@ -469,6 +410,10 @@ func (theme *theme) Append(anotherTheme Theme) {
 	}
 
 	another := anotherTheme.data()
+	if another.extends != "" {
+		theme.extends = another.extends
+	}
+
 	for tag, constant := range another.constants {
 		theme.constants[tag] = constant
 	}
@@ -500,9 +445,7 @@ func (theme *theme) Append(anotherTheme Theme) {
 	for _, anotherMedia := range another.mediaStyles {
 		exists := false
 		for _, media := range theme.mediaStyles {
-			if anotherMedia.maxHeight == media.maxHeight &&
-				anotherMedia.maxWidth == media.maxWidth &&
-				anotherMedia.orientation == media.orientation {
+			if anotherMedia.MediaRule == media.MediaRule {
 				for tag, style := range anotherMedia.styles {
 					media.styles[tag] = style
 				}
@@ -511,9 +454,58 @@ func (theme *theme) Append(anotherTheme Theme) {
 			}
 		}
 		if !exists {
-			theme.mediaStyles = append(theme.mediaStyles, anotherMedia)
+			media := mediaStyle{MediaRule: anotherMedia.MediaRule, styles: map[string]ViewStyle{}}
+			for tag, style := range anotherMedia.styles {
+				media.styles[tag] = style
+			}
+			theme.mediaStyles = append(theme.mediaStyles, media)
 		}
 	}
+	theme.sortMediaStyles()
+}
+
+// baseThemes returns the chain of the themes extended by the theme beginning from the most basic one.
+// The default theme is not included in the result
+func baseThemes(theme Theme) []Theme {
+	result := []Theme{}
+	names := []string{theme.Name()}
+	for {
+		name := theme.Extends()
+		if name == "" || name == "default" {
+			return result
+		}
+
+		for _, n := range names {
+			if n == name {
+				ErrorLogF(`The inheritance of the "%s" theme is cyclic`, name)
+				return result
+			}
+		}
+		names = append(names, name)
+
+		base, ok := getTheme(name)
+		if !ok {
+			ErrorLogF(`The base theme "%s" not found`, name)
+			return result
+		}
+
+		result = append([]Theme{base}, result...)
+		theme = base
+	}
+}
+
+// mergedTheme returns the new theme which contains the theme together with the themes it extends.
+// If withDefault is true then the default theme is the base of the result
+func mergedTheme(theme Theme, withDefault bool) Theme {
+	result := NewTheme("")
+	if withDefault {
+		result.Append(getDefaultTheme())
+	}
+	for _, base := range baseThemes(theme) {
+		result.Append(base)
+	}
+	result.Append(theme)
+	return result
 }
 
 func (theme *theme) cssText(session Session) string {
@@ -544,12 +536,25 @@ func (theme *theme) cssText(session Session) string {
 	return builder.finish()
 }
 
-func (theme *theme) addText(themeText string) bool {
+func (theme *theme) addText(themeText string) error {
+	data, err := ParseDataTextWithError(themeText)
+	if err != nil {
+		return err
+	}
+	if data.Tag() != "theme" {
+		return fmt.Errorf(`invalid theme tag "%s", the "theme" tag is expected`, data.Tag())
+	}
+	if !theme.addData(data) {
+		return errors.New("invalid theme data")
+	}
+	return nil
+}
+
+func (theme *theme) addData(data DataObject) bool {
 	if theme.constants == nil {
 		theme.init()
 	}
 
-	data := ParseDataText(themeText)
 	if data == nil || !data.IsObject() || data.Tag() != "theme" {
 		return false
 	}
@@ -578,6 +583,16 @@ func (theme *theme) addText(themeText string) bool {
 	for i := 0; i < count; i++ {
 		if d := data.Property(i); d != nil {
 			switch tag := d.Tag(); tag {
+			case "name":
+				if d.Type() == TextNode {
+					theme.name = d.Text()
+				}
+
+			case "extends":
+				if d.Type() == TextNode {
+					theme.extends = d.Text()
+				}
+
 			case "constants":
 				if d.Type() == ObjectNode {
 					if obj := d.Object(); obj != nil {
@@ -687,13 +702,7 @@ func (theme *theme) addText(themeText string) bool {
 func (theme *theme) sortMediaStyles() {
 	if len(theme.mediaStyles) > 1 {
 		sort.SliceStable(theme.mediaStyles, func(i, j int) bool {
-			if theme.mediaStyles[i].orientation != theme.mediaStyles[j].orientation {
-				return theme.mediaStyles[i].orientation < theme.mediaStyles[j].orientation
-			}
-			if theme.mediaStyles[i].maxWidth != theme.mediaStyles[j].maxWidth {
-				return theme.mediaStyles[i].maxWidth < theme.mediaStyles[j].maxWidth
-			}
-			return theme.mediaStyles[i].maxHeight < theme.mediaStyles[j].maxHeight
+			return mediaRuleLess(theme.mediaStyles[i].MediaRule, theme.mediaStyles[j].MediaRule)
 		})
 	}
 }
@@ -804,6 +813,16 @@ func (theme *theme) String() string {
 	}
 
 	buffer.WriteString("theme {\n")
+	if theme.name != "" {
+		buffer.WriteString("\tname = ")
+		writeString(theme.name)
+		buffer.WriteString(",\n")
+	}
+	if theme.extends != "" {
+		buffer.WriteString("\textends = ")
+		writeString(theme.extends)
+		buffer.WriteString(",\n")
+	}
 	writeConstants("colors", theme.colors)
 	writeConstants("colors:dark", theme.darkColors)
 	writeConstants("images", theme.images)
@@ -811,7 +830,7 @@ func (theme *theme) String() string {
 	writeConstants("constants", theme.constants)
 	writeConstants("constants:touch", theme.touchConstants)
 
-	writeStyles := func(orientation, maxWidth, maxHeihgt int, styles map[string]ViewStyle) bool {
+	writeStyles := func(rule MediaRule, styles map[string]ViewStyle) bool {
 		count := len(styles)
 		if count == 0 {
 			return false
@@ -823,20 +842,8 @@ func (theme *theme) String() string {
 		}
 		sort.Strings(tags)
 
-		buffer.WriteString("\tstyles")
-		switch orientation {
-		case PortraitMedia:
-			buffer.WriteString(":portrait")
-
-		case LandscapeMedia:
-			buffer.WriteString(":landscape")
-		}
-		if maxWidth > 0 {
-			buffer.WriteString(fmt.Sprintf(":width%d", maxWidth))
-		}
-		if maxHeihgt > 0 {
-			buffer.WriteString(fmt.Sprintf(":heihgt%d", maxHeihgt))
-		}
+		buffer.WriteString("\t")
+		buffer.WriteString(rule.sectionName())
 		buffer.WriteString(" = [\n")
 
 		for _, tag := range tags {
@@ -850,9 +857,9 @@ func (theme *theme) String() string {
 		return true
 	}
 
-	writeStyles(0, 0, 0, theme.styles)
+	writeStyles(MediaRule{}, theme.styles)
 	for _, media := range theme.mediaStyles {
-		writeStyles(media.orientation, media.maxWidth, media.maxHeight, media.styles)
+		writeStyles(media.MediaRule, media.styles)
 	}
 
 	buffer.WriteString("}\n")
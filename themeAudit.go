@@ -0,0 +1,257 @@
+package rui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MinContrastRatio is the minimal contrast ratio of a normal text required by WCAG 2 level AA
+	MinContrastRatio = 4.5
+	// MinLargeTextContrastRatio is the minimal contrast ratio of a large text (at least 18pt or 14pt bold)
+	// required by WCAG 2 level AA
+	MinLargeTextContrastRatio = 3
+)
+
+// ContrastViolation describes a pair of the text and background colors of a theme style
+// whose contrast ratio is less than required by WCAG 2 level AA
+type ContrastViolation struct {
+	// Style is the tag of the style, for example "ruiButton:hover"
+	Style string
+	// Rule is the media rule of the style section (the zero value for the main "styles" section)
+	Rule MediaRule
+	// Dark is true if the colors of the dark theme are used
+	Dark bool
+	// TextColor is the value of the "text-color" property, for example "@ruiTextColor"
+	TextColor string
+	// BackgroundColor is the value of the "background-color" property, for example "@ruiBackgroundColor"
+	BackgroundColor string
+	// Text is the resolved text color
+	Text Color
+	// Background is the resolved background color
+	Background Color
+	// Ratio is the contrast ratio of the text and background colors
+	Ratio float64
+	// MinRatio is the required contrast ratio: MinContrastRatio or MinLargeTextContrastRatio
+	MinRatio float64
+}
+
+// String returns the text description of the violation
+func (violation ContrastViolation) String() string {
+	mode := "light"
+	if violation.Dark {
+		mode = "dark"
+	}
+	colorText := func(value string, color Color) string {
+		if text := color.String(); text != value {
+			return value + " (" + text + ")"
+		}
+		return value
+	}
+	return fmt.Sprintf(`"%s" style (%s, %s): text color %s on background %s, contrast ratio %.2f, required %.1f`,
+		violation.Style, violation.Rule.sectionName(), mode,
+		colorText(violation.TextColor, violation.Text), colorText(violation.BackgroundColor, violation.Background),
+		violation.Ratio, violation.MinRatio)
+}
+
+// AuditThemeContrast checks the contrast of the text and background colors of the theme styles
+// (including the styles of media sections) with both the light and the dark colors and returns the list
+// of violations of WCAG 2 level AA. The theme is checked together with the default theme and the themes
+// it extends. A style is checked if it sets "text-color" or "background-color" property, the missing
+// property is taken from the base style ("ruiButton" for "ruiButton:hover") or the "ruiApp" style.
+// The styles whose tags contain "disabled" are skipped, because WCAG does not require the contrast
+// of inactive components
+func AuditThemeContrast(theme Theme) []ContrastViolation {
+	result := []ContrastViolation{}
+	if theme == nil {
+		return result
+	}
+
+	merged := theme
+	if theme != getDefaultTheme() {
+		merged = mergedTheme(theme, true)
+	}
+
+	data := merged.data()
+	result = auditStylesContrast(merged, MediaRule{}, nil, data.styles, result)
+	for _, media := range data.mediaStyles {
+		result = auditStylesContrast(merged, media.MediaRule, media.styles, data.styles, result)
+	}
+	return result
+}
+
+// auditStylesContrast checks the styles of the section. If "media" is nil then the main section is checked
+func auditStylesContrast(theme Theme, rule MediaRule, media, styles map[string]ViewStyle, result []ContrastViolation) []ContrastViolation {
+	section := styles
+	if media != nil {
+		section = media
+	}
+
+	// lookup returns the value of the property according to the cascade of the styles
+	lookup := func(tag, property string) interface{} {
+		tags := []string{tag}
+		if n := strings.IndexRune(tag, ':'); n > 0 {
+			tags = append(tags, tag[:n])
+		}
+		tags = append(tags, "ruiApp")
+
+		for _, t := range tags {
+			for _, s := range []map[string]ViewStyle{media, styles} {
+				if style, ok := s[t]; ok {
+					if value := style.Get(property); value != nil {
+						return value
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, tag := range sortedStyleTags(section) {
+		style := section[tag]
+		if strings.Contains(strings.ToLower(tag), "disabled") ||
+			(style.Get(TextColor) == nil && style.Get(BackgroundColor) == nil) {
+			continue
+		}
+
+		textValue := lookup(tag, TextColor)
+		backgroundValue := lookup(tag, BackgroundColor)
+		if textValue == nil || backgroundValue == nil {
+			continue
+		}
+
+		minRatio := MinContrastRatio
+		if auditLargeText(theme, lookup(tag, TextSize), lookup(tag, TextWeight)) {
+			minRatio = MinLargeTextContrastRatio
+		}
+
+		for _, dark := range []bool{false, true} {
+			text, ok := auditColor(theme, textValue, dark)
+			if !ok {
+				continue
+			}
+			background, ok := auditColor(theme, backgroundValue, dark)
+			if !ok {
+				continue
+			}
+
+			if background.Alpha() < 255 {
+				page, err := themeColor(theme, "ruiBackgroundColor", dark)
+				if err != nil {
+					page = 0xFFFFFFFF
+				}
+				background = page.Blend(background.WithAlpha(1), float64(background.Alpha())/255)
+			}
+			if text.Alpha() < 255 {
+				text = background.Blend(text.WithAlpha(1), float64(text.Alpha())/255)
+			}
+
+			if ratio := text.ContrastRatio(background); ratio < minRatio {
+				result = append(result, ContrastViolation{
+					Style:           tag,
+					Rule:            rule,
+					Dark:            dark,
+					TextColor:       auditValueText(textValue),
+					BackgroundColor: auditValueText(backgroundValue),
+					Text:            text,
+					Background:      background,
+					Ratio:           ratio,
+					MinRatio:        minRatio,
+				})
+			}
+		}
+	}
+	return result
+}
+
+func sortedStyleTags(styles map[string]ViewStyle) []string {
+	tags := make([]string, 0, len(styles))
+	for tag := range styles {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func auditValueText(value interface{}) string {
+	if color, ok := value.(Color); ok {
+		return color.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// auditColor returns the color of the style property value
+func auditColor(theme Theme, value interface{}, dark bool) (Color, bool) {
+	switch value := value.(type) {
+	case Color:
+		return value, true
+
+	case string:
+		if len(value) > 1 && value[0] == '@' {
+			color, err := themeColor(theme, value[1:], dark)
+			if err != nil {
+				ErrorLog(err.Error())
+				return 0, false
+			}
+			return color, true
+		}
+		if color, err := stringToColor(value); err == nil {
+			return color, true
+		}
+	}
+	return 0, false
+}
+
+// auditLargeText returns true if the text is large according to WCAG: at least 18pt (24px) or 14pt (18.66px) bold
+func auditLargeText(theme Theme, size, weight interface{}) bool {
+	if text, ok := size.(string); ok && len(text) > 1 && text[0] == '@' {
+		size, _ = themeSizeConstant(theme, text[1:], false)
+	}
+
+	sizeUnit, ok := size.(SizeUnit)
+	if !ok {
+		return false
+	}
+
+	var px float64
+	switch sizeUnit.Type {
+	case SizeInPixel:
+		px = sizeUnit.Value
+	case SizeInPt:
+		px = sizeUnit.Value * 4 / 3
+	case SizeInPc:
+		px = sizeUnit.Value * 16
+	case SizeInEM:
+		px = sizeUnit.Value * 16
+	case SizeInPercent:
+		px = sizeUnit.Value * 16 / 100
+	case SizeInInch:
+		px = sizeUnit.Value * 96
+	case SizeInMM:
+		px = sizeUnit.Value * 96 / 25.4
+	case SizeInCM:
+		px = sizeUnit.Value * 96 / 2.54
+	default:
+		return false
+	}
+
+	if px >= 24 {
+		return true
+	}
+
+	bold := false
+	switch weight := weight.(type) {
+	case int:
+		bold = weight >= 7
+
+	case string:
+		if len(weight) > 1 && weight[0] == '@' {
+			weight = theme.constant(weight[1:], false)
+		}
+		if n, ok := enumStringToInt(weight, enumProperties[TextWeight].values, false); ok {
+			bold = n >= 7
+		}
+	}
+	return bold && px >= 18.66
+}
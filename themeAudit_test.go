@@ -0,0 +1,70 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditThemeContrast(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		colors = _{
+			myText = #FF767676,
+			myBackground = #FFFFFFFF,
+		},
+		colors:dark = _{
+			myText = #FF303030,
+			myBackground = #FF000000,
+		},
+		styles = [
+			goodStyle { text-color = @myText, background-color = @myBackground },
+			lowStyle { text-color = #FFAAAAAA },
+			largeStyle { text-color = #FF949494, text-size = 18pt },
+			boldStyle { text-color = #FF949494, text-size = 14pt, text-weight = bold },
+			myDisabledStyle { text-color = #FFEEEEEE },
+			lowStyle:hover { background-color = #FFAAAAAA },
+		],
+		styles:width600 = [
+			goodStyle { text-color = #FFD0D0D0 },
+		],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		style string
+		rule  MediaRule
+		dark  bool
+	}{
+		{"goodStyle", MediaRule{}, true},
+		{"lowStyle", MediaRule{}, false},
+		{"lowStyle:hover", MediaRule{}, false},
+		{"lowStyle:hover", MediaRule{}, true},
+		{"goodStyle", MediaRule{MaxWidth: 600}, false},
+	}
+
+	violations := []ContrastViolation{}
+	for _, violation := range AuditThemeContrast(theme) {
+		if !strings.HasPrefix(violation.Style, "rui") {
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) != len(expected) {
+		for _, violation := range violations {
+			t.Log(violation.String())
+		}
+		t.Fatalf("%d violations found, expected %d", len(violations), len(expected))
+	}
+
+	for i, violation := range violations {
+		if violation.Style != expected[i].style || violation.Rule != expected[i].rule || violation.Dark != expected[i].dark {
+			t.Errorf("Unexpected violation: %s", violation.String())
+		}
+		if violation.Ratio >= violation.MinRatio || violation.MinRatio != MinContrastRatio {
+			t.Errorf("Invalid ratio: %s", violation.String())
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package rui
+
+import "strings"
+
+// ThemeEditor - the view which allows to edit the colors and the constants of the current theme of the session.
+// The changes are applied to the session immediately through the theme CSS
+type ThemeEditor interface {
+	CustomView
+	// Theme returns the edited theme: the copy of the session custom theme (or of the default theme) with the changes
+	Theme() Theme
+	// Reset discards all changes and restores the session theme
+	Reset()
+	// Save starts downloading of the edited theme as a ".rui" file with the given name on the client side.
+	// If the session has no custom theme then only the changed colors and constants are saved
+	Save(filename string)
+}
+
+type themeEditorData struct {
+	CustomViewData
+	session  Session
+	original Theme
+	theme    Theme
+	changes  Theme
+	pickers  map[string]ColorPicker
+	editors  map[string]EditView
+	updating bool
+}
+
+// NewThemeEditor create new ThemeEditor object and return it
+func NewThemeEditor(session Session, params Params) ThemeEditor {
+	editor := new(themeEditorData)
+	editor.session = session
+	editor.original = session.getCustomTheme()
+	editor.theme = editor.copyOriginal()
+	editor.changes = NewTheme(editor.theme.Name())
+	InitCustomView(editor, "ThemeEditor", session, params)
+	return editor
+}
+
+func newThemeEditor(session Session) View {
+	return NewThemeEditor(session, nil)
+}
+
+// copyOriginal returns the copy of the theme which is edited
+func (editor *themeEditorData) copyOriginal() Theme {
+	source := editor.original
+	if source == nil {
+		source = getDefaultTheme()
+	}
+	result := NewTheme(source.Name())
+	result.Append(source)
+	return result
+}
+
+func (editor *themeEditorData) CreateSuperView(session Session) View {
+	editor.pickers = map[string]ColorPicker{}
+	editor.editors = map[string]EditView{}
+
+	// the listeners are called when the initial values are set
+	editor.updating = true
+	defer func() { editor.updating = false }()
+
+	current := session.getCurrentTheme()
+
+	colors := []View{}
+	for i, tag := range current.ColorTags() {
+		color, _ := session.Color(tag)
+		picker := NewColorPicker(session, Params{
+			Row:               i,
+			Column:            1,
+			ColorPickerValue:  color,
+			ColorChangedEvent: editor.colorChanged(tag),
+		})
+		editor.pickers[tag] = picker
+		colors = append(colors, NewTextView(session, Params{Row: i, Column: 0, Text: tag}), picker)
+	}
+
+	constants := []View{}
+	for i, tag := range current.ConstantTags() {
+		edit := NewEditView(session, Params{
+			Row:            i,
+			Column:         1,
+			Text:           current.constant(tag, session.TouchScreen()),
+			LostFocusEvent: editor.constantChanged(tag),
+			KeyDownEvent:   editor.constantKeyDown(tag),
+		})
+		editor.editors[tag] = edit
+		constants = append(constants, NewTextView(session, Params{Row: i, Column: 0, Text: tag}), edit)
+	}
+
+	section := func(title string, content []View) []View {
+		return []View{
+			NewTextView(session, Params{Text: title, Semantics: H3Semantics}),
+			NewGridLayout(session, Params{
+				CellWidth:     []SizeUnit{AutoSize(), Fr(1)},
+				GridRowGap:    Px(4),
+				GridColumnGap: Px(8),
+				Content:       content,
+			}),
+		}
+	}
+
+	content := []View{
+		NewListLayout(session, Params{
+			Orientation: StartToEndOrientation,
+			Content: []View{
+				NewButton(session, Params{Content: "Reset", ClickEvent: func(View) { editor.Reset() }}),
+				NewButton(session, Params{Content: "Save", ClickEvent: func(View) { editor.Save("") }}),
+			},
+		}),
+	}
+	content = append(content, section("Colors", colors)...)
+	content = append(content, section("Constants", constants)...)
+
+	return NewListLayout(session, Params{
+		Orientation: TopDownOrientation,
+		Content:     content,
+	})
+}
+
+// colorChanged returns the listener of the color picker of the theme color. The dark color is changed if
+// the session uses the dark theme
+func (editor *themeEditorData) colorChanged(tag string) func(ColorPicker, Color) {
+	return func(picker ColorPicker, color Color) {
+		if editor.updating {
+			return
+		}
+
+		// the values are taken from the merged theme because the color can be inherited from the base or the default theme
+		light, dark := editor.session.getCurrentTheme().Color(tag)
+		if editor.session.DarkTheme() {
+			if light == "" {
+				light = color.String()
+			}
+			dark = color.String()
+		} else {
+			light = color.String()
+		}
+		editor.theme.SetColor(tag, light, dark)
+		editor.changes.SetColor(tag, light, dark)
+		editor.session.previewTheme(editor.theme)
+	}
+}
+
+// constantChanged returns the listener of the editor of the theme constant. The text is applied when
+// the editor loses the focus or Enter is pressed. The touch screen constant is changed if the session uses the touch screen
+func (editor *themeEditorData) constantChanged(tag string) func(View) {
+	return func(View) {
+		if !editor.updating {
+			editor.applyConstant(tag)
+		}
+	}
+}
+
+func (editor *themeEditorData) constantKeyDown(tag string) func(View, KeyEvent) {
+	return func(_ View, event KeyEvent) {
+		if !editor.updating && (event.Code == "Enter" || event.Code == "NumpadEnter") {
+			editor.applyConstant(tag)
+		}
+	}
+}
+
+func (editor *themeEditorData) applyConstant(tag string) {
+	edit, ok := editor.editors[tag]
+	if !ok {
+		return
+	}
+
+	touchScreen := editor.session.TouchScreen()
+	current := editor.session.getCurrentTheme().constant(tag, touchScreen)
+	text := strings.Trim(GetText(edit, ""), " \t\n\r")
+	if text == current {
+		return
+	}
+
+	if text == "" || !isThemeConstantValue(text, current) {
+		ErrorLogF(`Invalid value "%s" of the "%s" constant`, text, tag)
+		editor.updating = true
+		edit.Set(Text, current)
+		editor.updating = false
+		return
+	}
+
+	value, touch := editor.session.getCurrentTheme().Constant(tag)
+	if touchScreen {
+		if value == "" {
+			value = text
+		}
+		touch = text
+	} else {
+		value = text
+	}
+	editor.theme.SetConstant(tag, value, touch)
+	editor.changes.SetConstant(tag, value, touch)
+	editor.session.previewTheme(editor.theme)
+}
+
+// isThemeConstantValue returns true if the text can replace the value of the constant: the size constant
+// accepts only a size or a reference to another constant
+func isThemeConstantValue(text, current string) bool {
+	if current == "" || current[0] == '@' {
+		return true
+	}
+	if _, err := stringToSizeUnit(current); err != nil {
+		return true
+	}
+	if text[0] == '@' {
+		return true
+	}
+	_, err := stringToSizeUnit(text)
+	return err == nil
+}
+
+func (editor *themeEditorData) Theme() Theme {
+	return editor.theme
+}
+
+func (editor *themeEditorData) Reset() {
+	editor.theme = editor.copyOriginal()
+	editor.changes = NewTheme(editor.theme.Name())
+	editor.session.previewTheme(editor.original)
+
+	editor.updating = true
+	defer func() { editor.updating = false }()
+
+	current := editor.session.getCurrentTheme()
+	for tag, picker := range editor.pickers {
+		if color, ok := editor.session.Color(tag); ok {
+			picker.Set(ColorPickerValue, color)
+		}
+	}
+	for tag, edit := range editor.editors {
+		edit.Set(Text, current.constant(tag, editor.session.TouchScreen()))
+	}
+}
+
+func (editor *themeEditorData) Save(filename string) {
+	if filename == "" {
+		filename = "theme.rui"
+	}
+	theme := editor.theme
+	if editor.original == nil {
+		// the copy of the default theme is not saved entirely
+		theme = editor.changes
+	}
+	editor.session.DownloadFileData(filename, []byte(theme.String()))
+}
+
+// getCustomTheme returns the custom theme of the session or nil if the default theme is used
+func (session *sessionData) getCustomTheme() Theme {
+	return session.customTheme
+}
+
+// previewTheme sets the custom theme of the session without reloading the page: the page styles
+// and the styles of views are updated. If the theme is nil then the default theme is used
+func (session *sessionData) previewTheme(theme Theme) {
+	session.customTheme = theme
+	session.currentTheme = nil
+	session.updateThemeStyles()
+
+	if !session.themeVariables && !session.ignoreViewUpdates() {
+		// the values of the theme colors and constants are substituted into the styles of views
+		buffer := allocStringBuilder()
+		defer freeStringBuilder(buffer)
+
+		if session.rootView != nil {
+			writeViewsStyleScript(session.rootView, buffer)
+		}
+		if session.popups != nil {
+			for _, popup := range session.popups.popups {
+				writeViewsStyleScript(popup.rootView(), buffer)
+			}
+		}
+		session.runScript(buffer.String())
+	}
+}
@@ -0,0 +1,130 @@
+package rui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestThemeEditor(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	theme := NewTheme("test")
+	theme.SetColor("primary", "#FF102030", "#FF405060")
+	theme.SetConstant("gap", "8px", "12px")
+	session.customTheme = theme
+
+	editor := NewThemeEditor(session, nil)
+	session.rootView = editor
+
+	editorData := editor.(*themeEditorData)
+	primary, ok := editorData.pickers["primary"]
+	if !ok {
+		t.Fatal(`The color picker of "primary" is not created`)
+	}
+	if color := GetColorPickerValue(primary, ""); color != 0xFF102030 {
+		t.Errorf(`The initial color of "primary" picker: %s`, color.String())
+	}
+
+	primary.Set(ColorPickerValue, Color(0xFF708090))
+	if light, dark := editor.Theme().Color("primary"); light != "#FF708090" || dark != "#FF405060" {
+		t.Errorf(`Theme().Color("primary") = %s, %s`, light, dark)
+	}
+	if color, _ := session.Color("primary"); color != 0xFF708090 {
+		t.Errorf(`The session color "primary" is not updated: %s`, color.String())
+	}
+	if light, _ := theme.Color("primary"); light != "#FF102030" {
+		t.Error("The original theme is changed")
+	}
+
+	gap := editorData.editors["gap"]
+	gap.Set(Text, "10p")
+	if value, _ := editor.Theme().Constant("gap"); value != "8px" {
+		t.Errorf(`Theme().Constant("gap") is changed before the commit: %s`, value)
+	}
+	ignoreTestLog = true
+	editorData.constantChanged("gap")(gap)
+	ignoreTestLog = false
+	if value, _ := editor.Theme().Constant("gap"); value != "8px" {
+		t.Errorf(`The invalid value of "gap" is applied: %s`, value)
+	}
+	if text := GetText(gap, ""); text != "8px" {
+		t.Errorf(`The text of the invalid "gap" value is not restored: %s`, text)
+	}
+
+	gap.Set(Text, "10px")
+	editorData.constantKeyDown("gap")(gap, KeyEvent{Key: "Enter", Code: "Enter"})
+	if value, touch := editor.Theme().Constant("gap"); value != "10px" || touch != "12px" {
+		t.Errorf(`Theme().Constant("gap") = %s, %s`, value, touch)
+	}
+	if !strings.Contains(editor.Theme().String(), "gap") {
+		t.Error(`The text of the theme does not contain "gap" constant`)
+	}
+
+	editor.Reset()
+	if color, _ := session.Color("primary"); color != 0xFF102030 {
+		t.Errorf(`The session color "primary" is not restored: %s`, color.String())
+	}
+	if color := GetColorPickerValue(primary, ""); color != 0xFF102030 {
+		t.Errorf(`The color of "primary" picker is not restored: %s`, color.String())
+	}
+	if light, _ := editor.Theme().Color("primary"); light != "#FF102030" {
+		t.Errorf(`Theme().Color("primary") after Reset = %s`, light)
+	}
+}
+
+func TestThemeEditorSave(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	session.brige = new(testBrige)
+	editor := NewThemeEditor(session, nil)
+	session.rootView = editor
+
+	editorData := editor.(*themeEditorData)
+	editorData.pickers["ruiTextColor"].Set(ColorPickerValue, Color(0xFF202020))
+
+	editor.Save("")
+	file, ok := downloadFiles[strconv.Itoa(currentDownloadId)]
+	if !ok {
+		t.Fatal("The theme file is not downloaded")
+	}
+	delete(downloadFiles, strconv.Itoa(currentDownloadId))
+
+	text := string(file.data)
+	if !strings.Contains(text, "ruiTextColor") {
+		t.Errorf("The saved theme does not contain the changed color:\n%s", text)
+	}
+	if strings.Contains(text, "ruiBackgroundColor") || strings.Contains(text, "ruiButton") {
+		t.Errorf("The saved theme contains the unchanged default theme:\n%s", text)
+	}
+}
+
+func TestThemeEditorInheritedValues(t *testing.T) {
+	createTestLog(t, false)
+
+	session := new(sessionData)
+	session.darkTheme = true
+	session.touchScreen = true
+	theme := NewTheme("test")
+	theme.SetColor("primary", "#FF102030", "")
+	session.customTheme = theme
+
+	editor := NewThemeEditor(session, nil)
+	session.rootView = editor
+	editorData := editor.(*themeEditorData)
+
+	// the light color and the normal constant inherited from the default theme are kept
+	editorData.pickers["ruiTextColor"].Set(ColorPickerValue, Color(0xFFC0C0C0))
+	if light, dark := editor.Theme().Color("ruiTextColor"); light != "#FF000000" || dark != "#FFC0C0C0" {
+		t.Errorf(`Theme().Color("ruiTextColor") = %s, %s`, light, dark)
+	}
+
+	padding := editorData.editors["ruiButtonHorizontalPadding"]
+	padding.Set(Text, "24px")
+	editorData.constantChanged("ruiButtonHorizontalPadding")(padding)
+	if value, touch := editor.Theme().Constant("ruiButtonHorizontalPadding"); value != "16px" || touch != "24px" {
+		t.Errorf(`Theme().Constant("ruiButtonHorizontalPadding") = %s, %s`, value, touch)
+	}
+}
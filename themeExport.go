@@ -0,0 +1,391 @@
+package rui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// tokenColorGroup is the group of the color tokens of the design-token JSON
+	tokenColorGroup = "color"
+	// tokenDimensionGroup is the group of the size constant tokens of the design-token JSON
+	tokenDimensionGroup = "dimension"
+	// tokenExtension is the key of the "$extensions" object that contains the dark and touch screen values
+	tokenExtension = "rui"
+)
+
+// ExportThemeCSS returns the standalone CSS of the theme (together with the default theme and the themes it extends).
+// The colors and size constants are defined as CSS custom properties of the ":root" rule, the dark colors
+// are applied by the "prefers-color-scheme: dark" media query and the touch screen constants by the
+// "pointer: coarse" media query. The styles are output as classes with the names of the styles,
+// the media styles are output as @media rules
+func ExportThemeCSS(theme Theme) string {
+	session := new(sessionData)
+	if theme != getDefaultTheme() {
+		session.customTheme = theme
+	}
+	session.themeVariables = true
+	current := session.getCurrentTheme()
+
+	main, dark, touch := themeVariables(current, "\t\t")
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString(":root {\n")
+	buffer.WriteString(strings.ReplaceAll(main, "\t\t", "\t"))
+	buffer.WriteString("}\n")
+	if dark != "" {
+		buffer.WriteString("@media (prefers-color-scheme: dark) {\n\t:root {\n")
+		buffer.WriteString(dark)
+		buffer.WriteString("\t}\n}\n")
+	}
+	if touch != "" {
+		buffer.WriteString("@media (pointer: coarse) {\n\t:root {\n")
+		buffer.WriteString(touch)
+		buffer.WriteString("\t}\n}\n")
+	}
+
+	var builder cssStyleBuilder
+	builder.init()
+
+	writeStyles := func(styles map[string]ViewStyle) {
+		for _, tag := range sortedStyleTags(styles) {
+			builder.startStyle(tag)
+			styles[tag].cssViewStyle(&builder, session)
+			builder.endStyle()
+		}
+	}
+
+	data := current.data()
+	writeStyles(data.styles)
+	for _, media := range data.mediaStyles {
+		builder.startMedia(media.cssText())
+		writeStyles(media.styles)
+		builder.endMedia()
+	}
+
+	// cssStyleBuilder escapes the line breaks and tabs for the embedding in a script
+	css := strings.ReplaceAll(builder.finish(), `\n`, "\n")
+	buffer.WriteString(strings.ReplaceAll(css, `\t`, "\t"))
+	return buffer.String()
+}
+
+// ExportThemeTokens returns the design-token JSON (W3C Design Tokens format) of the theme colors and
+// size constants. The themes extended by the theme are exported too, the default theme is exported only
+// if it is the argument. The colors are placed in the "color" group, the size constants in the "dimension" group.
+// The references to other exported constants are output as aliases ("{color.name}"). The dark colors and
+// the touch screen constants are placed in the "$extensions" object of the token: {"rui": {"dark": "#101010"}}
+func ExportThemeTokens(theme Theme) ([]byte, error) {
+	if theme == nil {
+		return nil, errors.New("the theme is nil")
+	}
+
+	full := mergedTheme(theme, true)
+	exported := full
+	if theme != getDefaultTheme() {
+		exported = mergedTheme(theme, false)
+	}
+	data := exported.data()
+
+	tokenValue := func(group, value string, resolved interface{}) interface{} {
+		if len(value) > 1 && value[0] == '@' {
+			tag := value[1:]
+			switch group {
+			case tokenColorGroup:
+				if _, ok := data.colors[tag]; ok {
+					return "{" + group + "." + tag + "}"
+				}
+			case tokenDimensionGroup:
+				if _, ok := data.constants[tag]; ok {
+					return "{" + group + "." + tag + "}"
+				}
+			}
+		}
+		return resolved
+	}
+
+	colors := map[string]interface{}{"$type": "color"}
+	for _, tag := range exported.ColorTags() {
+		light, err := themeColor(full, tag, false)
+		if err != nil {
+			return nil, err
+		}
+
+		token := map[string]interface{}{
+			"$value": tokenValue(tokenColorGroup, data.colors[tag], colorTokenHex(light)),
+		}
+
+		dark, err := themeColor(full, tag, true)
+		if err != nil {
+			return nil, err
+		}
+		// the dark value of an alias without its own dark value is defined by the referenced token
+		if value, ok := data.darkColors[tag]; ok {
+			token["$extensions"] = map[string]interface{}{
+				tokenExtension: map[string]interface{}{"dark": tokenValue(tokenColorGroup, value, colorTokenHex(dark))},
+			}
+		} else if dark != light && token["$value"] == colorTokenHex(light) {
+			token["$extensions"] = map[string]interface{}{
+				tokenExtension: map[string]interface{}{"dark": colorTokenHex(dark)},
+			}
+		}
+		colors[tag] = token
+	}
+
+	dimensions := map[string]interface{}{"$type": "dimension"}
+	for _, tag := range exported.ConstantTags() {
+		size, ok := themeSizeConstant(full, tag, false)
+		if !ok {
+			continue
+		}
+
+		token := map[string]interface{}{
+			"$value": tokenValue(tokenDimensionGroup, data.constants[tag], size.String()),
+		}
+		if value, ok := data.touchConstants[tag]; ok {
+			if touch, ok := themeSizeConstant(full, tag, true); ok {
+				token["$extensions"] = map[string]interface{}{
+					tokenExtension: map[string]interface{}{"touch": tokenValue(tokenDimensionGroup, value, touch.String())},
+				}
+			}
+		}
+		dimensions[tag] = token
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		tokenColorGroup:     colors,
+		tokenDimensionGroup: dimensions,
+	}, "", "  ")
+}
+
+// colorTokenHex returns the color in the "#rrggbb" or "#rrggbbaa" format
+func colorTokenHex(color Color) string {
+	if alpha := color.Alpha(); alpha < 255 {
+		return fmt.Sprintf("#%06x%02x", int(color&0xFFFFFF), alpha)
+	}
+	return fmt.Sprintf("#%06x", int(color&0xFFFFFF))
+}
+
+// CreateThemeFromTokens creates the theme with the given name from the design-token JSON (W3C Design Tokens format).
+// The tokens of "color" type are added as colors (SetColor), the tokens of "dimension", "number", and "fontFamily"
+// types as constants (SetConstant), the tokens of other types are ignored. The name of a constant is the path
+// of the token in the groups joined by "-", the top-level "color" and "dimension" groups are not included in the name.
+// Aliases ("{color.primary}") are converted to references ("@primary"). The dark colors and the touch screen
+// constants are read from the "$extensions" object: {"rui": {"dark": "#101010", "touch": "20px"}}
+func CreateThemeFromTokens(name string, data []byte) (Theme, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	theme := NewTheme(name)
+	if err := importTokenGroup(theme, root, []string{}, ""); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+// tokenName returns the name of the theme constant for the path of the token
+func tokenName(path []string) string {
+	if len(path) > 1 && (path[0] == tokenColorGroup || path[0] == tokenDimensionGroup) {
+		path = path[1:]
+	}
+	return strings.Join(path, "-")
+}
+
+func importTokenGroup(theme Theme, group map[string]interface{}, path []string, groupType string) error {
+	if value, ok := group["$type"].(string); ok {
+		groupType = value
+	}
+
+	keys := make([]string, 0, len(group))
+	for key := range group {
+		if !strings.HasPrefix(key, "$") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		obj, ok := group[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		tokenPath := append(append([]string{}, path...), key)
+		if _, ok := obj["$value"]; !ok {
+			if err := importTokenGroup(theme, obj, tokenPath, groupType); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := importToken(theme, obj, tokenPath, groupType); err != nil {
+			return fmt.Errorf(`invalid "%s" token: %s`, strings.Join(tokenPath, "."), err.Error())
+		}
+	}
+	return nil
+}
+
+func importToken(theme Theme, token map[string]interface{}, path []string, tokenType string) error {
+	if value, ok := token["$type"].(string); ok {
+		tokenType = value
+	}
+
+	var convert func(value interface{}) (string, error)
+	switch tokenType {
+	case "color":
+		convert = colorTokenValue
+
+	case "dimension":
+		convert = dimensionTokenValue
+
+	case "number":
+		convert = func(value interface{}) (string, error) {
+			if n, ok := value.(float64); ok {
+				return fmt.Sprint(n), nil
+			}
+			return "", errors.New("a number is expected")
+		}
+
+	case "fontFamily":
+		convert = func(value interface{}) (string, error) {
+			switch value := value.(type) {
+			case string:
+				return value, nil
+
+			case []interface{}:
+				names := make([]string, 0, len(value))
+				for _, name := range value {
+					if text, ok := name.(string); ok {
+						names = append(names, text)
+					}
+				}
+				return strings.Join(names, ", "), nil
+			}
+			return "", errors.New("a font name is expected")
+		}
+
+	default:
+		return nil
+	}
+
+	aliasOrValue := func(value interface{}) (string, error) {
+		if text, ok := value.(string); ok && len(text) > 2 && text[0] == '{' && text[len(text)-1] == '}' {
+			return "@" + tokenName(strings.Split(text[1:len(text)-1], ".")), nil
+		}
+		return convert(value)
+	}
+
+	value, err := aliasOrValue(token["$value"])
+	if err != nil {
+		return err
+	}
+
+	extension := map[string]interface{}{}
+	if extensions, ok := token["$extensions"].(map[string]interface{}); ok {
+		if obj, ok := extensions[tokenExtension].(map[string]interface{}); ok {
+			extension = obj
+		}
+	}
+
+	name := tokenName(path)
+	if tokenType == "color" {
+		dark := ""
+		if value, ok := extension["dark"]; ok {
+			if dark, err = aliasOrValue(value); err != nil {
+				return err
+			}
+		}
+		theme.SetColor(name, value, dark)
+	} else {
+		touch := ""
+		if value, ok := extension["touch"]; ok {
+			if touch, err = aliasOrValue(value); err != nil {
+				return err
+			}
+		}
+		theme.SetConstant(name, value, touch)
+	}
+	return nil
+}
+
+// colorTokenValue converts the value of the color token to the text representation of Color.
+// The hex string ("#rrggbb", "#rrggbbaa") and the object with "srgb" color space are supported
+func colorTokenValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case string:
+		if strings.HasPrefix(value, "#") {
+			hex := value[1:]
+			switch len(hex) {
+			case 4:
+				hex = hex[3:] + hex[:3]
+			case 8:
+				hex = hex[6:] + hex[:6]
+			}
+			value = "#" + hex
+		}
+		color, err := stringToColor(value)
+		if err != nil {
+			return "", err
+		}
+		return color.String(), nil
+
+	case map[string]interface{}:
+		if space, ok := value["colorSpace"].(string); ok && space == "srgb" {
+			if components, ok := value["components"].([]interface{}); ok && len(components) == 3 {
+				rgb := [3]float64{}
+				for i, component := range components {
+					if n, ok := component.(float64); ok {
+						rgb[i] = n
+					} else {
+						return "", errors.New("invalid color components")
+					}
+				}
+				alpha := 1.0
+				if n, ok := value["alpha"].(float64); ok {
+					alpha = n
+				}
+				return rgbFloatColor(rgb[0], rgb[1], rgb[2], alpha).String(), nil
+			}
+		}
+		if hex, ok := value["hex"].(string); ok {
+			return colorTokenValue(hex)
+		}
+	}
+	return "", errors.New("unsupported color value")
+}
+
+// dimensionTokenValue converts the value of the dimension token to the text representation of SizeUnit.
+// The string ("4px") and the object ({"value": 4, "unit": "px"}) are supported
+func dimensionTokenValue(value interface{}) (string, error) {
+	text := ""
+	switch value := value.(type) {
+	case string:
+		text = value
+
+	case float64:
+		text = fmt.Sprintf("%gpx", value)
+
+	case map[string]interface{}:
+		n, ok := value["value"].(float64)
+		unit, ok2 := value["unit"].(string)
+		if !ok || !ok2 || math.IsNaN(n) {
+			return "", errors.New("invalid dimension value")
+		}
+		text = fmt.Sprintf("%g%s", n, unit)
+
+	default:
+		return "", errors.New("unsupported dimension value")
+	}
+
+	if _, err := stringToSizeUnit(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,141 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportTheme(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		colors = _{
+			primary = #FF1060C0,
+			primaryHover = "lighten(@primary, 10%)",
+			link = @primary,
+			shadow = #80000000,
+		},
+		colors:dark = _{
+			primary = #FF80B0F0,
+		},
+		constants = _{
+			gap = 8px,
+			bigGap = @gap,
+			font = Arial,
+		},
+		constants:touch = _{
+			gap = 12px,
+		},
+		styles = [
+			card { width = @gap, background-color = @primary },
+		],
+		styles:width600 = [
+			card { padding = 2px },
+		],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	css := ExportThemeCSS(theme)
+	for _, expected := range []string{
+		":root {\n\t--link: rgb(16,96,192);\n",
+		"--gap: 8px;",
+		"@media (prefers-color-scheme: dark) {\n\t:root {\n\t\t--link: rgb(128,176,240);\n",
+		"@media (pointer: coarse) {\n\t:root {\n",
+		"\t\t--gap: 12px;\n",
+		".card {\n\twidth: var(--gap);\n\tbackground-color: var(--primary);\n}\n",
+		"@media screen and (max-width: 600px) {\n\t.card {\n\t\tpadding: 2px;\n\t}\n}\n",
+	} {
+		if !strings.Contains(css, expected) {
+			t.Errorf("%q not found in:\n%s", expected, css)
+		}
+	}
+	if strings.Contains(css, `\n`) {
+		t.Error("CSS contains escaped line breaks")
+	}
+
+	data, err := ExportThemeTokens(theme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := CreateThemeFromTokens("imported", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	colors := []struct{ tag, light, dark string }{
+		{"primary", "#FF1060C0", "#FF80B0F0"},
+		{"primaryHover", "#FF1778EC", "#FFAECCF5"},
+		{"link", "@primary", ""},
+		{"shadow", "#80000000", ""},
+	}
+	for _, color := range colors {
+		if light, dark := imported.Color(color.tag); light != color.light || dark != color.dark {
+			t.Errorf(`"%s" color: "%s", "%s", expected "%s", "%s"`, color.tag, light, dark, color.light, color.dark)
+		}
+	}
+
+	constants := []struct{ tag, value, touch string }{
+		{"gap", "8px", "12px"},
+		{"bigGap", "@gap", ""},
+		{"font", "", ""},
+	}
+	for _, constant := range constants {
+		if value, touch := imported.Constant(constant.tag); value != constant.value || touch != constant.touch {
+			t.Errorf(`"%s" constant: "%s", "%s", expected "%s", "%s"`, constant.tag, value, touch, constant.value, constant.touch)
+		}
+	}
+}
+
+func TestCreateThemeFromTokens(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTokens("brand", []byte(`{
+		"brand": {
+			"$type": "color",
+			"primary": { "$value": "#1060c080" },
+			"accent": { "$value": { "colorSpace": "srgb", "components": [1, 0.5, 0], "alpha": 1 } },
+			"link": { "$value": "{brand.primary}" }
+		},
+		"spacing": {
+			"small": { "$type": "dimension", "$value": { "value": 0.5, "unit": "em" } },
+			"ratio": { "$type": "number", "$value": 1.5 },
+			"shadow": { "$type": "shadow", "$value": {} }
+		},
+		"font": { "$type": "fontFamily", "$value": ["Arial", "sans-serif"] }
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if theme.Name() != "brand" {
+		t.Errorf(`Name() = "%s"`, theme.Name())
+	}
+
+	for _, test := range []struct{ tag, value string }{
+		{"brand-primary", "#801060C0"},
+		{"brand-accent", "#FFFF8000"},
+		{"brand-link", "@brand-primary"},
+	} {
+		if value, _ := theme.Color(test.tag); value != test.value {
+			t.Errorf(`"%s" color = "%s", expected "%s"`, test.tag, value, test.value)
+		}
+	}
+
+	for _, test := range []struct{ tag, value string }{
+		{"spacing-small", "0.5em"},
+		{"spacing-ratio", "1.5"},
+		{"spacing-shadow", ""},
+		{"font", "Arial, sans-serif"},
+	} {
+		if value, _ := theme.Constant(test.tag); value != test.value {
+			t.Errorf(`"%s" constant = "%s", expected "%s"`, test.tag, value, test.value)
+		}
+	}
+
+	if _, err := CreateThemeFromTokens("", []byte(`{"color": {"bad": {"$type": "color", "$value": "#12"}}}`)); err == nil {
+		t.Error("Invalid color token is imported")
+	}
+}
@@ -0,0 +1,443 @@
+package rui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// darkThemeClass is the class of the root element of the page if the dark theme is used
+	darkThemeClass = "ruiDarkTheme"
+	// touchScreenClass is the class of the root element of the page if the touch screen is used
+	touchScreenClass = "ruiTouchScreen"
+)
+
+// themeColor returns the color of the theme. The references to other colors ("@name") are resolved
+// and the color expressions (for example, "lighten(@name, 10%)") are calculated
+func themeColor(theme Theme, tag string, darkUI bool) (Color, error) {
+	return themeColorNext(theme, tag, darkUI, []string{})
+}
+
+func themeColorNext(theme Theme, tag string, darkUI bool, prevTags []string) (Color, error) {
+	tags := append(append([]string{}, prevTags...), tag)
+	for {
+		result := theme.color(tag, darkUI)
+		if result == "" {
+			return 0, fmt.Errorf(`"%v" color not found`, tag)
+		}
+
+		if isColorExpression(result) {
+			color, err := evalColorExpression(result, func(ref string) (Color, error) {
+				for _, t := range tags {
+					if t == ref {
+						return 0, fmt.Errorf(`"%v" color is cyclic`, ref)
+					}
+				}
+				return themeColorNext(theme, ref, darkUI, tags)
+			})
+			if err != nil {
+				return 0, fmt.Errorf(`invalid value "%v" of "%v" color constant (%s)`, result, tag, err.Error())
+			}
+			return color, nil
+		}
+
+		if result[0] != '@' {
+			color, err := stringToColor(result)
+			if err != nil {
+				return 0, fmt.Errorf(`invalid value "%v" of "%v" color constant (%s)`, result, tag, err.Error())
+			}
+			return color, nil
+		}
+
+		tag = result[1:]
+		for _, t := range tags {
+			if t == tag {
+				return 0, fmt.Errorf(`"%v" color is cyclic`, tag)
+			}
+		}
+
+		tags = append(tags, tag)
+	}
+}
+
+// themeSizeConstant returns the value of the theme constant if it is a size
+func themeSizeConstant(theme Theme, tag string, touchUI bool) (SizeUnit, bool) {
+	tags := []string{tag}
+	for {
+		result := theme.constant(tag, touchUI)
+		if result == "" {
+			return AutoSize(), false
+		}
+
+		if result[0] != '@' {
+			size, err := stringToSizeUnit(result)
+			return size, err == nil
+		}
+
+		tag = result[1:]
+		for _, t := range tags {
+			if t == tag {
+				return AutoSize(), false
+			}
+		}
+		tags = append(tags, tag)
+	}
+}
+
+// themeVariablesCSS returns the rules which define the CSS custom properties of the theme colors and
+// size constants: the ":root" rule contains the light colors and the constants, the ":root.ruiDarkTheme" and
+// ":root.ruiTouchScreen" rules override the dark colors and the touch screen constants
+func themeVariablesCSS(theme Theme) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	main, dark, touch := themeVariables(theme, "\t")
+	buffer.WriteString(":root {\n" + main + "}\n")
+	buffer.WriteString(":root." + darkThemeClass + " {\n" + dark + "}\n")
+	buffer.WriteString(":root." + touchScreenClass + " {\n" + touch + "}\n")
+	return buffer.String()
+}
+
+// themeVariables returns the declarations of the CSS custom properties: the light colors and the constants,
+// the dark colors which differ from the light ones, and the touch screen constants which differ from the main ones
+func themeVariables(theme Theme, indent string) (string, string, string) {
+	colorTags := theme.ColorTags()
+	constantTags := theme.ConstantTags()
+
+	variables := func(write func(writeVariable func(tag, value string))) string {
+		buffer := allocStringBuilder()
+		defer freeStringBuilder(buffer)
+
+		write(func(tag, value string) {
+			buffer.WriteString(indent)
+			buffer.WriteString("--")
+			buffer.WriteString(tag)
+			buffer.WriteString(": ")
+			buffer.WriteString(value)
+			buffer.WriteString(";\n")
+		})
+		return buffer.String()
+	}
+
+	main := variables(func(writeVariable func(tag, value string)) {
+		for _, tag := range colorTags {
+			if color, err := themeColor(theme, tag, false); err == nil {
+				writeVariable(tag, color.cssString())
+			}
+		}
+		for _, tag := range constantTags {
+			if size, ok := themeSizeConstant(theme, tag, false); ok {
+				writeVariable(tag, size.cssString("0"))
+			}
+		}
+	})
+
+	dark := variables(func(writeVariable func(tag, value string)) {
+		for _, tag := range colorTags {
+			if color, err := themeColor(theme, tag, true); err == nil {
+				if light, err := themeColor(theme, tag, false); err != nil || light != color {
+					writeVariable(tag, color.cssString())
+				}
+			}
+		}
+	})
+
+	touch := variables(func(writeVariable func(tag, value string)) {
+		for _, tag := range constantTags {
+			if size, ok := themeSizeConstant(theme, tag, true); ok {
+				if normal, ok := themeSizeConstant(theme, tag, false); !ok || !normal.Equal(size) {
+					writeVariable(tag, size.cssString("0"))
+				}
+			}
+		}
+	})
+
+	return main, dark, touch
+}
+
+// themeVariable returns "var(--name)" if the session uses the theme CSS variables and the value
+// is the reference ("@name") to the theme color (colorValue == true) or to the size constant
+func (session *sessionData) themeVariable(value interface{}, colorValue bool) (string, bool) {
+	if !session.themeVariables {
+		return "", false
+	}
+
+	text, ok := value.(string)
+	if !ok || len(text) < 2 || text[0] != '@' {
+		return "", false
+	}
+
+	tag := text[1:]
+	theme := session.getCurrentTheme()
+	if colorValue {
+		if _, err := themeColor(theme, tag, false); err != nil {
+			return "", false
+		}
+	} else if _, ok := themeSizeConstant(theme, tag, false); !ok {
+		return "", false
+	}
+	return "var(--" + tag + ")", true
+}
+
+// hasThemeReference returns true if the value contains a reference ("@name") to a theme color or constant
+func hasThemeReference(value interface{}) bool {
+	switch value := value.(type) {
+	case string:
+		return strings.Contains(value, "@")
+
+	case Properties:
+		for _, tag := range value.AllTags() {
+			if hasThemeReference(value.getRaw(tag)) {
+				return true
+			}
+		}
+
+	case []ViewShadow:
+		for _, shadow := range value {
+			if hasThemeReference(shadow) {
+				return true
+			}
+		}
+
+	case []BackgroundElement:
+		for _, element := range value {
+			if hasThemeReference(element) {
+				return true
+			}
+		}
+
+	case []BackgroundGradientPoint:
+		for _, point := range value {
+			if hasThemeReference(point.Color) || hasThemeReference(point.Pos) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range value {
+			if hasThemeReference(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasLiteralThemeReference returns true if the style of the view contains a reference to a theme color or constant
+// which is not output as the theme CSS variable, i.e. the style of the view must be updated when the theme is changed
+func (session *sessionData) hasLiteralThemeReference(view View) bool {
+	isVariable := func(tag string, value interface{}) bool {
+		if isPropertyInList(tag, styleSizeProperties) {
+			_, ok := session.themeVariable(value, false)
+			return ok
+		}
+		for _, p := range styleColorProperties {
+			if p.property == tag {
+				_, ok := session.themeVariable(value, true)
+				return ok
+			}
+		}
+		return false
+	}
+
+	for _, tag := range view.AllTags() {
+		if value := view.getRaw(tag); !isVariable(tag, value) && hasThemeReference(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeThemeReferencesStyleScript writes the script which updates the inline styles of the views of the tree
+// which contain the theme references not output as the theme CSS variables (see hasLiteralThemeReference)
+func (session *sessionData) writeThemeReferencesStyleScript(view View, buffer *strings.Builder) {
+	if view == nil {
+		return
+	}
+
+	if session.hasLiteralThemeReference(view) {
+		builder := viewCSSBuilder{buffer: allocStringBuilder()}
+		view.cssStyle(view, &builder)
+
+		buffer.WriteString(`updateCSSStyle('`)
+		buffer.WriteString(view.htmlID())
+		buffer.WriteString(`', '`)
+		buffer.WriteString(builder.finish())
+		buffer.WriteString("');\n")
+	}
+
+	if container, ok := view.(ParanetView); ok {
+		for _, subview := range container.Views() {
+			session.writeThemeReferencesStyleScript(subview, buffer)
+		}
+	}
+}
+
+// writeThemeClassesScript writes the script which sets the dark theme and touch screen classes of the page
+func (session *sessionData) writeThemeClassesScript(buffer *strings.Builder) {
+	if session.themeVariables {
+		for _, class := range []struct {
+			name string
+			on   bool
+		}{
+			{name: darkThemeClass, on: session.darkTheme},
+			{name: touchScreenClass, on: session.touchScreen},
+		} {
+			buffer.WriteString(`document.documentElement.classList.toggle('`)
+			buffer.WriteString(class.name)
+			if class.on {
+				buffer.WriteString("', true);\n")
+			} else {
+				buffer.WriteString("', false);\n")
+			}
+		}
+	}
+}
+
+// themeCSSRule is the CSS rule of the theme style. If the theme CSS variables are used then each rule
+// is placed in the separate style element of the page and is updated only if it is changed
+type themeCSSRule struct {
+	// section is 0 for the main section of the theme and i+1 for the i-th media section
+	section int
+	text    string
+}
+
+// themeCSSState describes the theme CSS which is sent to the client if the theme CSS variables are used
+type themeCSSState struct {
+	variables string
+	media     []string
+	rules     map[string]themeCSSRule
+}
+
+// cssRules returns the media rules of the media sections of the theme and the CSS rules of the theme styles.
+// The key of a rule is the number of its section and the style tag
+func (theme *theme) cssRules(session Session) ([]string, map[string]themeCSSRule) {
+	media := make([]string, len(theme.mediaStyles))
+	rules := map[string]themeCSSRule{}
+
+	addRules := func(section int, styles map[string]ViewStyle) {
+		for tag, style := range styles {
+			if isPropertyInList(tag, disabledStyles) {
+				continue
+			}
+
+			var builder cssStyleBuilder
+			builder.init()
+			if section > 0 {
+				builder.startMedia(media[section-1])
+			}
+			builder.startStyle(tag)
+			style.cssViewStyle(&builder, session)
+			builder.endStyle()
+			if section > 0 {
+				builder.endMedia()
+			}
+			rules[strconv.Itoa(section)+"-"+tag] = themeCSSRule{section: section, text: builder.finish()}
+		}
+	}
+
+	addRules(0, theme.styles)
+	for i, mediaStyle := range theme.mediaStyles {
+		media[i] = mediaStyle.cssText()
+		addRules(i+1, mediaStyle.styles)
+	}
+	return media, rules
+}
+
+// writeThemeRules writes the JS array of the theme rules with the given keys. The removed rules have the empty text
+func writeThemeRules(buffer *strings.Builder, keys []string, rules map[string]themeCSSRule) {
+	sort.Strings(keys)
+	buffer.WriteRune('[')
+	for i, key := range keys {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		rule := rules[key]
+		buffer.WriteString(`["`)
+		buffer.WriteString(textToJS(key))
+		buffer.WriteString(`", `)
+		buffer.WriteString(strconv.Itoa(rule.section))
+		buffer.WriteString(`, "`)
+		buffer.WriteString(strings.ReplaceAll(rule.text, `"`, `\"`))
+		buffer.WriteString(`"]`)
+	}
+	buffer.WriteRune(']')
+}
+
+// writeThemeStylesScript writes the script which replaces the theme CSS variables and all rules of the theme styles.
+// It is used if the session uses the theme CSS variables
+func (session *sessionData) writeThemeStylesScript(buffer *strings.Builder) {
+	theme := session.getCurrentTheme().data()
+	state := &session.themeCSS
+	state.variables = themeVariablesCSS(theme)
+	state.media, state.rules = theme.cssRules(session)
+
+	keys := make([]string, 0, len(state.rules))
+	for key := range state.rules {
+		keys = append(keys, key)
+	}
+
+	buffer.WriteString(`setThemeStyles("`)
+	buffer.WriteString(textToJS(state.variables))
+	buffer.WriteString(`", `)
+	writeThemeRules(buffer, keys, state.rules)
+	buffer.WriteString(");\n")
+}
+
+// writeThemeChangesScript writes the script which updates only the changed theme CSS variables and
+// the changed rules of the theme styles. If the media sections of the theme are changed then all rules are replaced
+func (session *sessionData) writeThemeChangesScript(buffer *strings.Builder) {
+	state := &session.themeCSS
+	theme := session.getCurrentTheme().data()
+	media, rules := theme.cssRules(session)
+
+	if state.rules == nil || len(media) != len(state.media) {
+		session.writeThemeStylesScript(buffer)
+		return
+	}
+	for i, rule := range media {
+		if rule != state.media[i] {
+			session.writeThemeStylesScript(buffer)
+			return
+		}
+	}
+
+	changed := map[string]themeCSSRule{}
+	for key, rule := range rules {
+		if old, ok := state.rules[key]; !ok || old.text != rule.text {
+			changed[key] = rule
+		}
+	}
+	for key, rule := range state.rules {
+		if _, ok := rules[key]; !ok {
+			changed[key] = themeCSSRule{section: rule.section}
+		}
+	}
+
+	variables := themeVariablesCSS(theme)
+	if variables == state.variables && len(changed) == 0 {
+		return
+	}
+
+	buffer.WriteString(`updateThemeStyles(`)
+	if variables == state.variables {
+		buffer.WriteString(`null`)
+	} else {
+		buffer.WriteRune('"')
+		buffer.WriteString(textToJS(variables))
+		buffer.WriteRune('"')
+	}
+	buffer.WriteString(`, `)
+
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	writeThemeRules(buffer, keys, changed)
+	buffer.WriteString(");\n")
+
+	state.variables = variables
+	state.rules = rules
+}
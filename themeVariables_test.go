@@ -0,0 +1,203 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThemeVariables(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		colors = _{
+			myTextColor = #FF102030,
+			myLinkColor = @myTextColor,
+		},
+		colors:dark = _{
+			myTextColor = #FFF0F0F0,
+		},
+		constants = _{
+			myGap = 8px,
+			myFont = "Arial",
+		},
+		constants:touch = _{
+			myGap = 16px,
+		},
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	css := themeVariablesCSS(theme)
+	for _, expected := range []string{
+		"--myTextColor: rgb(16,32,48);",
+		"--myLinkColor: rgb(16,32,48);",
+		"--myGap: 8px;",
+		":root.ruiDarkTheme {\n\t--myLinkColor: rgb(240,240,240);\n\t--myTextColor: rgb(240,240,240);\n}",
+		":root.ruiTouchScreen {\n\t--myGap: 16px;\n}",
+	} {
+		if !strings.Contains(css, expected) {
+			t.Errorf("%q not found in:\n%s", expected, css)
+		}
+	}
+	if strings.Contains(css, "myFont") {
+		t.Errorf("not size constant in:\n%s", css)
+	}
+
+	session := new(sessionData)
+	session.customTheme = theme
+	session.themeVariables = true
+
+	view := NewTextView(session, Params{
+		TextColor:       "@myLinkColor",
+		BackgroundColor: "#FF000000",
+		Width:           "@myGap",
+		Border:          NewBorder(Params{Style: SolidLine, Width: Px(1), ColorTag: "@myTextColor"}),
+	})
+
+	var builder viewCSSBuilder
+	view.cssStyle(view, &builder)
+	style := builder.finish()
+	for _, expected := range []string{
+		"color: var(--myLinkColor);",
+		"background-color: rgb(0,0,0);",
+		"width: var(--myGap);",
+		"border-color: var(--myTextColor);",
+	} {
+		if !strings.Contains(style, expected) {
+			t.Errorf("%q not found in %q", expected, style)
+		}
+	}
+
+	session.themeVariables = false
+	view.cssStyle(view, &builder)
+	if style = builder.finish(); strings.Contains(style, "var(") {
+		t.Errorf("CSS variables are used: %q", style)
+	}
+}
+
+func TestThemeVariablesDarkTheme(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		colors = _{
+			myTextColor = #FF102030,
+			myShadowColor = #FF405060,
+		},
+		colors:dark = _{
+			myTextColor = #FFF0F0F0,
+			myShadowColor = #FFA0B0C0,
+		},
+		constants = _{
+			myMargin = 4px,
+		},
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	brige := new(testBrige)
+	session := new(sessionData)
+	session.customTheme = theme
+	session.themeVariables = true
+	session.brige = brige
+
+	text := NewTextView(session, Params{TextColor: "@myTextColor"})
+	shadow := NewTextView(session, Params{
+		Shadow: NewShadowWithParams(Params{ColorTag: "@myShadowColor", BlurRadius: Px(4)}),
+		Margin: "@myMargin",
+	})
+	session.rootView = NewListLayout(session, Params{Content: []View{text, shadow}})
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	session.writeInitScript(buffer)
+	if script := buffer.String(); !strings.Contains(script, `setThemeStyles(":root {`) ||
+		!strings.Contains(script, `--myTextColor`) {
+		t.Errorf("The theme styles are not set by the init script:\n%s", script)
+	}
+
+	session.SetDarkTheme(true)
+	script := strings.Join(brige.scripts, "\n")
+
+	// only the theme rules with the literal dark colors are updated
+	for _, expected := range []string{
+		`classList.toggle('ruiDarkTheme', true)`,
+		`updateThemeStyles(null, [["0-ruiPopup", 0, ".ruiPopup {`,
+		`updateCSSStyle('` + shadow.htmlID() + `'`,
+		`rgb(160,176,192)`,
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("%q not found in:\n%s", expected, script)
+		}
+	}
+	for _, unexpected := range []string{`document.querySelector('style').textContent`, `setThemeStyles(`, `"0-ruiTab"`} {
+		if strings.Contains(script, unexpected) {
+			t.Errorf("%q is found in:\n%s", unexpected, script)
+		}
+	}
+
+	// the style of the view which refers only to the theme variables is not updated
+	if strings.Contains(script, `updateCSSStyle('`+text.htmlID()+`'`) {
+		t.Errorf("The style of the view with the theme variables is updated:\n%s", script)
+	}
+}
+
+func TestThemeVariablesCustomTheme(t *testing.T) {
+	createTestLog(t, false)
+
+	first, err := CreateThemeFromTextWithError(`theme {
+		name = testVariablesFirst,
+		colors = _{ myColor = #FF102030 },
+		styles = [ myStyle { text-color = @myColor } ],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CreateThemeFromTextWithError(`theme {
+		name = testVariablesSecond,
+		colors = _{ myColor = #FF405060 },
+		styles = [ myStyle { text-color = @myColor, padding = 4px } ],
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTheme(first)
+	addTheme(second)
+	defer func() {
+		delete(resources.themes, "testVariablesFirst")
+		delete(resources.themes, "testVariablesSecond")
+	}()
+
+	brige := new(testBrige)
+	session := new(sessionData)
+	session.themeVariables = true
+	session.brige = brige
+	session.customTheme = first
+	session.rootView = NewTextView(session, Params{Text: "text"})
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	session.writeInitScript(buffer)
+
+	session.SetCustomTheme("testVariablesSecond")
+	script := strings.Join(brige.scripts, "\n")
+
+	// the variables and the changed rule are sent only
+	for _, expected := range []string{`updateThemeStyles(":root {`, `--myColor: rgb(64,80,96)`, `["0-myStyle", 0, ".myStyle {`} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("%q not found in:\n%s", expected, script)
+		}
+	}
+	for _, unexpected := range []string{`document.querySelector('style').textContent`, `"0-ruiTab"`, `setThemeStyles(`} {
+		if strings.Contains(script, unexpected) {
+			t.Errorf("%q is found in:\n%s", unexpected, script)
+		}
+	}
+
+	brige.scripts = nil
+	session.SetCustomTheme("testVariablesSecond")
+	if len(brige.scripts) > 0 {
+		t.Errorf("The script is sent without changes of the theme:\n%s", strings.Join(brige.scripts, "\n"))
+	}
+}
@@ -0,0 +1,118 @@
+package rui
+
+import "testing"
+
+func TestThemeColorExpressions(t *testing.T) {
+	createTestLog(t, false)
+
+	theme, err := CreateThemeFromTextWithError(`theme {
+		colors = _{
+			primary = #FFFF0000,
+			light = "lighten(@primary, 20%)",
+			dark = "darken(@primary, 0.2)",
+			gray = "mix(black, white, 30%)",
+			redPart = "mix(#FFFF0000, #FF0000FF, 25%)",
+			shadow = "alpha(@primary, 0.5)",
+			onYellow = "contrast(#FFFFFF00)",
+			onNavy = "contrast(#FF000080)",
+			nested = "alpha(mix(@primary, #FF0000FF), 50%)",
+			cyclic1 = "lighten(@cyclic2, 10%)",
+			cyclic2 = @cyclic1,
+			invalid = "mix(@primary)",
+		},
+		colors:dark = _{
+			primary = #FF0000FF,
+		},
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tag   string
+		dark  bool
+		color Color
+	}{
+		{"light", false, 0xFFFF6666},
+		{"dark", false, 0xFF990000},
+		{"gray", false, 0xFFB3B3B3},
+		{"redPart", false, 0xFF4000BF},
+		{"shadow", false, 0x80FF0000},
+		{"onYellow", false, 0xFF000000},
+		{"onNavy", false, 0xFFFFFFFF},
+		{"nested", false, 0x80800080},
+		{"light", true, 0xFF6666FF},
+		{"shadow", true, 0x800000FF},
+	}
+
+	for _, test := range tests {
+		color, err := themeColor(theme, test.tag, test.dark)
+		if err != nil {
+			t.Errorf(`"%s": %s`, test.tag, err.Error())
+		} else if color != test.color {
+			t.Errorf(`"%s" (dark = %v) = %s, expected %s`, test.tag, test.dark, color.String(), test.color.String())
+		}
+	}
+
+	for _, tag := range []string{"cyclic1", "invalid"} {
+		if _, err := themeColor(theme, tag, false); err == nil {
+			t.Errorf(`"%s" color must be invalid`, tag)
+		}
+	}
+}
+
+func TestThemeExtends(t *testing.T) {
+	createTestLog(t, false)
+
+	base, err := CreateThemeFromTextWithError(`theme {
+		name = testBase,
+		colors = _{
+			primary = #FF102030,
+			accent = #FF405060,
+		},
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	brand, err := CreateThemeFromTextWithError(`theme {
+		name = testBrand,
+		extends = testBase,
+		colors = _{
+			accent = "lighten(@primary, 0%)",
+		},
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if brand.Name() != "testBrand" || brand.Extends() != "testBase" {
+		t.Errorf(`Invalid name "%s" or base theme "%s"`, brand.Name(), brand.Extends())
+	}
+
+	addTheme(base)
+	addTheme(brand)
+	defer func() {
+		delete(resources.themes, "testBase")
+		delete(resources.themes, "testBrand")
+	}()
+
+	session := new(sessionData)
+	session.customTheme = resources.themes["testBrand"]
+
+	if color, ok := session.Color("accent"); !ok || color != 0xFF102030 {
+		t.Errorf(`"accent" color = %s`, color.String())
+	}
+	if _, ok := session.Color("ruiTextColor"); !ok {
+		t.Error(`"ruiTextColor" of the default theme not found`)
+	}
+
+	createTestLog(t, true)
+	// the registered theme is the copy of the added one
+	registered, _ := getTheme("testBase")
+	registered.SetExtends("testBrand")
+	session.currentTheme = nil
+	if _, ok := session.Color("primary"); !ok {
+		t.Error(`"primary" color not found`)
+	}
+}
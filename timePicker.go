@@ -118,6 +118,31 @@ func (picker *timePickerData) Set(tag string, value interface{}) bool {
 	return picker.set(picker.normalizeTag(tag), value)
 }
 
+// timeFormatForText returns the layout for time.Parse that matches the time text.
+// Supported formats: "15:04", "15:04:05", "3:04 PM", and "03:04:05 PM"
+func timeFormatForText(text string) string {
+	lowText := strings.ToLower(text)
+	pm := strings.HasSuffix(lowText, "pm") || strings.HasSuffix(lowText, "am")
+
+	var format string
+	switch len(strings.Split(text, ":")) {
+	case 2:
+		if pm {
+			format = "3:04 PM"
+		} else {
+			format = "15:04"
+		}
+
+	default:
+		if pm {
+			format = "03:04:05 PM"
+		} else {
+			format = "15:04:05"
+		}
+	}
+	return format
+}
+
 func (picker *timePickerData) set(tag string, value interface{}) bool {
 	if value == nil {
 		picker.remove(tag)
@@ -132,26 +157,7 @@ func (picker *timePickerData) set(tag string, value interface{}) bool {
 
 		case string:
 			if text, ok := picker.Session().resolveConstants(value); ok {
-				lowText := strings.ToLower(text)
-				pm := strings.HasSuffix(lowText, "pm") || strings.HasSuffix(lowText, "am")
-
-				var format string
-				switch len(strings.Split(text, ":")) {
-				case 2:
-					if pm {
-						format = "3:04 PM"
-					} else {
-						format = "15:04"
-					}
-
-				default:
-					if pm {
-						format = "03:04:05 PM"
-					} else {
-						format = "15:04:05"
-					}
-				}
-
+				format := timeFormatForText(text)
 				if time, err := time.Parse(format, text); err == nil {
 					picker.properties[tag] = value
 					return time, true
@@ -301,10 +307,17 @@ func (picker *timePickerData) htmlTag() string {
 	return "input"
 }
 
+func (picker *timePickerData) languageChanged() {
+	if picker.created {
+		updateLangAttribute(picker.htmlID(), picker.session)
+	}
+}
+
 func (picker *timePickerData) htmlProperties(self View, buffer *strings.Builder) {
 	picker.viewData.htmlProperties(self, buffer)
 
 	buffer.WriteString(` type="time"`)
+	htmlLangAttribute(picker.session, buffer)
 
 	if min, ok := getTimeProperty(picker, TimePickerMin, Min); ok {
 		buffer.WriteString(` min="`)
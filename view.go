@@ -63,6 +63,7 @@ type View interface {
 	handleCommand(self View, command string, data DataObject) bool
 	htmlClass(disabled bool) string
 	htmlTag() string
+	ariaRole() string
 	closeHTMLTag() bool
 	htmlID() string
 	htmlSubviews(self View, buffer *strings.Builder)
@@ -179,7 +180,8 @@ func (view *viewData) Focusable() bool {
 	if focus, ok := boolProperty(view, Focusable, view.session); ok {
 		return focus
 	}
-	return false
+	_, ok := intProperty(view, TabIndex, view.session, 0)
+	return ok
 }
 
 func (view *viewData) Remove(tag string) {
@@ -319,6 +321,14 @@ func (view *viewData) set(tag string, value interface{}) bool {
 	case UserData:
 		view.properties[tag] = value
 
+	case TextArgs:
+		args, ok := valueToTextArgs(value)
+		if !ok {
+			notCompatibleType(tag, value)
+			return false
+		}
+		view.properties[tag] = args
+
 	case Style, StyleDisabled:
 		text, ok := value.(string)
 		if !ok {
@@ -375,7 +385,25 @@ func viewPropertyChanged(view *viewData, tag string) {
 	htmlID := view.htmlID()
 	session := view.session
 
+	if isAriaProperty(tag) {
+		// the built-in role is defined by the view which embeds viewData
+		if self := session.viewByHTMLID(htmlID); self != nil {
+			updateAriaProperty(self, tag)
+		} else {
+			updateAriaProperty(view, tag)
+		}
+		return
+	}
+
 	switch tag {
+	case TabIndex, FocusGroup:
+		if self := session.viewByHTMLID(htmlID); self != nil {
+			updateFocusProperty(self, tag)
+		} else {
+			updateFocusProperty(view, tag)
+		}
+		return
+
 	case Disabled:
 		updateInnerHTML(view.parentHTMLID(), session)
 		return
@@ -647,6 +675,10 @@ func (view *viewData) htmlTag() string {
 	return "div"
 }
 
+func (view *viewData) ariaRole() string {
+	return viewRole(view, "")
+}
+
 func (view *viewData) closeHTMLTag() bool {
 	return true
 }
@@ -734,9 +766,9 @@ func viewHTML(view View, buffer *strings.Builder) {
 		buffer.WriteRune(' ')
 	}
 
-	if view.Focusable() && !disabled {
-		buffer.WriteString(`tabindex="0" `)
-	}
+	ariaHTML(view, buffer)
+
+	focusHtml(view, disabled, buffer)
 
 	buffer.WriteString(`onscroll="scrollEvent(this, event)" `)
 
@@ -798,12 +830,14 @@ func (view *viewData) handleCommand(self View, command string, data DataObject)
 
 	case FocusEvent:
 		view.hasFocus = true
+		view.session.viewFocusChanged(self, true)
 		for _, listener := range getFocusListeners(view, "", command) {
 			listener(self)
 		}
 
 	case LostFocusEvent:
 		view.hasFocus = false
+		view.session.viewFocusChanged(self, false)
 		for _, listener := range getFocusListeners(view, "", command) {
 			listener(self)
 		}
@@ -30,6 +30,10 @@ type polygonClip struct {
 	points []interface{}
 }
 
+type pathClip struct {
+	propertyList
+}
+
 // InsetClip creates a rectangle View clipping area.
 // top - offset from the top border of a View;
 // right - offset from the right border of a View;
@@ -92,6 +96,26 @@ func PolygonPointsClip(points []SizeUnit) ClipShape {
 	return nil
 }
 
+// PathClip creates a View clipping area defined by the Path.
+func PathClip(path Path) ClipShape {
+	clip := new(pathClip)
+	clip.init()
+	if path != nil && clip.Set(PathData, path) {
+		return clip
+	}
+	return nil
+}
+
+// SVGPathClip creates a View clipping area defined by the SVG path data string.
+func SVGPathClip(d string) ClipShape {
+	clip := new(pathClip)
+	clip.init()
+	if clip.Set(PathData, d) {
+		return clip
+	}
+	return nil
+}
+
 func (clip *insetClip) Set(tag string, value interface{}) bool {
 	switch strings.ToLower(tag) {
 	case Top, Right, Bottom, Left:
@@ -461,6 +485,82 @@ func (clip *polygonClip) valid(session Session) bool {
 	return true
 }
 
+func (clip *pathClip) Set(tag string, value interface{}) bool {
+	if value == nil {
+		clip.Remove(tag)
+		return true
+	}
+
+	if PathData == strings.ToLower(tag) {
+		switch value := value.(type) {
+		case Path:
+			clip.properties[PathData] = value.SVGData()
+			return true
+
+		case string:
+			if isConstantName(value) {
+				clip.properties[PathData] = value
+				return true
+			}
+			if _, err := NewPathFromSVG(value); err != nil {
+				ErrorLog(err.Error())
+				return false
+			}
+			clip.properties[PathData] = value
+			return true
+		}
+
+		notCompatibleType(tag, value)
+		return false
+	}
+
+	ErrorLogF(`"%s" property is not supported by the path clip shape`, tag)
+	return false
+}
+
+func (clip *pathClip) String() string {
+	return runStringWriter(clip)
+}
+
+func (clip *pathClip) writeString(buffer *strings.Builder, indent string) {
+	buffer.WriteString("path { ")
+	if value, ok := clip.properties[PathData]; ok {
+		buffer.WriteString(PathData)
+		buffer.WriteString(" = ")
+		writePropertyValue(buffer, PathData, value, indent)
+		buffer.WriteRune(' ')
+	}
+	buffer.WriteRune('}')
+}
+
+func (clip *pathClip) data(session Session) string {
+	if value, ok := clip.properties[PathData].(string); ok {
+		if text, ok := session.resolveConstants(value); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+func (clip *pathClip) cssStyle(session Session) string {
+	data := clip.data(session)
+	if data == "" {
+		return ""
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString(`path("`)
+	buffer.WriteString(data)
+	buffer.WriteString(`")`)
+	return buffer.String()
+}
+
+func (clip *pathClip) valid(session Session) bool {
+	return clip.data(session) != ""
+}
+
 func parseClipShape(obj DataObject) ClipShape {
 	switch obj.Tag() {
 	case "inset":
@@ -496,6 +596,14 @@ func parseClipShape(obj DataObject) ClipShape {
 			clip.Set(Points, value)
 		}
 		return clip
+
+	case "path":
+		clip := new(pathClip)
+		clip.init()
+		if value, ok := obj.PropertyValue(PathData); ok {
+			clip.Set(PathData, value)
+		}
+		return clip
 	}
 
 	return nil
@@ -513,7 +621,7 @@ func (style *viewStyle) setClipShape(tag string, value interface{}) bool {
 			return true
 		}
 
-		if obj := NewDataObject(value); obj == nil {
+		if obj := ParseDataText(value); obj != nil {
 			if clip := parseClipShape(obj); clip != nil {
 				style.properties[tag] = clip
 				return true
@@ -547,7 +655,7 @@ func getClipShape(prop Properties, tag string, session Session) ClipShape {
 
 		case string:
 			if text, ok := session.resolveConstants(value); ok {
-				if obj := NewDataObject(text); obj == nil {
+				if obj := ParseDataText(text); obj != nil {
 					return parseClipShape(obj)
 				}
 			}
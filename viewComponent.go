@@ -0,0 +1,528 @@
+package rui
+
+import (
+	"embed"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// componentTag is the tag of the view component description
+	componentTag = "Component"
+	// includeTag is the tag of the object which is replaced by the content of other view file
+	includeTag = "Include"
+	// repeatTag is the tag of the object which is replaced by copies of its content for each array element
+	repeatTag = "Repeat"
+	// maxComponentDepth limits the nesting of components and includes (protection from cycles)
+	maxComponentDepth = 32
+)
+
+// viewComponent describes a view template declared in a view file with the "Component" tag
+type viewComponent struct {
+	name          string
+	params        []DataNode
+	content       DataObject
+	fromResources bool
+}
+
+var viewComponents = struct {
+	components map[string]*viewComponent
+	mutex      sync.RWMutex
+}{
+	components: map[string]*viewComponent{},
+}
+
+// RegisterViewComponent registers the view component. The component is described by the object with
+// the "Component" tag, for example
+//
+//	Component {
+//		name = Card,
+//		params = _{ title = "", items = [] },
+//		content = ListLayout {
+//			content = [
+//				TextView { text = @title },
+//				Repeat { items = @items, item = entry, content = TextView { text = "• @{entry}" } },
+//			],
+//		},
+//	}
+//
+// After registration the name of the component can be used as a tag of a view description:
+//
+//	Card { id = card1, title = "Fruits", items = [apple, orange] }
+//
+// Returns false if the description is invalid or the name is used by a view type
+func RegisterViewComponent(object DataObject) bool {
+	component := newViewComponent(object, "")
+	if component == nil {
+		return false
+	}
+	return addViewComponent(component)
+}
+
+func addViewComponent(component *viewComponent) bool {
+	if _, ok := viewCreators[component.name]; ok || component.name == includeTag || component.name == repeatTag {
+		ErrorLogF(`The component name "%s" is used by a view type`, component.name)
+		return false
+	}
+
+	viewComponents.mutex.Lock()
+	viewComponents.components[component.name] = component
+	viewComponents.mutex.Unlock()
+	return true
+}
+
+func findViewComponent(name string) *viewComponent {
+	viewComponents.mutex.RLock()
+	defer viewComponents.mutex.RUnlock()
+	return viewComponents.components[name]
+}
+
+// newViewComponent creates the component from the description. defaultName is used if the "name" property is not set
+func newViewComponent(object DataObject, defaultName string) *viewComponent {
+	if object == nil {
+		return nil
+	}
+
+	if object.Tag() != componentTag {
+		ErrorLogF(`Invalid component tag "%s". The "%s" tag is expected`, object.Tag(), componentTag)
+		return nil
+	}
+
+	component := &viewComponent{name: defaultName, params: []DataNode{}}
+	for i := 0; i < object.PropertyCount(); i++ {
+		node := object.Property(i)
+		switch node.Tag() {
+		case "name":
+			if node.Type() != TextNode {
+				ErrorLog(`The component "name" property must be a text`)
+				return nil
+			}
+			component.name = node.Text()
+
+		case "params":
+			switch node.Type() {
+			case ObjectNode:
+				params := node.Object()
+				for k := 0; k < params.PropertyCount(); k++ {
+					component.params = append(component.params, params.Property(k))
+				}
+
+			case ArrayNode:
+				for _, value := range node.ArrayElements() {
+					if value.IsObject() {
+						ErrorLog(`The element of the component "params" array must be a text`)
+						return nil
+					}
+					component.params = append(component.params, textDataNode(value.Value(), ""))
+				}
+
+			default:
+				for _, name := range strings.Split(node.Text(), ",") {
+					if name = strings.Trim(name, " \t\n\r"); name != "" {
+						component.params = append(component.params, textDataNode(name, ""))
+					}
+				}
+			}
+
+		case "content":
+			if node.Type() != ObjectNode {
+				ErrorLog(`The component "content" property must be a view description`)
+				return nil
+			}
+			component.content = node.Object()
+
+		default:
+			ErrorLogF(`Unknown component property "%s"`, node.Tag())
+		}
+	}
+
+	if component.name == "" {
+		ErrorLog(`The component name is not defined`)
+		return nil
+	}
+
+	if component.content == nil {
+		ErrorLogF(`The content of the "%s" component is not defined`, component.name)
+		return nil
+	}
+
+	return component
+}
+
+func textDataNode(tag, text string) DataNode {
+	return &dataNode{tag: tag, value: &dataStringValue{value: text}}
+}
+
+// isViewTemplate returns true if the object is a component usage or an include
+func isViewTemplate(object DataObject) bool {
+	tag := object.Tag()
+	return tag == includeTag || findViewComponent(tag) != nil
+}
+
+// expandViewTemplate replaces all components and includes of the view description by their content
+func expandViewTemplate(object DataObject, depth int) DataObject {
+	if depth > maxComponentDepth {
+		ErrorLogF(`The nesting of components is too deep (the cycle in "%s"?)`, object.Tag())
+		return nil
+	}
+
+	var content DataObject
+	var params map[string]DataNode
+	extra := []DataNode{}
+
+	switch tag := object.Tag(); tag {
+	case includeTag:
+		name, ok := object.PropertyValue("view")
+		if !ok || name == "" {
+			ErrorLog(`The "view" property of "Include" is not defined`)
+			return nil
+		}
+
+		data := viewResourceData(name)
+		if data == nil {
+			ErrorLogF(`The view file "%s" not found`, name)
+			return nil
+		}
+
+		if data.Tag() == componentTag {
+			component := newViewComponent(data, name)
+			if component == nil {
+				return nil
+			}
+			content, params, extra = component.arguments(object, "view")
+		} else {
+			content = data
+			for i := 0; i < object.PropertyCount(); i++ {
+				if node := object.Property(i); node.Tag() != "view" {
+					extra = append(extra, node)
+				}
+			}
+		}
+
+	default:
+		component := findViewComponent(tag)
+		if component == nil {
+			return expandViewObject(object, nil, depth)
+		}
+		content, params, extra = component.arguments(object, "")
+	}
+
+	result := expandViewObject(content, params, depth+1)
+	if result == nil {
+		return nil
+	}
+
+	if obj, ok := result.(*dataObject); ok {
+		for _, node := range extra {
+			if node = expandViewNode(node, nil, depth+1); node != nil {
+				obj.setNode(node)
+			}
+		}
+	}
+	return result
+}
+
+// arguments returns the content template, the parameter values and the properties of the usage
+// which are not parameters (they are applied to the root view)
+func (component *viewComponent) arguments(object DataObject, skip string) (DataObject, map[string]DataNode, []DataNode) {
+	params := map[string]DataNode{}
+	for _, param := range component.params {
+		params[param.Tag()] = param
+	}
+
+	extra := []DataNode{}
+	for i := 0; i < object.PropertyCount(); i++ {
+		node := object.Property(i)
+		if tag := node.Tag(); tag != skip {
+			if _, ok := params[tag]; ok {
+				params[tag] = node
+			} else {
+				extra = append(extra, node)
+			}
+		}
+	}
+
+	return component.content, params, extra
+}
+
+// expandViewObject returns the copy of the object in which the parameters are substituted,
+// "Repeat" objects are unrolled, and components and includes are expanded
+func expandViewObject(object DataObject, params map[string]DataNode, depth int) DataObject {
+	if depth > maxComponentDepth {
+		ErrorLogF(`The nesting of components is too deep (the cycle in "%s"?)`, object.Tag())
+		return nil
+	}
+
+	result := &dataObject{tag: object.Tag(), property: make([]DataNode, 0, object.PropertyCount())}
+	for i := 0; i < object.PropertyCount(); i++ {
+		if node := expandViewNode(object.Property(i), params, depth); node != nil {
+			result.property = append(result.property, node)
+		}
+	}
+
+	if isViewTemplate(result) {
+		return expandViewTemplate(result, depth)
+	}
+	return result
+}
+
+func expandViewNode(node DataNode, params map[string]DataNode, depth int) DataNode {
+	tag := node.Tag()
+	switch node.Type() {
+	case TextNode:
+		text := node.Text()
+		if param := templateParam(text, params); param != nil {
+			return copyDataNode(tag, param)
+		}
+		return textDataNode(tag, interpolateTemplateText(text, params))
+
+	case ObjectNode:
+		obj := node.Object()
+		if obj.Tag() == repeatTag {
+			return &dataNode{tag: tag, array: expandRepeat(obj, params, depth)}
+		}
+		if obj = expandViewObject(obj, params, depth); obj != nil {
+			return &dataNode{tag: tag, value: obj}
+		}
+		return nil
+
+	case ArrayNode:
+		return &dataNode{tag: tag, array: expandViewArray(node.ArrayElements(), params, depth)}
+	}
+	return nil
+}
+
+func expandViewArray(array []DataValue, params map[string]DataNode, depth int) []DataValue {
+	result := make([]DataValue, 0, len(array))
+	for _, value := range array {
+		if value.IsObject() {
+			obj := value.Object()
+			if obj.Tag() == repeatTag {
+				result = append(result, expandRepeat(obj, params, depth)...)
+			} else if obj = expandViewObject(obj, params, depth); obj != nil {
+				result = append(result, obj)
+			}
+		} else if param := templateParam(value.Value(), params); param != nil {
+			if param.Type() == ArrayNode {
+				result = append(result, param.ArrayElements()...)
+			} else if param.Type() == ObjectNode {
+				result = append(result, param.Object())
+			} else {
+				result = append(result, &dataStringValue{value: param.Text()})
+			}
+		} else {
+			result = append(result, &dataStringValue{value: interpolateTemplateText(value.Value(), params)})
+		}
+	}
+	return result
+}
+
+// expandRepeat unrolls the "Repeat" object:
+//
+//	Repeat { items = @list, item = entry, index = n, content = TextView { text = "@{n}. @{entry}" } }
+//
+// "content" can be a view description or an array of them. "item" is "item" by default, "index" is optional
+func expandRepeat(object DataObject, params map[string]DataNode, depth int) []DataValue {
+	itemName := "item"
+	if name, ok := object.PropertyValue("item"); ok && name != "" {
+		itemName = name
+	}
+	indexName, _ := object.PropertyValue("index")
+
+	var items []DataValue
+	if node := object.PropertyWithTag("items"); node != nil {
+		if node.Type() == TextNode {
+			if param := templateParam(node.Text(), params); param != nil {
+				node = param
+			}
+		}
+
+		switch node.Type() {
+		case ArrayNode:
+			items = node.ArrayElements()
+
+		case ObjectNode:
+			items = []DataValue{node.Object()}
+
+		default:
+			for _, text := range strings.Split(node.Text(), ",") {
+				if text = strings.Trim(text, " \t\n\r"); text != "" {
+					items = append(items, &dataStringValue{value: text})
+				}
+			}
+		}
+	}
+
+	content := object.PropertyWithTag("content")
+	if content == nil {
+		ErrorLog(`The content of "Repeat" is not defined`)
+		return []DataValue{}
+	}
+
+	var templates []DataValue
+	switch content.Type() {
+	case ArrayNode:
+		templates = content.ArrayElements()
+
+	case ObjectNode:
+		templates = []DataValue{content.Object()}
+
+	default:
+		ErrorLog(`The content of "Repeat" must be a view description or an array of them`)
+		return []DataValue{}
+	}
+
+	result := []DataValue{}
+	for i, item := range items {
+		itemParams := map[string]DataNode{}
+		for key, value := range params {
+			itemParams[key] = value
+		}
+		if item.IsObject() {
+			itemParams[itemName] = &dataNode{tag: itemName, value: item.Object()}
+		} else {
+			itemParams[itemName] = textDataNode(itemName, item.Value())
+		}
+		if indexName != "" {
+			itemParams[indexName] = textDataNode(indexName, strconv.Itoa(i))
+		}
+		result = append(result, expandViewArray(templates, itemParams, depth)...)
+	}
+	return result
+}
+
+// templateParam returns the parameter if the text is a reference to it: "@name" or "@name.property"
+func templateParam(text string, params map[string]DataNode) DataNode {
+	if len(params) == 0 || len(text) < 2 || text[0] != '@' {
+		return nil
+	}
+	return lookupTemplateParam(text[1:], params)
+}
+
+func lookupTemplateParam(name string, params map[string]DataNode) DataNode {
+	path := strings.Split(name, ".")
+	node, ok := params[path[0]]
+	if !ok {
+		return nil
+	}
+
+	for _, tag := range path[1:] {
+		if node.Type() != ObjectNode {
+			return nil
+		}
+		if node = node.Object().PropertyWithTag(tag); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// interpolateTemplateText replaces "@{name}" and "@{name.property}" in the text by the text parameters
+func interpolateTemplateText(text string, params map[string]DataNode) string {
+	if len(params) == 0 || !strings.Contains(text, "@{") {
+		return text
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	for {
+		start := strings.Index(text, "@{")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexRune(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		buffer.WriteString(text[:start])
+		if param := lookupTemplateParam(text[start+2:end], params); param != nil && param.Type() == TextNode {
+			buffer.WriteString(param.Text())
+		} else {
+			buffer.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+
+	buffer.WriteString(text)
+	return buffer.String()
+}
+
+// copyDataNode returns the node with the new tag and the value of the given node
+func copyDataNode(tag string, node DataNode) DataNode {
+	switch node.Type() {
+	case ObjectNode:
+		return &dataNode{tag: tag, value: node.Object()}
+
+	case ArrayNode:
+		return &dataNode{tag: tag, array: node.ArrayElements()}
+	}
+	return textDataNode(tag, node.Text())
+}
+
+func registerViewComponentData(data DataObject, name string) {
+	if data != nil && data.Tag() == componentTag {
+		if component := newViewComponent(data, name); component != nil {
+			component.fromResources = true
+			addViewComponent(component)
+		}
+	}
+}
+
+func scanEmbedViewsDir(fs *embed.FS, dir, prefix string) {
+	if files, err := fs.ReadDir(dir); err == nil {
+		for _, file := range files {
+			name := file.Name()
+			path := dir + "/" + name
+			if file.IsDir() {
+				scanEmbedViewsDir(fs, path, prefix+name+"/")
+			} else if isDataFile(name) {
+				if data, err := fs.ReadFile(path); err == nil {
+					registerViewComponentData(parseDataFile(path, data), prefix+strings.TrimSuffix(name, filepath.Ext(name)))
+				}
+			}
+		}
+	}
+}
+
+func scanViewsDir(path, prefix string) {
+	if files, err := ioutil.ReadDir(path); err == nil {
+		for _, file := range files {
+			filename := file.Name()
+			if filename[0] != '.' {
+				newPath := path + `/` + filename
+				if file.IsDir() {
+					scanViewsDir(newPath, prefix+filename+"/")
+				} else if isDataFile(newPath) {
+					if data, err := ioutil.ReadFile(newPath); err == nil {
+						registerViewComponentData(parseDataFile(newPath, data), prefix+strings.TrimSuffix(filename, filepath.Ext(filename)))
+					} else {
+						ErrorLog(err.Error())
+					}
+				}
+			}
+		}
+	}
+}
+
+func reloadViewComponents() {
+	viewComponents.mutex.Lock()
+	for name, component := range viewComponents.components {
+		if component.fromResources {
+			delete(viewComponents.components, name)
+		}
+	}
+	viewComponents.mutex.Unlock()
+
+	for _, fs := range resources.embedFS {
+		for _, dir := range embedResourceDirs(fs, viewDir) {
+			scanEmbedViewsDir(fs, dir, "")
+		}
+	}
+	scanViewsDir(resources.path+viewDir, "")
+}
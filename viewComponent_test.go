@@ -0,0 +1,68 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestViewComponent(t *testing.T) {
+
+	SetErrorLog(func(text string) {
+		t.Error(text)
+	})
+
+	component := ParseDataText(`Component {
+		name = TestCard,
+		params = _{ title = Untitled, items = [], color = "" },
+		content = ListLayout {
+			id = card,
+			content = [
+				TextView { text = @title, text-color = @color, background-color = @background },
+				Repeat { items = @items, item = entry, index = n, content = TextView { id = "item@{n}", text = "@{entry.name}: @{entry.price}" } },
+			],
+		},
+	}`)
+
+	if !RegisterViewComponent(component) {
+		t.Fatal("RegisterViewComponent error")
+	}
+	defer func() {
+		viewComponents.mutex.Lock()
+		delete(viewComponents.components, "TestCard")
+		viewComponents.mutex.Unlock()
+	}()
+
+	usage := ParseDataText(`TestCard {
+		id = fruits,
+		title = "Fruits",
+		items = [ _{ name = apple, price = 1 }, _{ name = orange, price = 2 } ],
+	}`)
+
+	result := expandViewTemplate(usage, 0)
+	if result == nil {
+		t.Fatal("expandViewTemplate error")
+	}
+
+	expected := ParseDataText(`ListLayout {
+		id = fruits,
+		content = [
+			TextView { text = Fruits, text-color = "", background-color = @background },
+			TextView { id = item0, text = "apple: 1" },
+			TextView { id = item1, text = "orange: 2" },
+		],
+	}`)
+
+	if json, expectedJSON := DataObjectToJSON(result), DataObjectToJSON(expected); json != expectedJSON {
+		t.Errorf("expandViewTemplate result:\n%s\nexpected:\n%s", json, expectedJSON)
+	}
+
+	SetErrorLog(func(text string) {})
+	for _, text := range []string{
+		`Component { params = [a] }`,
+		`Component { name = TextView, content = View {} }`,
+		`Component { name = Empty }`,
+	} {
+		if RegisterViewComponent(ParseDataText(text)) {
+			t.Errorf("RegisterViewComponent(`%s`) must fail", text)
+		}
+	}
+}
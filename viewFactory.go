@@ -1,9 +1,8 @@
 package rui
 
 import (
+	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 )
 
 var viewCreators = map[string]func(Session) View{
@@ -33,6 +32,11 @@ var viewCreators = map[string]func(Session) View{
 	"TableView":      newTableView,
 	"AudioPlayer":    newAudioPlayer,
 	"VideoPlayer":    newVideoPlayer,
+	"LineChart":      newLineChart,
+	"BarChart":       newBarChart,
+	"PieChart":       newPieChart,
+	"ScatterChart":   newScatterChart,
+	"ThemeEditor":    newThemeEditor,
 }
 
 // RegisterViewCreator register function of creating view
@@ -62,6 +66,11 @@ func RegisterViewCreator(tag string, creator func(Session) View) bool {
 		"CanvasView",
 		"ImageView",
 		"TableView",
+		"LineChart",
+		"BarChart",
+		"PieChart",
+		"ScatterChart",
+		"ThemeEditor",
 	}
 
 	for _, name := range builtinViews {
@@ -93,6 +102,13 @@ func CreateViewFromObject(session Session, object DataObject) View {
 		return view
 	}
 
+	if isViewTemplate(object) {
+		if data := expandViewTemplate(object, 0); data != nil {
+			return CreateViewFromObject(session, data)
+		}
+		return nil
+	}
+
 	ErrorLog(`Unknown view type "` + object.Tag() + `"`)
 	return nil
 }
@@ -105,31 +121,77 @@ func CreateViewFromText(session Session, text string) View {
 	return nil
 }
 
+// CreateViewFromTextWithError create new View and initialize it by content of text.
+// If the text is invalid then *DataParseError is returned
+func CreateViewFromTextWithError(session Session, text string) (View, error) {
+	data, err := ParseDataTextWithError(text)
+	if err != nil {
+		return nil, err
+	}
+	if view := CreateViewFromObject(session, data); view != nil {
+		return view, nil
+	}
+	return nil, fmt.Errorf(`unable to create the "%s" view`, data.Tag())
+}
+
+// CreateViewFromJSON create new View and initialize it by JSON data (see ParseDataJSON)
+func CreateViewFromJSON(session Session, data []byte) View {
+	object, err := ParseDataJSON(data)
+	if err != nil {
+		ErrorLog(err.Error())
+		return nil
+	}
+	return CreateViewFromObject(session, object)
+}
+
 // CreateViewFromResources create new View and initialize it by the content of
-// the resource file from "views" directory
+// the resource file from "views" directory. The file can be in the rui text format (".rui" extension)
+// or in JSON (".json" extension). If the name has no extension then "name.rui" and "name.json" are searched.
+// If the file describes a component (see RegisterViewComponent) then the view is created with default parameters
 func CreateViewFromResources(session Session, name string) View {
-	if strings.ToLower(filepath.Ext(name)) != ".rui" {
-		name += ".rui"
+	if data := viewResourceData(name); data != nil {
+		if data.Tag() == componentTag {
+			component := newViewComponent(data, name)
+			if component == nil {
+				return nil
+			}
+			content, params, _ := component.arguments(NewDataObject(component.name), "")
+			if data = expandViewObject(content, params, 1); data == nil {
+				return nil
+			}
+		}
+		return CreateViewFromObject(session, data)
+	}
+	return nil
+}
+
+// viewResourceData returns the content of the resource file from "views" directory
+func viewResourceData(name string) DataObject {
+	names := []string{name}
+	if !isDataFile(name) {
+		names = []string{name + ".rui", name + ".json"}
 	}
 
 	for _, fs := range resources.embedFS {
 		rootDirs := embedRootDirs(fs)
 		for _, dir := range rootDirs {
-			switch dir {
-			case imageDir, themeDir, rawDir:
-				// do nothing
-
-			case viewDir:
-				if data, err := fs.ReadFile(dir + "/" + name); err == nil {
-					if data := ParseDataText(string(data)); data != nil {
-						return CreateViewFromObject(session, data)
+			for _, name := range names {
+				switch dir {
+				case imageDir, themeDir, rawDir:
+					// do nothing
+
+				case viewDir:
+					if data, err := fs.ReadFile(dir + "/" + name); err == nil {
+						if data := parseDataFile(name, data); data != nil {
+							return data
+						}
 					}
-				}
 
-			default:
-				if data, err := fs.ReadFile(dir + "/" + viewDir + "/" + name); err == nil {
-					if data := ParseDataText(string(data)); data != nil {
-						return CreateViewFromObject(session, data)
+				default:
+					if data, err := fs.ReadFile(dir + "/" + viewDir + "/" + name); err == nil {
+						if data := parseDataFile(name, data); data != nil {
+							return data
+						}
 					}
 				}
 			}
@@ -137,9 +199,11 @@ func CreateViewFromResources(session Session, name string) View {
 	}
 
 	if resources.path != "" {
-		if data, err := os.ReadFile(resources.path + viewDir + "/" + name); err == nil {
-			if data := ParseDataText(string(data)); data != nil {
-				return CreateViewFromObject(session, data)
+		for _, name := range names {
+			if data, err := os.ReadFile(resources.path + viewDir + "/" + name); err == nil {
+				if data := parseDataFile(name, data); data != nil {
+					return data
+				}
 			}
 		}
 	}
@@ -160,6 +160,21 @@ func (style *viewStyle) backgroundCSS(session Session) string {
 	return ""
 }
 
+// styleSizeProperties is the list of size properties output by cssViewStyle
+var styleSizeProperties = []string{
+	Width, Height, MinWidth, MinHeight, MaxWidth, MaxHeight, Left, Right, Top, Bottom,
+	TextSize, TextIndent, LetterSpacing, WordSpacing, LineHeight, TextLineThickness,
+	GridRowGap, GridColumnGap, ColumnGap, ColumnWidth,
+}
+
+// styleColorProperties is the list of color properties output by cssViewStyle
+var styleColorProperties = []struct{ property, cssTag string }{
+	{BackgroundColor, BackgroundColor},
+	{TextColor, "color"},
+	{TextLineColor, "text-decoration-color"},
+	{CaretColor, CaretColor},
+}
+
 func (style *viewStyle) cssViewStyle(builder cssBuilder, session Session) {
 
 	if margin, ok := boundsProperty(style, Margin, session); ok {
@@ -180,7 +195,12 @@ func (style *viewStyle) cssViewStyle(builder cssBuilder, session Session) {
 	radius.cssValue(builder)
 
 	if outline := getOutline(style); outline != nil {
-		outline.ViewOutline(session).cssValue(builder)
+		viewOutline := outline.ViewOutline(session)
+		if color, ok := session.themeVariable(outline.getRaw(ColorTag), true); ok {
+			viewOutline.cssValueWithColor(builder, color)
+		} else {
+			viewOutline.cssValue(builder)
+		}
 	}
 
 	if z, ok := intProperty(style, ZIndex, session, 0); ok {
@@ -195,28 +215,22 @@ func (style *viewStyle) cssViewStyle(builder cssBuilder, session Session) {
 		builder.add(ColumnCount, strconv.Itoa(n))
 	}
 
-	for _, tag := range []string{
-		Width, Height, MinWidth, MinHeight, MaxWidth, MaxHeight, Left, Right, Top, Bottom,
-		TextSize, TextIndent, LetterSpacing, WordSpacing, LineHeight, TextLineThickness,
-		GridRowGap, GridColumnGap, ColumnGap, ColumnWidth} {
-
-		if size, ok := sizeProperty(style, tag, session); ok && size.Type != Auto {
-			cssTag, ok := sizeProperties[tag]
-			if !ok {
-				cssTag = tag
-			}
+	for _, tag := range styleSizeProperties {
+		cssTag, ok := sizeProperties[tag]
+		if !ok {
+			cssTag = tag
+		}
+		if value, ok := session.themeVariable(style.getRaw(tag), false); ok {
+			builder.add(cssTag, value)
+		} else if size, ok := sizeProperty(style, tag, session); ok && size.Type != Auto {
 			builder.add(cssTag, size.cssString(""))
 		}
 	}
 
-	colorProperties := []struct{ property, cssTag string }{
-		{BackgroundColor, BackgroundColor},
-		{TextColor, "color"},
-		{TextLineColor, "text-decoration-color"},
-		{CaretColor, CaretColor},
-	}
-	for _, p := range colorProperties {
-		if color, ok := colorProperty(style, p.property, session); ok && color != 0 {
+	for _, p := range styleColorProperties {
+		if value, ok := session.themeVariable(style.getRaw(p.property), true); ok {
+			builder.add(p.cssTag, value)
+		} else if color, ok := colorProperty(style, p.property, session); ok && color != 0 {
 			builder.add(p.cssTag, color.cssString())
 		}
 	}